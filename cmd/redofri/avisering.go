@@ -0,0 +1,46 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/redofri/redofri/pkg/avisering"
+)
+
+// runImportAvisering reads a Bolagsverket avisering package and writes its
+// registration metadata and parsed reports as JSON.
+func runImportAvisering(args []string) error {
+	inputPath, outputPath, err := parseIOFlags(args)
+	if err != nil {
+		return err
+	}
+	if inputPath == "" || inputPath == "-" {
+		return fmt.Errorf("missing input file\nUsage: redofri import-avisering [-o output.json] <package.zip>")
+	}
+
+	p, err := avisering.OpenPackage(inputPath)
+	if err != nil {
+		return fmt.Errorf("reading avisering package: %w", err)
+	}
+
+	for _, f := range p.Filings {
+		if f.ParseError != "" {
+			fmt.Fprintf(os.Stderr, "Warning: %s: %s\n", f.Kvittensnummer, f.ParseError)
+		}
+		if f.Notice == nil {
+			fmt.Fprintf(os.Stderr, "Warning: %s: no posttyp 920 record in the aviseringsfil\n", f.Kvittensnummer)
+		}
+	}
+	for _, name := range p.Other {
+		fmt.Fprintf(os.Stderr, "Warning: %s: not a report document or aviseringsfil\n", name)
+	}
+
+	out, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding JSON: %w", err)
+	}
+	out = append(out, '\n')
+
+	return writeOutput(outputPath, out, "Imported")
+}
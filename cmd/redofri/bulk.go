@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/redofri/redofri/pkg/bulk"
+)
+
+// runBulkParse parses every annual report in one or more daily avisering
+// packages and writes their key figures as CSV or JSON Lines.
+func runBulkParse(args []string) error {
+	const usage = "Usage: redofri bulk-parse [--format csv|jsonl] [--workers N] [-o output] <Arsredovisning_digital_ÅÅMMDD.zip>..."
+
+	format, outputPath := "csv", ""
+	var opts bulk.Options
+	var archives []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		name, value, hasValue := strings.Cut(arg, "=")
+		switch name {
+		case "--format", "-f", "--workers", "-o", "--output":
+			if !hasValue {
+				i++
+				if i >= len(args) {
+					return fmt.Errorf("%s requires a value", arg)
+				}
+				value = args[i]
+			}
+			switch name {
+			case "--format", "-f":
+				format = value
+			case "--workers":
+				n, err := strconv.Atoi(value)
+				if err != nil || n < 1 {
+					return fmt.Errorf("--workers must be a positive number, got %q", value)
+				}
+				opts.Workers = n
+			default:
+				outputPath = value
+			}
+		default:
+			if strings.HasPrefix(arg, "-") {
+				return fmt.Errorf("unknown flag: %s", arg)
+			}
+			archives = append(archives, arg)
+		}
+	}
+	if len(archives) == 0 {
+		return fmt.Errorf("missing input file\n%s", usage)
+	}
+
+	var out io.Writer = os.Stdout
+	if outputPath != "" {
+		f, err := os.Create(outputPath)
+		if err != nil {
+			return fmt.Errorf("writing %s: %w", outputPath, err)
+		}
+		defer f.Close()
+		out = f
+	}
+	bw := bufio.NewWriter(out)
+
+	var w bulk.Writer
+	switch format {
+	case "csv":
+		w = bulk.NewCSVWriter(bw)
+	case "jsonl":
+		w = bulk.NewJSONLWriter(bw)
+	default:
+		return fmt.Errorf("unknown format %q (available: csv, jsonl)", format)
+	}
+
+	total, failed := 0, 0
+	err := bulk.Run(context.Background(), archives, opts, func(r bulk.Record) error {
+		total++
+		if r.Error != "" {
+			failed++
+			fmt.Fprintf(os.Stderr, "Warning: %s: %s\n", strings.TrimSuffix(r.Archive+"/"+r.File, "/"), r.Error)
+		}
+		return w.Write(r)
+	})
+	if err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	if outputPath != "" {
+		fmt.Fprintf(os.Stderr, "Parsed %d report(s) from %d archive(s) to %s, %d failed\n", total-failed, len(archives), outputPath, failed)
+	}
+	return nil
+}
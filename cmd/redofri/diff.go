@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/redofri/redofri/pkg/diff"
+	"github.com/redofri/redofri/pkg/ixbrl"
+)
+
+// runDiff compares two reports and writes their differences. It reports
+// whether there were any, for the exit code: 0 when the reports are equal,
+// 1 when they differ.
+func runDiff(args []string) (bool, error) {
+	const usage = "Usage: redofri diff [--format text|json] [-o output] <a> <b>"
+
+	args, format, err := extractFormatFlag(args, "text")
+	if err != nil {
+		return false, err
+	}
+	var paths []string
+	outputPath := ""
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "-o" || arg == "--output":
+			i++
+			if i >= len(args) {
+				return false, fmt.Errorf("%s requires a value", arg)
+			}
+			outputPath = args[i]
+		case strings.HasPrefix(arg, "--output="):
+			outputPath = strings.TrimPrefix(arg, "--output=")
+		case strings.HasPrefix(arg, "-") && arg != "-":
+			return false, fmt.Errorf("unknown flag: %s", arg)
+		default:
+			paths = append(paths, arg)
+		}
+	}
+	if len(paths) != 2 {
+		return false, fmt.Errorf("expected two reports to compare\n%s", usage)
+	}
+	if format != "text" && format != "json" {
+		return false, fmt.Errorf("unknown format %q (available: json, text)", format)
+	}
+
+	a, err := loadDiffInput(paths[0])
+	if err != nil {
+		return false, err
+	}
+	b, err := loadDiffInput(paths[1])
+	if err != nil {
+		return false, err
+	}
+	result, err := diff.CompareInputs(a, b)
+	if err != nil {
+		return false, err
+	}
+
+	var buf bytes.Buffer
+	if format == "json" {
+		out, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return false, fmt.Errorf("encoding JSON: %w", err)
+		}
+		buf.Write(out)
+		buf.WriteByte('\n')
+	} else if err := result.WriteText(&buf); err != nil {
+		return false, err
+	}
+	if err := writeOutput(outputPath, buf.Bytes(), "Compared"); err != nil {
+		return false, err
+	}
+	return !result.Empty(), nil
+}
+
+// loadDiffInput reads a report to compare as loadAnyReport does, keeping
+// the facts of an iXBRL document or XBRL instance as they are written.
+func loadDiffInput(path string) (diff.Input, error) {
+	if !isDocumentPath(path) {
+		report, err := loadReport(path)
+		return diff.Input{Report: report}, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return diff.Input{}, fmt.Errorf("reading %s: %w", path, err)
+	}
+	report, err := parseDocument(path, data)
+	if err != nil {
+		return diff.Input{}, err
+	}
+	doc, err := ixbrl.ReadDocument(bytes.NewReader(data))
+	if err != nil {
+		return diff.Input{}, fmt.Errorf("reading facts of %s: %w", path, err)
+	}
+	return diff.Input{Report: report, Document: doc}, nil
+}
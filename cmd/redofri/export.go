@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/redofri/redofri/pkg/ixbrl"
+	"github.com/redofri/redofri/pkg/model"
+)
+
+// exporter writes one export format, either from a JSON report or from an
+// existing iXBRL document.
+type exporter struct {
+	fromReport   func(io.Writer, *model.AnnualReport) error
+	fromDocument func(io.Writer, io.Reader) error
+}
+
+// exporters maps the formats accepted by export --format to their writers.
+var exporters = map[string]exporter{
+	"xbrl":      {ixbrl.WriteInstance, ixbrl.ExtractInstance},
+	"xbrl-json": {ixbrl.WriteJSONFacts, ixbrl.ExtractJSONFacts},
+	"csv":       {ixbrl.WriteCSVFacts, ixbrl.ExtractCSVFacts},
+}
+
+// exportFormats returns the names of the export formats, sorted.
+func exportFormats() []string {
+	names := make([]string, 0, len(exporters))
+	for name := range exporters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// extractFormatFlag removes --format from args and returns its value, or def
+// when the flag is absent.
+func extractFormatFlag(args []string, def string) (rest []string, format string, err error) {
+	format = def
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "--format" || arg == "-f":
+			i++
+			if i >= len(args) {
+				return nil, "", fmt.Errorf("%s requires a value", arg)
+			}
+			format = args[i]
+		case strings.HasPrefix(arg, "--format="):
+			format = strings.TrimPrefix(arg, "--format=")
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	return rest, format, nil
+}
+
+// isInlineXBRLPath reports whether path names an iXBRL document rather
+// than JSON input.
+func isInlineXBRLPath(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".xhtml", ".html", ".htm":
+		return true
+	}
+	return false
+}
+
+// runExport converts a JSON report or an iXBRL document to an XBRL instance,
+// xBRL-JSON or CSV facts.
+func runExport(args []string) error {
+	args, format, err := extractFormatFlag(args, "xbrl")
+	if err != nil {
+		return err
+	}
+	inputPath, outputPath, err := parseIOFlags(args)
+	if err != nil {
+		return err
+	}
+	if inputPath == "" {
+		return fmt.Errorf("missing input file\nUsage: redofri export [--format xbrl|xbrl-json|csv] [-o output] <input.json|input.xhtml>")
+	}
+
+	exp, ok := exporters[format]
+	if !ok {
+		return fmt.Errorf("unknown format %q (available: %s)", format, strings.Join(exportFormats(), ", "))
+	}
+
+	var buf bytes.Buffer
+	if isInlineXBRLPath(inputPath) {
+		f, err := os.Open(inputPath)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", inputPath, err)
+		}
+		defer f.Close()
+		if err := exp.fromDocument(&buf, f); err != nil {
+			return fmt.Errorf("exporting %s: %w", format, err)
+		}
+	} else {
+		report, err := loadReport(inputPath)
+		if err != nil {
+			return err
+		}
+		if err := exp.fromReport(&buf, report); err != nil {
+			return fmt.Errorf("exporting %s: %w", format, err)
+		}
+	}
+
+	return writeOutput(outputPath, buf.Bytes(), "Exported")
+}
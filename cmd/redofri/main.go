@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/redofri/redofri/pkg/ixbrl"
@@ -31,11 +32,25 @@ func main() {
 
 	switch os.Args[1] {
 	case "validate":
-		if len(os.Args) < 3 {
-			fmt.Fprintf(os.Stderr, "Usage: redofri validate <input.json>\n")
+		if err := runValidate(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+	case "rules":
+		if err := runRules(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
-		if err := runValidate(os.Args[2]); err != nil {
+
+	case "explain-rule":
+		if err := runExplainRule(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+	case "lint":
+		if err := runLint(os.Args[2:]); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
@@ -58,18 +73,59 @@ func main() {
 			os.Exit(1)
 		}
 
+	case "render-pdf":
+		if err := runRenderPDF(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+	case "export":
+		if err := runExport(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
 	case "parse":
 		if err := runParse(os.Args[2:]); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 
+	case "coverage":
+		if err := runCoverage(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+	case "diff":
+		// Exit codes as for diff(1): 1 when the reports differ, 2 on errors.
+		differ, err := runDiff(os.Args[2:])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(2)
+		}
+		if differ {
+			os.Exit(1)
+		}
+
 	case "import-sie":
 		if err := runImportSIE(os.Args[2:]); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 
+	case "import-avisering":
+		if err := runImportAvisering(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+	case "bulk-parse":
+		if err := runBulkParse(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
 	case "demo-generate":
 		if err := runDemoGenerate(os.Args[2:]); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -94,22 +150,75 @@ func printUsage() {
 
 Usage:
 	redofri validate <input.json>         Load and validate JSON input
+	redofri rules                         List the validation rules
+	redofri explain-rule <rule|code>      Explain a rule, by id or BV code
+	redofri lint <file.xhtml>             Check an iXBRL file against Bolagsverket's technical rules
 	redofri generate <input.json>         Generate iXBRL to stdout
 	redofri generate -o <out> <input>     Generate iXBRL to file
 	redofri check <input.json>            Validate, generate, and remote-check a submission
 	redofri submit <input.json>           Validate, generate, check, and submit a report
-	redofri parse <input.xhtml>           Parse iXBRL to JSON (stdout)
-	redofri parse -o <out> <input>        Parse iXBRL to JSON file
+	redofri render-pdf -o <out> <input>   Render the report as an A4 PDF file
+	redofri export --format <f> <input>   Export JSON or iXBRL as xbrl, xbrl-json or csv
+	redofri parse <input.xhtml|.xbrl>     Parse iXBRL or XBRL to JSON (stdout)
+	redofri parse -o <out> <input>        Parse iXBRL or XBRL to JSON file
+	redofri diff <a> <b>                  Compare two reports (JSON, .xhtml or .xbrl)
+	redofri coverage <taxonomy>           List the taxonomy concepts redofri supports
 	redofri import-sie <input.sie>        Import SIE4 to partial JSON (stdout)
 	redofri import-sie -o <out> <input>   Import SIE4 to partial JSON file
+	redofri import-avisering <pkg.zip>    Read a Bolagsverket avisering package to JSON
+	redofri bulk-parse <pkg.zip>...       Extract key figures from avisering packages as CSV
   redofri demo-generate                Generate demo iXBRL to redofri-demo.xhtml
   redofri demo-generate -o <out>       Generate demo iXBRL to file
   redofri version                       Show version
   redofri help                          Show this help
 
-	Flags (generate, parse, import-sie):
+	Flags (generate, render-pdf, export, parse, diff, coverage, import-sie,
+	       import-avisering, bulk-parse):
 	  -o, --output <file>   Write output to file (default: stdout)
 
+	Presentation flags (generate, check, submit):
+	  --theme <name>        Built-in theme: standard, print, compact
+	  --css <file>          Extra CSS appended to the theme stylesheet
+	  --cover-template <f>  html/template replacing the cover title
+	  --header-template <f> html/template replacing the page header contents
+
+	Bulk flags (bulk-parse):
+	  --format <f>          Output format: csv (default) or jsonl
+	  --workers <n>         Reports parsed in parallel (default: number of CPUs)
+
+	Taxonomy flags (validate, generate):
+	  --taxonomy <pkg>      Local taxonomy package (directory or ZIP): validate
+	                        runs its calculation linkbase, generate uses its labels
+	  --only-declared       With --taxonomy, generate leaves the concepts the
+	                        package does not declare untagged and names them
+
+	Rule flags (validate, check, submit):
+	  --rules <file>        JSON rule configuration: enable or disable rules,
+	                        override severities, suppress findings with a justification
+
+	Validate flags (validate):
+	  --format <f>          Output format: text (default) or json, with the
+	                        catalogue entry of each finding's rule
+	  --previous <file>     Last year's filed report (.xhtml, .xbrl or JSON) to
+	                        compare the comparatives with
+
+	Rules flags (rules):
+	  --format <f>          Output format: text (default) or json
+	  --lang <l>            Description language: sv (default) or en
+
+	Coverage flags (coverage):
+	  --format <f>          Output format: text (default) or json
+	  --lang <l>            Label language: sv (default) or en
+
+	Diff flags (diff):
+	  --format <f>          Output format: text (default) or json
+	  Exits with 0 when the reports are equal, 1 when they differ, 2 on errors.
+
+	PDF flags (render-pdf):
+	  --pdfa                Produce PDF/A-1b for archiving (requires --font)
+	  --font <file.ttf>     TrueType font to embed instead of Helvetica
+	  --bold-font <f.ttf>   TrueType font to embed for headings
+
 	Submission flags (check, submit):
 	  --base-url <url>      Submission API base URL
 	  --api-key <key>       Submission API bearer token
@@ -122,7 +231,7 @@ Usage:
 	  --email <addr>        Add recipient to epostadresser
 	  --receipt-email <a>   Add recipient to kvittensepostadresser
 	  --notify-email <a>    Add recipient to notifieringEpostadresser
-	  --document-type <t>   Handling type (default: arsredovisning_komplett)
+	  --document-type <t>   Handling type, checked before any call (default: arsredovisning_komplett)
 
 	Input can be a file path or "-" to read from stdin.
 `, version)
@@ -130,6 +239,18 @@ Usage:
 
 // runGenerate parses flags, loads JSON input, and generates iXBRL output.
 func runGenerate(args []string) error {
+	args, theme, err := extractThemeFlags(args)
+	if err != nil {
+		return err
+	}
+	args, tax, err := extractTaxonomyFlag(args)
+	if err != nil {
+		return err
+	}
+	args, onlyDeclared := extractOnlyDeclaredFlag(args)
+	if onlyDeclared && tax == nil {
+		return fmt.Errorf("--only-declared requires --taxonomy")
+	}
 	inputPath, outputPath, err := parseIOFlags(args)
 	if err != nil {
 		return err
@@ -143,22 +264,35 @@ func runGenerate(args []string) error {
 		return err
 	}
 
+	opts := ixbrl.Options{Theme: theme}
+	if tax != nil {
+		opts.Labels = tax
+	}
+	if onlyDeclared {
+		if opts.Release, err = declaredRelease(report, tax); err != nil {
+			return err
+		}
+	}
 	var buf bytes.Buffer
-	if err := ixbrl.Generate(&buf, report); err != nil {
+	if err := ixbrl.GenerateWithOptions(&buf, report, opts); err != nil {
 		return fmt.Errorf("generating iXBRL: %w", err)
 	}
+	if err := ixbrl.CheckDocument(buf.Bytes()); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
 
 	return writeOutput(outputPath, buf.Bytes(), "Generated")
 }
 
-// runParse reads an iXBRL file, parses it, and writes JSON output.
+// runParse reads an iXBRL or XBRL instance file, parses it, and writes
+// JSON output.
 func runParse(args []string) error {
 	inputPath, outputPath, err := parseIOFlags(args)
 	if err != nil {
 		return err
 	}
 	if inputPath == "" {
-		return fmt.Errorf("missing input file\nUsage: redofri parse [-o output.json] <input.xhtml>")
+		return fmt.Errorf("missing input file\nUsage: redofri parse [-o output.json] <input.xhtml|input.xbrl>")
 	}
 
 	var r io.Reader
@@ -173,12 +307,19 @@ func runParse(args []string) error {
 		r = f
 	}
 
-	report, err := ixbrl.Parse(r)
+	result, err := ixbrl.Parse(r)
 	if err != nil {
 		return fmt.Errorf("parsing iXBRL: %w", err)
 	}
 
-	out, err := json.MarshalIndent(report, "", "  ")
+	for _, w := range result.Warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+	}
+	for _, e := range result.Errors {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", e)
+	}
+
+	out, err := json.MarshalIndent(result.Report, "", "  ")
 	if err != nil {
 		return fmt.Errorf("encoding JSON: %w", err)
 	}
@@ -250,47 +391,239 @@ func loadReport(path string) (*model.AnnualReport, error) {
 		return nil, fmt.Errorf("parsing JSON: %w", err)
 	}
 
+	baseDir := "."
+	if path != "-" {
+		baseDir = filepath.Dir(path)
+	}
+	if err := loadImage(report.Company.Logo, baseDir); err != nil {
+		return nil, fmt.Errorf("loading company logo: %w", err)
+	}
+
 	return &report, nil
 }
 
+// loadAnyReport reads a report from JSON, or parses it from an iXBRL
+// document or XBRL instance. Facts that cannot be read are listed as
+// warnings.
+func loadAnyReport(path string) (*model.AnnualReport, error) {
+	if !isDocumentPath(path) {
+		return loadReport(path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return parseDocument(path, data)
+}
+
+// isDocumentPath reports whether path names an iXBRL document or XBRL
+// instance rather than a JSON report.
+func isDocumentPath(path string) bool {
+	return isInlineXBRLPath(path) || strings.EqualFold(filepath.Ext(path), ".xbrl")
+}
+
+// parseDocument parses a report from an iXBRL document or XBRL instance
+// read from path.
+func parseDocument(path string, data []byte) (*model.AnnualReport, error) {
+	result, err := ixbrl.Parse(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	for _, e := range result.Errors {
+		fmt.Fprintf(os.Stderr, "Warning: %s: %v\n", path, e)
+	}
+	return result.Report, nil
+}
+
+// loadImage reads an image referenced by path into img.Data. Relative paths
+// are resolved against baseDir. Images that already carry data are left as is.
+func loadImage(img *model.Image, baseDir string) error {
+	if img == nil || len(img.Data) > 0 || img.Path == "" {
+		return nil
+	}
+	p := img.Path
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(baseDir, p)
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return err
+	}
+	img.Data = data
+	return nil
+}
+
+// extractPreviousFlag removes --previous <file> from args and loads last
+// year's filed report from it, as loadAnyReport does.
+func extractPreviousFlag(args []string) (rest []string, previous *model.AnnualReport, err error) {
+	path := ""
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "--previous":
+			i++
+			if i >= len(args) {
+				return nil, nil, fmt.Errorf("%s requires last year's filed report", arg)
+			}
+			path = args[i]
+		case strings.HasPrefix(arg, "--previous="):
+			path = strings.TrimPrefix(arg, "--previous=")
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	if path == "" {
+		return rest, nil, nil
+	}
+	previous, err = loadAnyReport(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rest, previous, nil
+}
+
 // runValidate loads a JSON file, runs all validation checks, and prints findings.
 // Exits with code 1 if there are errors.
-func runValidate(path string) error {
+func runValidate(args []string) error {
+	args, format, err := extractFormatFlag(args, "text")
+	if err != nil {
+		return err
+	}
+	args, tax, err := extractTaxonomyFlag(args)
+	if err != nil {
+		return err
+	}
+	args, cfg, err := extractRulesFlag(args)
+	if err != nil {
+		return err
+	}
+	args, previous, err := extractPreviousFlag(args)
+	if err != nil {
+		return err
+	}
+	path, _, err := parseIOFlags(args)
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		return fmt.Errorf("missing input file\nUsage: redofri validate [--taxonomy <package>] [--rules <config.json>] [--previous <filed report>] [--format text|json] <input.json>")
+	}
+	if format != "text" && format != "json" {
+		return fmt.Errorf("unknown format %q (available: json, text)", format)
+	}
+	var extra []validate.Rule
+	if tax != nil {
+		rule, err := validate.TaxonomyCalculationRule(tax)
+		if err != nil {
+			return err
+		}
+		extra = append(extra, rule)
+	}
+	if previous != nil {
+		rules, err := validate.PreviousReportRules(previous)
+		if err != nil {
+			return err
+		}
+		extra = append(extra, rules...)
+	}
+	validator, err := validate.NewValidator(cfg, extra...)
+	if err != nil {
+		return err
+	}
 	report, err := loadReport(path)
 	if err != nil {
 		return err
 	}
+	results, suppressed := validator.Validate(report)
+	entries := catalogueByID(validator.Catalogue())
+
+	if format == "json" {
+		out, err := validationJSON(results, suppressed, entries)
+		if err != nil {
+			return err
+		}
+		os.Stdout.Write(out)
+		if errors := countErrors(results); errors > 0 {
+			return fmt.Errorf("validation failed with %d error(s)", errors)
+		}
+		return nil
+	}
 
 	fmt.Printf("Company:      %s (%s)\n", report.Company.Name, report.Company.OrgNr)
 	fmt.Printf("Fiscal year:  %s – %s\n", report.FiscalYear.StartDate, report.FiscalYear.EndDate)
 	fmt.Printf("Entry point:  %s\n", report.Meta.EntryPoint)
+	if report.Meta.TaxonomyVersion != "" {
+		fmt.Printf("Taxonomy:     K2 %s\n", report.Meta.TaxonomyVersion)
+	}
 	fmt.Println()
 
-	results := validate.Validate(report)
-
 	if len(results) == 0 {
 		fmt.Println("Validation passed: no errors or warnings.")
+		printSuppressed(suppressed)
 		return nil
 	}
+	errors := printResults(results, entries)
+	printSuppressed(suppressed)
+	if errors > 0 {
+		return fmt.Errorf("validation failed with %d error(s)", errors)
+	}
+	return nil
+}
 
-	var errors, warnings int
-	for _, r := range results {
-		fmt.Println(r)
-		if r.Severity == validate.Error {
-			errors++
-		} else {
-			warnings++
-		}
+// runLint checks an iXBRL document against Bolagsverket's technical rules.
+func runLint(args []string) error {
+	inputPath, _, err := parseIOFlags(args)
+	if err != nil {
+		return err
+	}
+	if inputPath == "" {
+		return fmt.Errorf("missing input file\nUsage: redofri lint <file.xhtml>")
 	}
 
-	fmt.Printf("\n%d error(s), %d warning(s)\n", errors, warnings)
+	var doc []byte
+	if inputPath == "-" {
+		doc, err = io.ReadAll(os.Stdin)
+	} else {
+		doc, err = os.ReadFile(inputPath)
+	}
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", inputPath, err)
+	}
 
-	if errors > 0 {
-		return fmt.Errorf("validation failed with %d error(s)", errors)
+	results := validate.Lint(doc)
+	if len(results) == 0 {
+		fmt.Println("Lint passed: no errors or warnings.")
+		return nil
+	}
+	if errors := printResults(results, nil); errors > 0 {
+		return fmt.Errorf("lint failed with %d error(s)", errors)
 	}
 	return nil
 }
 
+// printResults prints validation results, with the remediation hints of
+// their rules in entries, and a summary line. It returns the number of
+// errors.
+func printResults(results []validate.Result, entries map[string]validate.CatalogueEntry) int {
+	for _, r := range results {
+		fmt.Println(r)
+		printHints(r, entries)
+	}
+	errors := countErrors(results)
+	fmt.Printf("\n%d error(s), %d warning(s)\n", errors, len(results)-errors)
+	return errors
+}
+
+// countErrors returns the number of error results.
+func countErrors(results []validate.Result) int {
+	n := 0
+	for _, r := range results {
+		if r.Severity == validate.Error {
+			n++
+		}
+	}
+	return n
+}
+
 // runImportSIE reads a SIE4 file, parses it, and writes a partial JSON report.
 func runImportSIE(args []string) error {
 	inputPath, outputPath, err := parseIOFlags(args)
@@ -1,7 +1,10 @@
 package main
 
 import (
+	"archive/zip"
+	"bytes"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -72,6 +75,39 @@ func TestGenerateCommand(t *testing.T) {
 		}
 	})
 
+	t.Run("generate with theme", func(t *testing.T) {
+		cssPath := filepath.Join(tmpDir, "extra.css")
+		if err := os.WriteFile(cssPath, []byte(".ar-page { color: rgb(1, 2, 3); }\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		cmd := exec.Command(bin, "generate", "--theme", "print", "--css", cssPath, inputPath)
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("generate failed: %v", err)
+		}
+		if !strings.Contains(string(out), "rgb(1, 2, 3)") {
+			t.Error("user CSS not included in output")
+		}
+	})
+
+	t.Run("generate with unsafe css", func(t *testing.T) {
+		cssPath := filepath.Join(tmpDir, "unsafe.css")
+		if err := os.WriteFile(cssPath, []byte(`@import "https://example.com/x.css";`), 0644); err != nil {
+			t.Fatal(err)
+		}
+		cmd := exec.Command(bin, "generate", "--css", cssPath, inputPath)
+		if err := cmd.Run(); err == nil {
+			t.Fatal("expected error for unsafe CSS")
+		}
+	})
+
+	t.Run("generate with unknown theme", func(t *testing.T) {
+		cmd := exec.Command(bin, "generate", "--theme", "nope", inputPath)
+		if err := cmd.Run(); err == nil {
+			t.Fatal("expected error for unknown theme")
+		}
+	})
+
 	t.Run("missing input", func(t *testing.T) {
 		cmd := exec.Command(bin, "generate")
 		if err := cmd.Run(); err == nil {
@@ -79,6 +115,121 @@ func TestGenerateCommand(t *testing.T) {
 		}
 	})
 
+	t.Run("render-pdf to file", func(t *testing.T) {
+		outPath := filepath.Join(tmpDir, "output.pdf")
+		cmd := exec.Command(bin, "render-pdf", "-o", outPath, inputPath)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("render-pdf failed: %v\n%s", err, out)
+		}
+
+		data, err := os.ReadFile(outPath)
+		if err != nil {
+			t.Fatalf("output file not found: %v", err)
+		}
+		if !strings.HasPrefix(string(data), "%PDF-1.4") {
+			t.Errorf("output is not a PDF: %q", string(data[:min(len(data), 20)]))
+		}
+	})
+
+	t.Run("render-pdf --pdfa without font", func(t *testing.T) {
+		cmd := exec.Command(bin, "render-pdf", "--pdfa", "-o", filepath.Join(tmpDir, "pdfa.pdf"), inputPath)
+		out, err := cmd.CombinedOutput()
+		if err == nil {
+			t.Fatal("expected error for --pdfa without --font")
+		}
+		if !strings.Contains(string(out), "font") {
+			t.Errorf("unexpected error output:\n%s", out)
+		}
+	})
+
+	t.Run("export xbrl", func(t *testing.T) {
+		outPath := filepath.Join(tmpDir, "output.xbrl")
+		cmd := exec.Command(bin, "export", "--format", "xbrl", "-o", outPath, inputPath)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("export failed: %v\n%s", err, out)
+		}
+		data, err := os.ReadFile(outPath)
+		if err != nil {
+			t.Fatalf("output file not found: %v", err)
+		}
+		if !strings.Contains(string(data), "<xbrli:xbrl") {
+			t.Error("output is not an XBRL instance")
+		}
+	})
+
+	t.Run("parse xbrl", func(t *testing.T) {
+		// Parse the instance written by "export xbrl" back to JSON.
+		cmd := exec.Command(bin, "parse", filepath.Join(tmpDir, "output.xbrl"))
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("parse failed: %v\n%s", err, stderr.String())
+		}
+		var parsed, input struct {
+			Company struct {
+				Name  string `json:"name"`
+				OrgNr string `json:"orgNr"`
+			} `json:"company"`
+		}
+		if err := json.Unmarshal(out, &parsed); err != nil {
+			t.Fatalf("parse output is not valid JSON: %v", err)
+		}
+		data, err := os.ReadFile(inputPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		json.Unmarshal(data, &input)
+		if parsed.Company != input.Company || parsed.Company.Name == "" {
+			t.Errorf("company = %+v, want %+v", parsed.Company, input.Company)
+		}
+	})
+
+	t.Run("export xbrl from ixbrl", func(t *testing.T) {
+		cmd := exec.Command(bin, "export", "--format=xbrl", filepath.Join("..", "..", "ref", "exempel", "faststalld-arsredovisning-exempel-1.xhtml"))
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("export failed: %v", err)
+		}
+		if !strings.Contains(string(out), "se-k2-risbs-2017-09-30.xsd") {
+			t.Error("instance does not reference the document's schema")
+		}
+	})
+
+	t.Run("export xbrl-json and csv", func(t *testing.T) {
+		out, err := exec.Command(bin, "export", "--format", "xbrl-json", inputPath).Output()
+		if err != nil {
+			t.Fatalf("export xbrl-json failed: %v", err)
+		}
+		var report struct {
+			DocumentInfo struct {
+				DocumentType string `json:"documentType"`
+			} `json:"documentInfo"`
+			Facts map[string]json.RawMessage `json:"facts"`
+		}
+		if err := json.Unmarshal(out, &report); err != nil {
+			t.Fatalf("invalid xBRL-JSON: %v", err)
+		}
+		if report.DocumentInfo.DocumentType != "https://xbrl.org/2021/xbrl-json" || len(report.Facts) == 0 {
+			t.Errorf("unexpected xBRL-JSON report: %s", out[:min(len(out), 200)])
+		}
+
+		out, err = exec.Command(bin, "export", "--format", "csv", inputPath).Output()
+		if err != nil {
+			t.Fatalf("export csv failed: %v", err)
+		}
+		if !strings.HasPrefix(string(out), "entity,concept,") || !strings.Contains(string(out), "se-gen-base:Nettoomsattning") {
+			t.Errorf("unexpected CSV output: %s", out[:min(len(out), 200)])
+		}
+	})
+
+	t.Run("export unknown format", func(t *testing.T) {
+		cmd := exec.Command(bin, "export", "--format", "nope", inputPath)
+		if err := cmd.Run(); err == nil {
+			t.Fatal("expected error for unknown format")
+		}
+	})
+
 	t.Run("validate command", func(t *testing.T) {
 		cmd := exec.Command(bin, "validate", inputPath)
 		out, err := cmd.CombinedOutput()
@@ -90,6 +241,186 @@ func TestGenerateCommand(t *testing.T) {
 		}
 	})
 
+	t.Run("validate with rules", func(t *testing.T) {
+		report, err := os.ReadFile(inputPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		report = bytes.Replace(report, []byte(`"date": "2017-02-20"`), []byte(`"date": "2017-04-01"`), 1)
+		reportPath := filepath.Join(tmpDir, "late-signing.json")
+		if err := os.WriteFile(reportPath, report, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		out, err := exec.Command(bin, "validate", reportPath).CombinedOutput()
+		if err != nil || !strings.Contains(string(out), "WARN [BV 1183]") {
+			t.Fatalf("validate: %v\n%s", err, out)
+		}
+
+		rulesPath := filepath.Join(tmpDir, "rules.json")
+		rules := `{"rules": {"agm-after-signing": {"severity": "error"}}}`
+		if err := os.WriteFile(rulesPath, []byte(rules), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		out, err = exec.Command(bin, "validate", "--rules", rulesPath, reportPath).CombinedOutput()
+		if err == nil || !strings.Contains(string(out), "ERROR [BV 1183]") {
+			t.Errorf("validate with severity override: %v\n%s", err, out)
+		}
+
+		rules = `{"suppressions": [{"rule": "agm-after-signing", "justification": "Extra meeting"}]}`
+		if err := os.WriteFile(rulesPath, []byte(rules), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		out, err = exec.Command(bin, "validate", "--rules="+rulesPath, reportPath).CombinedOutput()
+		if err != nil || !strings.Contains(string(out), "Validation passed") || !strings.Contains(string(out), "justification: Extra meeting") {
+			t.Errorf("validate with suppression: %v\n%s", err, out)
+		}
+
+		rules = `{"rules": {"no-such-rule": {"enabled": false}}}`
+		if err := os.WriteFile(rulesPath, []byte(rules), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		out, err = exec.Command(bin, "validate", "--rules", rulesPath, reportPath).CombinedOutput()
+		if err == nil || !strings.Contains(string(out), `unknown rule "no-such-rule"`) {
+			t.Errorf("validate with unknown rule: %v\n%s", err, out)
+		}
+	})
+
+	t.Run("rules", func(t *testing.T) {
+		out, err := exec.Command(bin, "rules", "--format", "json").Output()
+		if err != nil {
+			t.Fatalf("rules: %v", err)
+		}
+		var entries []struct {
+			ID   string
+			Code int
+			SV   string
+		}
+		if err := json.Unmarshal(out, &entries); err != nil || len(entries) == 0 || entries[0].ID != "company-name" || entries[0].SV == "" {
+			t.Errorf("rules = %s (%v)", out, err)
+		}
+
+		out, err = exec.Command(bin, "explain-rule", "1114").CombinedOutput()
+		if err != nil || !strings.Contains(string(out), "Rule:         signing-after-year-end") {
+			t.Errorf("explain-rule 1114: %v\n%s", err, out)
+		}
+		if out, err := exec.Command(bin, "explain-rule", "no-such-rule").CombinedOutput(); err == nil {
+			t.Errorf("explain-rule of unknown rule: no error\n%s", out)
+		}
+
+		report, err := os.ReadFile(inputPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		report = bytes.Replace(report, []byte(`"date": "2017-02-20"`), []byte(`"date": "2016-12-31"`), 1)
+		reportPath := filepath.Join(tmpDir, "early-signing.json")
+		if err := os.WriteFile(reportPath, report, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		out, _ = exec.Command(bin, "validate", reportPath).CombinedOutput()
+		if !strings.Contains(string(out), "fix: ") || !strings.Contains(string(out), "explain-rule signing-after-year-end") {
+			t.Errorf("validate without hint:\n%s", out)
+		}
+		out, err = exec.Command(bin, "validate", "--format", "json", reportPath).Output()
+		if err == nil {
+			t.Error("validate --format json: no error")
+		}
+		var doc struct {
+			Results []struct {
+				Rule  string
+				Entry *struct{ Remediation string }
+			}
+		}
+		if err := json.Unmarshal(out, &doc); err != nil || len(doc.Results) == 0 ||
+			doc.Results[0].Rule != "signing-after-year-end" || doc.Results[0].Entry == nil || doc.Results[0].Entry.Remediation == "" {
+			t.Errorf("validate --format json = %s (%v)", out, err)
+		}
+	})
+
+	t.Run("validate --previous", func(t *testing.T) {
+		filedPath := filepath.Join(tmpDir, "filed.xhtml")
+		if out, err := exec.Command(bin, "generate", "-o", filedPath, inputPath).CombinedOutput(); err != nil {
+			t.Fatalf("generate: %v\n%s", err, out)
+		}
+		// The same year's report is not last year's.
+		out, err := exec.Command(bin, "validate", "--previous", filedPath, inputPath).CombinedOutput()
+		if err == nil || !strings.Contains(string(out), "fiscalYear.startDate") || !strings.Contains(string(out), "last year's filed report ends 2016-12-31") {
+			t.Errorf("validate --previous: %v\n%s", err, out)
+		}
+		if out, err := exec.Command(bin, "validate", "--previous", filepath.Join(tmpDir, "missing.xhtml"), inputPath).CombinedOutput(); err == nil {
+			t.Errorf("validate --previous with a missing file: no error\n%s", out)
+		}
+	})
+
+	t.Run("lint command", func(t *testing.T) {
+		xhtmlPath := filepath.Join(tmpDir, "lint.xhtml")
+		if out, err := exec.Command(bin, "generate", "-o", xhtmlPath, inputPath).CombinedOutput(); err != nil {
+			t.Fatalf("generate: %v\n%s", err, out)
+		}
+		out, err := exec.Command(bin, "lint", xhtmlPath).CombinedOutput()
+		if err != nil || !strings.Contains(string(out), "Lint passed") {
+			t.Fatalf("lint of generated file: %v\n%s", err, out)
+		}
+
+		// A document with a script fails.
+		doc, err := os.ReadFile(xhtmlPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		doc = bytes.Replace(doc, []byte("<body>"), []byte("<body><script>alert(1)</script>"), 1)
+		if err := os.WriteFile(xhtmlPath, doc, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		out, err = exec.Command(bin, "lint", xhtmlPath).CombinedOutput()
+		if err == nil || !strings.Contains(string(out), "ERROR [BV 5005]") {
+			t.Errorf("lint of document with script: %v\n%s", err, out)
+		}
+	})
+
+	t.Run("taxonomy", func(t *testing.T) {
+		taxPath := filepath.Join("..", "..", "testdata", "taxonomy", "k2")
+
+		out, err := exec.Command(bin, "validate", "--taxonomy", taxPath, inputPath).CombinedOutput()
+		if err != nil || !strings.Contains(string(out), "Validation passed") {
+			t.Errorf("validate --taxonomy: %v\n%s", err, out)
+		}
+
+		full, err := exec.Command(bin, "generate", inputPath).Output()
+		if err != nil {
+			t.Fatalf("generate: %v", err)
+		}
+		out, err = exec.Command(bin, "generate", "--taxonomy", taxPath, inputPath).Output()
+		if err != nil || !strings.Contains(string(out), ">Summa rörelseintäkter, lagerförändringar m.m.</td>") {
+			t.Errorf("generate --taxonomy: %v", err)
+		}
+		// The package declares only some concepts; its labels must not
+		// untag the rest.
+		if got, want := strings.Count(string(out), "<ix:nonFraction"), strings.Count(string(full), "<ix:nonFraction"); got != want {
+			t.Errorf("generate --taxonomy writes %d numeric facts, want %d", got, want)
+		}
+
+		cmd := exec.Command(bin, "generate", "--taxonomy", taxPath, "--only-declared", inputPath)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		out, err = cmd.Output()
+		if err != nil || !strings.Contains(stderr.String(), "se-gen-base:Soliditet") ||
+			strings.Contains(string(out), `name="se-gen-base:Soliditet"`) ||
+			!strings.Contains(string(out), `name="se-gen-base:Nettoomsattning"`) {
+			t.Errorf("generate --only-declared: %v\n%s", err, stderr.String())
+		}
+		if err := exec.Command(bin, "generate", "--only-declared", inputPath).Run(); err == nil {
+			t.Error("--only-declared without --taxonomy accepted")
+		}
+
+		out, err = exec.Command(bin, "coverage", "--format", "json", taxPath).Output()
+		if err != nil {
+			t.Fatalf("coverage: %v", err)
+		}
+		var cov struct{ Concepts, Supported int }
+		if err := json.Unmarshal(out, &cov); err != nil || cov.Concepts != 17 || cov.Supported != 15 {
+			t.Errorf("coverage = %+v (%v)", cov, err)
+		}
+	})
+
 	t.Run("demo-generate command writes default file", func(t *testing.T) {
 		workDir := t.TempDir()
 		cmd := exec.Command(bin, "demo-generate")
@@ -174,12 +505,29 @@ func TestGenerateCommand(t *testing.T) {
 			t.Fatalf("generate for parse test: %v\n%s", err, out)
 		}
 
+		// Add a fact in a context the document does not define.
+		doc, err := os.ReadFile(xhtmlPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		doc = bytes.Replace(doc, []byte("</ix:hidden>"),
+			[]byte(`<ix:nonNumeric name="se-gen-base:Okand" contextRef="saknas">x</ix:nonNumeric></ix:hidden>`), 1)
+		if err := os.WriteFile(xhtmlPath, doc, 0o644); err != nil {
+			t.Fatal(err)
+		}
+
 		// Parse it back.
 		parseCmd := exec.Command(bin, "parse", xhtmlPath)
+		var stderr bytes.Buffer
+		parseCmd.Stderr = &stderr
 		out, err := parseCmd.Output()
 		if err != nil {
 			t.Fatalf("parse failed: %v", err)
 		}
+		// Facts that cannot be placed are listed as warnings.
+		if !strings.Contains(stderr.String(), `Warning: context "saknas" is not defined`) {
+			t.Errorf("expected parse warnings on stderr, got: %s", stderr.String())
+		}
 		// Output should be JSON starting with '{'.
 		if len(out) == 0 || out[0] != '{' {
 			preview := string(out)
@@ -213,6 +561,176 @@ func TestGenerateCommand(t *testing.T) {
 		}
 	})
 
+	t.Run("import-avisering", func(t *testing.T) {
+		xhtmlPath := filepath.Join(tmpDir, "avisering.xhtml")
+		genCmd := exec.Command(bin, "generate", "-o", xhtmlPath, inputPath)
+		if out, err := genCmd.CombinedOutput(); err != nil {
+			t.Fatalf("generate: %v\n%s", err, out)
+		}
+		xhtml, err := os.ReadFile(xhtmlPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		for name, data := range map[string][]byte{
+			"6100000022.xhtml":  xhtml,
+			"aviseringsfil.txt": []byte("POSTTYP;KVITTENSNR;ORGNR;REGDATUM\n920;6100000022;5569999999;20170320\n"),
+		} {
+			w, _ := zw.Create(name)
+			w.Write(data)
+		}
+		zw.Close()
+		zipPath := filepath.Join(tmpDir, "Arsredovisning_digital_170321.zip")
+		if err := os.WriteFile(zipPath, buf.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		out, err := exec.Command(bin, "import-avisering", zipPath).Output()
+		if err != nil {
+			t.Fatalf("import-avisering: %v", err)
+		}
+		var pkg struct {
+			Date    string `json:"date"`
+			Filings []struct {
+				Kvittensnummer string `json:"kvittensnummer"`
+				Notice         struct {
+					RegistrationDate string `json:"registrationDate"`
+				} `json:"notice"`
+				Report struct {
+					Company struct {
+						Name string `json:"name"`
+					} `json:"company"`
+				} `json:"report"`
+			} `json:"filings"`
+		}
+		if err := json.Unmarshal(out, &pkg); err != nil {
+			t.Fatalf("decoding output: %v", err)
+		}
+		if pkg.Date != "2017-03-21" || len(pkg.Filings) != 1 {
+			t.Fatalf("package = %+v", pkg)
+		}
+		f := pkg.Filings[0]
+		if f.Kvittensnummer != "6100000022" || f.Notice.RegistrationDate != "2017-03-20" || f.Report.Company.Name == "" {
+			t.Errorf("filing = %+v", f)
+		}
+	})
+
+	t.Run("diff", func(t *testing.T) {
+		exitCode := func(err error) int {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				return exitErr.ExitCode()
+			}
+			if err != nil {
+				t.Fatalf("running diff: %v", err)
+			}
+			return 0
+		}
+
+		// The iXBRL document and XBRL instance of one report are equal.
+		ref := filepath.Join("..", "..", "ref", "exempel", "faststalld-arsredovisning-exempel-1")
+		out, err := exec.Command(bin, "diff", ref+".xhtml", ref+".xbrl").Output()
+		if code := exitCode(err); code != 0 || string(out) != "No differences.\n" {
+			t.Errorf("diff of equal reports: exit %d, output:\n%s", code, out)
+		}
+
+		// An edited JSON input differs from its generated iXBRL.
+		xhtmlPath := filepath.Join(tmpDir, "diff.xhtml")
+		if out, err := exec.Command(bin, "generate", "-o", xhtmlPath, inputPath).CombinedOutput(); err != nil {
+			t.Fatalf("generate: %v\n%s", err, out)
+		}
+		data, err := os.ReadFile(inputPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var report map[string]any
+		if err := json.Unmarshal(data, &report); err != nil {
+			t.Fatal(err)
+		}
+		report["company"].(map[string]any)["name"] = "Ändrat Namn AB"
+		edited, _ := json.Marshal(report)
+		editedPath := filepath.Join(tmpDir, "diff.json")
+		if err := os.WriteFile(editedPath, edited, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		out, err = exec.Command(bin, "diff", "--format", "json", xhtmlPath, editedPath).Output()
+		if code := exitCode(err); code != 1 {
+			t.Errorf("diff of changed reports: exit %d, want 1", code)
+		}
+		var result struct {
+			Facts []struct {
+				Change  string `json:"change"`
+				Concept string `json:"concept"`
+				New     string `json:"new"`
+			} `json:"facts"`
+		}
+		if err := json.Unmarshal(out, &result); err != nil {
+			t.Fatalf("invalid JSON output: %v\n%s", err, out)
+		}
+		found := false
+		for _, c := range result.Facts {
+			if c.Concept == "se-cd-base:ForetagetsNamn" && c.Change == "changed" && c.New == "Ändrat Namn AB" {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("company name change not reported:\n%s", out)
+		}
+
+		// Errors exit with 2.
+		err = exec.Command(bin, "diff", inputPath, filepath.Join(tmpDir, "saknas.json")).Run()
+		if code := exitCode(err); code != 2 {
+			t.Errorf("diff with missing file: exit %d, want 2", code)
+		}
+	})
+
+	t.Run("bulk-parse", func(t *testing.T) {
+		xhtmlPath := filepath.Join(tmpDir, "bulk.xhtml")
+		genCmd := exec.Command(bin, "generate", "-o", xhtmlPath, inputPath)
+		if out, err := genCmd.CombinedOutput(); err != nil {
+			t.Fatalf("generate: %v\n%s", err, out)
+		}
+		xhtml, err := os.ReadFile(xhtmlPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		for _, f := range []struct {
+			name string
+			data []byte
+		}{
+			{"6100000001.xhtml", xhtml},
+			{"6100000002.xhtml", []byte("<html>")},
+		} {
+			w, _ := zw.Create(f.name)
+			w.Write(f.data)
+		}
+		zw.Close()
+		zipPath := filepath.Join(tmpDir, "Arsredovisning_digital_170322.zip")
+		if err := os.WriteFile(zipPath, buf.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		cmd := exec.Command(bin, "bulk-parse", "--workers", "2", "--format=jsonl", zipPath)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("bulk-parse: %v\n%s", err, stderr.String())
+		}
+		lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+		if len(lines) != 2 || !strings.Contains(lines[0], `"netSales":2650000`) || !strings.Contains(lines[1], `"error":`) {
+			t.Errorf("bulk-parse output:\n%s", out)
+		}
+		if !strings.Contains(stderr.String(), "Warning: Arsredovisning_digital_170322.zip/6100000002.xhtml: ") {
+			t.Errorf("expected per-file error on stderr, got: %s", stderr.String())
+		}
+	})
+
 	t.Run("check command", func(t *testing.T) {
 		var paths []string
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -248,6 +766,27 @@ func TestGenerateCommand(t *testing.T) {
 		if !strings.Contains(string(out), "Remote check passed") {
 			t.Fatalf("expected success output, got:\n%s", out)
 		}
+
+		report, err := os.ReadFile(inputPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		report = bytes.Replace(report, []byte(`"date": "2017-02-20"`), []byte(`"date": "2017-04-01"`), 1)
+		reportPath := filepath.Join(tmpDir, "check-late-signing.json")
+		rulesPath := filepath.Join(tmpDir, "check-rules.json")
+		rules := `{"suppressions": [{"rule": "agm-after-signing", "justification": "Extra meeting"}]}`
+		if err := os.WriteFile(reportPath, report, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(rulesPath, []byte(rules), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		cmd = exec.Command(bin, "check", "--sender-pnr", "190001010106", "--rules", rulesPath, reportPath)
+		cmd.Env = append(os.Environ(), envSubmissionBaseURL+"="+server.URL)
+		out, err = cmd.CombinedOutput()
+		if err != nil || !strings.Contains(string(out), "1 suppressed:") || !strings.Contains(string(out), "justification: Extra meeting") {
+			t.Fatalf("check with suppression: %v\n%s", err, out)
+		}
 	})
 
 	t.Run("submit command", func(t *testing.T) {
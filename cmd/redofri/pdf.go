@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/redofri/redofri/pkg/pdf"
+)
+
+// extractPDFFlags removes the PDF flags from args and returns the render
+// options with any font files loaded.
+func extractPDFFlags(args []string) (rest []string, opts pdf.Options, err error) {
+	fonts := map[string]*[]byte{"--font": &opts.Font, "--bold-font": &opts.BoldFont}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		name, value, hasValue := strings.Cut(arg, "=")
+		switch {
+		case arg == "--pdfa":
+			opts.PDFA = true
+		case fonts[name] != nil:
+			if !hasValue {
+				i++
+				if i >= len(args) {
+					return nil, opts, fmt.Errorf("%s requires a font file", name)
+				}
+				value = args[i]
+			}
+			data, err := os.ReadFile(value)
+			if err != nil {
+				return nil, opts, fmt.Errorf("reading %s: %w", value, err)
+			}
+			*fonts[name] = data
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	return rest, opts, nil
+}
+
+// runRenderPDF loads JSON input and renders it as a PDF document.
+func runRenderPDF(args []string) error {
+	args, opts, err := extractPDFFlags(args)
+	if err != nil {
+		return err
+	}
+	inputPath, outputPath, err := parseIOFlags(args)
+	if err != nil {
+		return err
+	}
+	if inputPath == "" {
+		return fmt.Errorf("missing input file\nUsage: redofri render-pdf [-o output.pdf] [--pdfa --font <file.ttf>] <input.json>")
+	}
+
+	report, err := loadReport(inputPath)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Render(&buf, report, opts); err != nil {
+		return fmt.Errorf("rendering PDF: %w", err)
+	}
+
+	return writeOutput(outputPath, buf.Bytes(), "Rendered")
+}
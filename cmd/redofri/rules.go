@@ -0,0 +1,217 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/redofri/redofri/pkg/validate"
+)
+
+// extractRulesFlag removes --rules from args and loads the rule
+// configuration it names. It returns a nil configuration when the flag was
+// not given.
+func extractRulesFlag(args []string) (rest []string, cfg *validate.Config, err error) {
+	path := ""
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "--rules":
+			i++
+			if i >= len(args) {
+				return nil, nil, fmt.Errorf("%s requires a configuration file", arg)
+			}
+			path = args[i]
+		case strings.HasPrefix(arg, "--rules="):
+			path = strings.TrimPrefix(arg, "--rules=")
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	if path == "" {
+		return rest, nil, nil
+	}
+	cfg, err = validate.LoadConfig(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rest, cfg, nil
+}
+
+// printSuppressed lists suppressed findings with their justifications.
+func printSuppressed(suppressed []validate.Suppressed) {
+	if len(suppressed) == 0 {
+		return
+	}
+	fmt.Printf("\n%d suppressed:\n", len(suppressed))
+	for _, s := range suppressed {
+		fmt.Printf("  %s\n    justification: %s\n", s.Result, s.Justification)
+	}
+}
+
+// printHints prints how to fix a result when its rule has a remediation
+// hint in the catalogue.
+func printHints(res validate.Result, entries map[string]validate.CatalogueEntry) {
+	if e, ok := entries[res.Rule]; ok && e.Remediation != "" {
+		fmt.Printf("    fix: %s (redofri explain-rule %s)\n", e.Remediation, res.Rule)
+	}
+}
+
+// catalogueByID indexes catalogue entries by rule id.
+func catalogueByID(entries []validate.CatalogueEntry) map[string]validate.CatalogueEntry {
+	byID := make(map[string]validate.CatalogueEntry, len(entries))
+	for _, e := range entries {
+		byID[e.ID] = e
+	}
+	return byID
+}
+
+// jsonResult is a validation result in validate --format json output, with
+// the catalogue entry of its rule.
+type jsonResult struct {
+	Severity      string                   `json:"severity"`
+	Code          int                      `json:"code,omitempty"`
+	Field         string                   `json:"field"`
+	Message       string                   `json:"message"`
+	Rule          string                   `json:"rule,omitempty"`
+	Justification string                   `json:"justification,omitempty"`
+	Entry         *validate.CatalogueEntry `json:"entry,omitempty"`
+}
+
+// validationJSON encodes validation results and suppressed findings with
+// their catalogue entries.
+func validationJSON(results []validate.Result, suppressed []validate.Suppressed, entries map[string]validate.CatalogueEntry) ([]byte, error) {
+	convert := func(res validate.Result, justification string) jsonResult {
+		severity := "error"
+		if res.Severity == validate.Warning {
+			severity = "warning"
+		}
+		out := jsonResult{
+			Severity:      severity,
+			Code:          res.Code,
+			Field:         res.Field,
+			Message:       res.Message,
+			Rule:          res.Rule,
+			Justification: justification,
+		}
+		if e, ok := entries[res.Rule]; ok {
+			out.Entry = &e
+		}
+		return out
+	}
+	var doc struct {
+		Results    []jsonResult `json:"results"`
+		Suppressed []jsonResult `json:"suppressed,omitempty"`
+	}
+	doc.Results = []jsonResult{}
+	for _, res := range results {
+		doc.Results = append(doc.Results, convert(res, ""))
+	}
+	for _, s := range suppressed {
+		doc.Suppressed = append(doc.Suppressed, convert(s.Result, s.Justification))
+	}
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encoding JSON: %w", err)
+	}
+	return append(out, '\n'), nil
+}
+
+// runRules lists the validation rules.
+func runRules(args []string) error {
+	const usage = "Usage: redofri rules [--format text|json] [--lang sv|en] [-o output]"
+
+	args, format, err := extractFormatFlag(args, "text")
+	if err != nil {
+		return err
+	}
+	outputPath, lang := "", "sv"
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "-o" || arg == "--output" || arg == "--lang":
+			i++
+			if i >= len(args) {
+				return fmt.Errorf("%s requires a value", arg)
+			}
+			if arg == "--lang" {
+				lang = args[i]
+			} else {
+				outputPath = args[i]
+			}
+		case strings.HasPrefix(arg, "--output="):
+			outputPath = strings.TrimPrefix(arg, "--output=")
+		case strings.HasPrefix(arg, "--lang="):
+			lang = strings.TrimPrefix(arg, "--lang=")
+		default:
+			return fmt.Errorf("unexpected argument: %s\n%s", arg, usage)
+		}
+	}
+	if format != "text" && format != "json" {
+		return fmt.Errorf("unknown format %q (available: json, text)", format)
+	}
+	if lang != "sv" && lang != "en" {
+		return fmt.Errorf("unknown language %q (available: en, sv)", lang)
+	}
+
+	entries := validate.Catalogue()
+	var buf bytes.Buffer
+	if format == "json" {
+		out, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encoding JSON: %w", err)
+		}
+		buf.Write(out)
+		buf.WriteByte('\n')
+		return writeOutput(outputPath, buf.Bytes(), "Wrote")
+	}
+
+	width := 0
+	for _, e := range entries {
+		width = max(width, len(e.ID))
+	}
+	fmt.Fprintf(&buf, "%-*s  %-4s  %-7s  %s\n", width, "RULE", "BV", "LEVEL", "DESCRIPTION")
+	for _, e := range entries {
+		code := "-"
+		if e.Code > 0 {
+			code = strconv.Itoa(e.Code)
+		}
+		desc := e.Swedish
+		if lang == "en" {
+			desc = e.English
+		}
+		fmt.Fprintf(&buf, "%-*s  %-4s  %-7s  %s\n", width, e.ID, code, e.Severity, desc)
+	}
+	return writeOutput(outputPath, buf.Bytes(), "Wrote")
+}
+
+// runExplainRule shows the catalogue entries of the rules with an id or
+// Bolagsverket code.
+func runExplainRule(args []string) error {
+	if len(args) != 1 || strings.HasPrefix(args[0], "-") {
+		return fmt.Errorf("expected a rule id or BV code\nUsage: redofri explain-rule <rule|code>")
+	}
+	entries := validate.FindRules(args[0])
+	if len(entries) == 0 {
+		return fmt.Errorf("no rule with id or BV code %q (see redofri rules)", args[0])
+	}
+	for i, e := range entries {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("Rule:         %s\n", e.ID)
+		if e.Code > 0 {
+			fmt.Printf("BV code:      %d\n", e.Code)
+		}
+		fmt.Printf("Severity:     %s\n", e.Severity)
+		fmt.Printf("Svenska:      %s\n", e.Swedish)
+		fmt.Printf("English:      %s\n", e.English)
+		if len(e.Fields) > 0 {
+			fmt.Printf("Fields:       %s\n", strings.Join(e.Fields, ", "))
+		}
+		if e.Remediation != "" {
+			fmt.Printf("Remediation:  %s\n", e.Remediation)
+		}
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/redofri/redofri/pkg/ixbrl"
 	"github.com/redofri/redofri/pkg/model"
 	"github.com/redofri/redofri/pkg/submission"
 	"github.com/redofri/redofri/pkg/validate"
@@ -39,7 +40,7 @@ func runCheck(args []string) error {
 	result, err := service.Check(context.Background(), report, flags.submitOptions())
 	if err != nil {
 		if validationErr, ok := err.(*submission.ValidationError); ok {
-			printLocalValidationReport(report, validationErr.Findings)
+			printLocalValidationReport(report, validationErr.Findings, validationErr.Suppressed)
 			return err
 		}
 		if remoteErr, ok := err.(*submission.RemoteCheckError); ok {
@@ -81,7 +82,7 @@ func runSubmit(args []string) error {
 	result, err := service.Submit(context.Background(), report, flags.submitOptions())
 	if err != nil {
 		if validationErr, ok := err.(*submission.ValidationError); ok {
-			printLocalValidationReport(report, validationErr.Findings)
+			printLocalValidationReport(report, validationErr.Findings, validationErr.Suppressed)
 			return err
 		}
 		if remoteErr, ok := err.(*submission.RemoteCheckError); ok {
@@ -110,6 +111,8 @@ type submissionFlags struct {
 	emailAddresses             []string
 	receiptEmailAddresses      []string
 	notificationEmailAddresses []string
+	theme                      *ixbrl.Theme
+	validator                  *validate.Validator
 	documentType               string
 }
 
@@ -122,6 +125,22 @@ func parseSubmissionFlags(args []string) (submissionFlags, error) {
 		documentType:         submission.DefaultDocumentType,
 	}
 
+	args, theme, err := extractThemeFlags(args)
+	if err != nil {
+		return flags, err
+	}
+	flags.theme = theme
+
+	args, cfg, err := extractRulesFlag(args)
+	if err != nil {
+		return flags, err
+	}
+	if cfg != nil {
+		if flags.validator, err = validate.NewValidator(cfg); err != nil {
+			return flags, err
+		}
+	}
+
 	for i := 0; i < len(args); i++ {
 		arg := args[i]
 		switch {
@@ -210,6 +229,8 @@ func (f submissionFlags) submitOptions() submission.SubmitOptions {
 		ReceiptEmailAddresses:      append([]string(nil), f.receiptEmailAddresses...),
 		NotificationEmailAddresses: append([]string(nil), f.notificationEmailAddresses...),
 		DocumentType:               f.documentType,
+		Theme:                      f.theme,
+		Validator:                  f.validator,
 	}
 }
 
@@ -225,19 +246,11 @@ func newSubmissionService(baseURL, apiKey string) (*submission.Service, error) {
 	return submission.NewService(client), nil
 }
 
-func printLocalValidationReport(report *model.AnnualReport, findings []validate.Result) {
+func printLocalValidationReport(report *model.AnnualReport, findings []validate.Result, suppressed []validate.Suppressed) {
 	fmt.Printf("Company:      %s (%s)\n", report.Company.Name, report.Company.OrgNr)
 	fmt.Printf("Fiscal year:  %s - %s\n\n", report.FiscalYear.StartDate, report.FiscalYear.EndDate)
-	var errors, warnings int
-	for _, finding := range findings {
-		fmt.Println(finding)
-		if finding.Severity == validate.Error {
-			errors++
-		} else {
-			warnings++
-		}
-	}
-	fmt.Printf("\n%d error(s), %d warning(s)\n", errors, warnings)
+	printResults(findings, catalogueByID(validate.Catalogue()))
+	printSuppressed(suppressed)
 }
 
 func printCheckSummary(report *model.AnnualReport, baseURL string, result *submission.CheckResult) {
@@ -257,6 +270,7 @@ func printCheckSummary(report *model.AnnualReport, baseURL string, result *submi
 	}
 	fmt.Println()
 	printLocalWarnings(result.LocalFindings)
+	printSuppressed(result.LocalSuppressed)
 }
 
 func printSubmitSummary(report *model.AnnualReport, baseURL string, result *submission.SubmitResult, skipCheck bool) {
@@ -286,6 +300,7 @@ func printSubmitSummary(report *model.AnnualReport, baseURL string, result *subm
 	}
 	fmt.Println()
 	printLocalWarnings(result.LocalFindings)
+	printSuppressed(result.LocalSuppressed)
 }
 
 func printRemoteFindings(findings []submission.Finding) {
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/redofri/redofri/pkg/ixbrl"
+	"github.com/redofri/redofri/pkg/model"
+	"github.com/redofri/redofri/pkg/taxonomy"
+)
+
+// extractTaxonomyFlag removes --taxonomy from args and loads the package it
+// names. It returns a nil taxonomy when the flag was not given.
+func extractTaxonomyFlag(args []string) (rest []string, tax *taxonomy.Taxonomy, err error) {
+	path := ""
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "--taxonomy":
+			i++
+			if i >= len(args) {
+				return nil, nil, fmt.Errorf("%s requires a taxonomy package", arg)
+			}
+			path = args[i]
+		case strings.HasPrefix(arg, "--taxonomy="):
+			path = strings.TrimPrefix(arg, "--taxonomy=")
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	if path == "" {
+		return rest, nil, nil
+	}
+	tax, err = taxonomy.Load(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rest, tax, nil
+}
+
+// extractOnlyDeclaredFlag removes --only-declared from args.
+func extractOnlyDeclaredFlag(args []string) (rest []string, onlyDeclared bool) {
+	for _, arg := range args {
+		if arg == "--only-declared" {
+			onlyDeclared = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return rest, onlyDeclared
+}
+
+// declaredRelease returns the report's K2 release narrowed to the concepts
+// the taxonomy package declares, and warns about the concepts that will be
+// written untagged.
+func declaredRelease(report *model.AnnualReport, tax *taxonomy.Taxonomy) (*taxonomy.Release, error) {
+	release := taxonomy.K2Release(report.Meta.TaxonomyVersion)
+	if release == nil {
+		return nil, fmt.Errorf("unknown taxonomy version %q", report.Meta.TaxonomyVersion)
+	}
+	release, err := release.WithPackage(tax, ixbrl.Concepts())
+	if err != nil {
+		return nil, err
+	}
+	if len(release.Missing) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: the taxonomy package does not declare %d concept(s) of K2 %s, written untagged: %s\n",
+			len(release.Missing), release.Version, strings.Join(release.Missing, ", "))
+	}
+	return release, nil
+}
+
+// runCoverage lists which concepts of a taxonomy package redofri supports.
+func runCoverage(args []string) error {
+	const usage = "Usage: redofri coverage [--format text|json] [--lang sv|en] [-o output] <taxonomy>"
+
+	args, format, err := extractFormatFlag(args, "text")
+	if err != nil {
+		return err
+	}
+	var paths []string
+	outputPath, lang := "", "sv"
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "-o" || arg == "--output" || arg == "--lang":
+			i++
+			if i >= len(args) {
+				return fmt.Errorf("%s requires a value", arg)
+			}
+			if arg == "--lang" {
+				lang = args[i]
+			} else {
+				outputPath = args[i]
+			}
+		case strings.HasPrefix(arg, "--output="):
+			outputPath = strings.TrimPrefix(arg, "--output=")
+		case strings.HasPrefix(arg, "--lang="):
+			lang = strings.TrimPrefix(arg, "--lang=")
+		case strings.HasPrefix(arg, "-"):
+			return fmt.Errorf("unknown flag: %s", arg)
+		default:
+			paths = append(paths, arg)
+		}
+	}
+	if len(paths) != 1 {
+		return fmt.Errorf("expected a taxonomy package (directory or ZIP)\n%s", usage)
+	}
+	if format != "text" && format != "json" {
+		return fmt.Errorf("unknown format %q (available: json, text)", format)
+	}
+
+	tax, err := taxonomy.Load(paths[0])
+	if err != nil {
+		return err
+	}
+	supported := ixbrl.ConceptsFor(tax.Version)
+	if supported == nil {
+		supported = ixbrl.Concepts()
+	}
+	cov := tax.Coverage(supported, lang)
+
+	var buf bytes.Buffer
+	if format == "json" {
+		out, err := json.MarshalIndent(cov, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encoding JSON: %w", err)
+		}
+		buf.Write(out)
+		buf.WriteByte('\n')
+	} else if err := cov.WriteText(&buf); err != nil {
+		return err
+	}
+	return writeOutput(outputPath, buf.Bytes(), "Wrote")
+}
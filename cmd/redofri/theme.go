@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/redofri/redofri/pkg/ixbrl"
+)
+
+// themeFlagNames lists the presentation flags shared by generate, check and submit.
+var themeFlagNames = []string{"--theme", "--css", "--cover-template", "--header-template"}
+
+// extractThemeFlags removes the theme flags from args and builds the selected
+// theme. It returns a nil theme when no theme flag was given.
+func extractThemeFlags(args []string) (rest []string, theme *ixbrl.Theme, err error) {
+	values := map[string]string{}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		matched := false
+		for _, name := range themeFlagNames {
+			switch {
+			case arg == name:
+				i++
+				if i >= len(args) {
+					return nil, nil, fmt.Errorf("%s requires a value", name)
+				}
+				values[name] = args[i]
+				matched = true
+			case strings.HasPrefix(arg, name+"="):
+				values[name] = strings.TrimPrefix(arg, name+"=")
+				matched = true
+			}
+		}
+		if !matched {
+			rest = append(rest, arg)
+		}
+	}
+	if len(values) == 0 {
+		return rest, nil, nil
+	}
+
+	name := values["--theme"]
+	if name == "" {
+		name = "standard"
+	}
+	theme, ok := ixbrl.BuiltinTheme(name)
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown theme %q (available: %s)", name, strings.Join(ixbrl.BuiltinThemes(), ", "))
+	}
+	for flag, dst := range map[string]*string{
+		"--css":             &theme.ExtraCSS,
+		"--cover-template":  &theme.CoverTemplate,
+		"--header-template": &theme.HeaderTemplate,
+	} {
+		path := values[flag]
+		if path == "" {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+		if flag == "--css" {
+			// User CSS is added after the built-in theme rules so it can
+			// override them.
+			*dst += string(data)
+		} else {
+			*dst = string(data)
+		}
+	}
+	if err := theme.Validate(); err != nil {
+		return nil, nil, err
+	}
+	return rest, theme, nil
+}
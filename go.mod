@@ -2,4 +2,4 @@ module github.com/redofri/redofri
 
 go 1.25.0
 
-require golang.org/x/text v0.34.0 // indirect
+require golang.org/x/text v0.34.0
@@ -0,0 +1,167 @@
+package avisering
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// RecordTypeAnnualReport is posttyp 920, which describes a registered
+// annual report.
+const RecordTypeAnnualReport = "920"
+
+// Notice is one record of an aviseringsfil.
+type Notice struct {
+	RecordType string `json:"posttyp"` // POSTTYP, "920" for annual reports
+
+	// Kvittensnummer identifies a digitally filed report and names its
+	// files; Helfilmnummer ("rulle-ruta") is set instead for paper reports.
+	Kvittensnummer string `json:"kvittensnummer,omitempty"` // KVITTENSNR
+	Helfilmnummer  string `json:"helfilmnummer,omitempty"`  // HELFILMNR
+
+	OrgNr            string `json:"orgNr,omitempty"`            // ORGNR, as NNNNNN-NNNN
+	ReceivedDate     string `json:"receivedDate,omitempty"`     // INKOMDATUM, YYYY-MM-DD
+	RegistrationDate string `json:"registrationDate,omitempty"` // REGDATUM, YYYY-MM-DD
+	FiscalYearStart  string `json:"fiscalYearStart,omitempty"`  // RAKFROM, YYYY-MM-DD
+	FiscalYearEnd    string `json:"fiscalYearEnd,omitempty"`    // RAKTOM, YYYY-MM-DD
+
+	// Fields holds every field of the record by name, including those
+	// above as written in the file.
+	Fields map[string]string `json:"fields"`
+}
+
+// ReadNotices reads an aviseringsfil in the HFR format.
+//
+// The avisering document names posttyp 920 and its KVITTENSNR and HELFILMNR
+// fields but leaves the record layout to the HFR specification. The file
+// is read as delimited text: a header line naming the fields, then one
+// record per line, with fields separated by semicolons or tabs. Files that
+// are not valid UTF-8 are read as ISO 8859-1. Records of other types than
+// 920 are returned too, with only Fields and RecordType set.
+func ReadNotices(r io.Reader) ([]Notice, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading aviseringsfil: %w", err)
+	}
+	if !utf8.Valid(data) {
+		if data, err = charmap.ISO8859_1.NewDecoder().Bytes(data); err != nil {
+			return nil, fmt.Errorf("decoding aviseringsfil: %w", err)
+		}
+	}
+	text := strings.TrimPrefix(string(data), "\ufeff")
+
+	var header []string
+	var sep string
+	var notices []Notice
+	sc := bufio.NewScanner(strings.NewReader(text))
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	lineNo := 0
+	for sc.Scan() {
+		lineNo++
+		line := strings.TrimRight(sc.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if header == nil {
+			sep = ";"
+			if !strings.Contains(line, ";") && strings.Contains(line, "\t") {
+				sep = "\t"
+			}
+			for _, name := range strings.Split(line, sep) {
+				header = append(header, strings.ToUpper(strings.TrimSpace(name)))
+			}
+			if !slices.Contains(header, "POSTTYP") {
+				return nil, fmt.Errorf("aviseringsfil line %d: header has no POSTTYP field", lineNo)
+			}
+			continue
+		}
+
+		values := strings.Split(line, sep)
+		if len(values) > len(header) {
+			return nil, fmt.Errorf("aviseringsfil line %d: %d fields, header names %d", lineNo, len(values), len(header))
+		}
+		n := Notice{Fields: map[string]string{}}
+		for i, v := range values {
+			n.Fields[header[i]] = strings.TrimSpace(v)
+		}
+		if err := n.fill(); err != nil {
+			return nil, fmt.Errorf("aviseringsfil line %d: %w", lineNo, err)
+		}
+		notices = append(notices, n)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("reading aviseringsfil: %w", err)
+	}
+	if header == nil {
+		return nil, fmt.Errorf("aviseringsfil is empty")
+	}
+	return notices, nil
+}
+
+// fill sets the named fields of a record from n.Fields.
+func (n *Notice) fill() error {
+	f := n.Fields
+	n.RecordType = f["POSTTYP"]
+	if n.RecordType != RecordTypeAnnualReport {
+		return nil
+	}
+	n.Kvittensnummer = f["KVITTENSNR"]
+	n.Helfilmnummer = f["HELFILMNR"]
+	if n.Kvittensnummer == "" && n.Helfilmnummer == "" {
+		return fmt.Errorf("posttyp 920 has neither KVITTENSNR nor HELFILMNR")
+	}
+	n.OrgNr = formatOrgNr(f["ORGNR"])
+
+	for _, d := range []struct {
+		field string
+		dst   *string
+	}{
+		{"INKOMDATUM", &n.ReceivedDate},
+		{"REGDATUM", &n.RegistrationDate},
+		{"RAKFROM", &n.FiscalYearStart},
+		{"RAKTOM", &n.FiscalYearEnd},
+	} {
+		v, err := normalizeDate(f[d.field])
+		if err != nil {
+			return fmt.Errorf("%s: %w", d.field, err)
+		}
+		*d.dst = v
+	}
+	return nil
+}
+
+// normalizeDate converts YYYYMMDD or YYYY-MM-DD to YYYY-MM-DD.
+func normalizeDate(s string) (string, error) {
+	switch {
+	case s == "":
+		return "", nil
+	case len(s) == 8 && isDigits(s):
+		return s[:4] + "-" + s[4:6] + "-" + s[6:], nil
+	case len(s) == 10 && s[4] == '-' && s[7] == '-' && isDigits(s[:4]+s[5:7]+s[8:]):
+		return s, nil
+	}
+	return "", fmt.Errorf("invalid date %q", s)
+}
+
+// formatOrgNr writes a ten-digit organisationsnummer as NNNNNN-NNNN, the
+// form the model uses. Other values are returned unchanged.
+func formatOrgNr(s string) string {
+	if len(s) == 10 && isDigits(s) {
+		return s[:6] + "-" + s[6:]
+	}
+	return s
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
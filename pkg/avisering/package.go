@@ -0,0 +1,302 @@
+// Package avisering reads the packages in which Bolagsverket distributes
+// digitally filed annual reports ("Aviseringar och filformat", version 2.2).
+//
+// A package is a ZIP file such as Arsredovisning_digital_180112.zip. It
+// holds the files of each registered report, named by kvittensnummer:
+//
+//	6100000022.xhtml     the iXBRL file as filed
+//	6100000022.xbrl      the tagged data, converted by Bolagsverket
+//	6100000022.pdf       a rendering of the iXBRL file
+//	6100000022_RB.xhtml  a revisionsberättelse filed separately (and .xbrl)
+//	6100000022_FI.xhtml  a fastställelseintyg filed separately (and .xbrl)
+//	6100000022.zip       the taxonomy package of an ESEF report
+//
+// and an aviseringsfil with a posttyp 920 record per report.
+package avisering
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/redofri/redofri/pkg/ixbrl"
+	"github.com/redofri/redofri/pkg/model"
+)
+
+// Document kinds, from the suffix after the kvittensnummer.
+const (
+	KindAnnualReport  = "arsredovisning"      // no suffix
+	KindAuditReport   = "revisionsberattelse" // _RB
+	KindCertification = "faststallelseintyg"  // _FI
+)
+
+// Package is a read avisering package.
+type Package struct {
+	Name string `json:"name"` // file name of the ZIP, if known
+
+	// Date is the publication date from the package name, YYYY-MM-DD, or
+	// empty if the name does not follow the documented pattern.
+	Date string `json:"date,omitempty"`
+
+	// Notices holds the records of the aviseringsfiler in the package.
+	Notices []Notice `json:"notices"`
+
+	// Filings holds the reports in the package, sorted by kvittensnummer.
+	Filings []*Filing `json:"filings"`
+
+	// Other lists files that are neither report documents nor readable
+	// aviseringsfiler.
+	Other []string `json:"other,omitempty"`
+}
+
+// Filing is one registered report: its documents, its posttyp 920 record
+// and the annual report parsed from the iXBRL file.
+type Filing struct {
+	Kvittensnummer string `json:"kvittensnummer"`
+
+	// Notice is the posttyp 920 record with this kvittensnummer, or nil
+	// if the aviseringsfil has none.
+	Notice *Notice `json:"notice,omitempty"`
+
+	Documents []Document `json:"documents"`
+
+	// Report is parsed from the annual report's .xhtml file. It is nil if
+	// the filing has none (e.g. an ESEF report) or if ParseError is set.
+	Report     *model.AnnualReport `json:"report,omitempty"`
+	Warnings   []string            `json:"warnings,omitempty"`
+	FactErrors []string            `json:"factErrors,omitempty"`
+	ParseError string              `json:"parseError,omitempty"`
+}
+
+// Document is a file of a filing.
+type Document struct {
+	Name   string `json:"name"`   // file name in the package
+	Kind   string `json:"kind"`   // KindAnnualReport, KindAuditReport or KindCertification
+	Format string `json:"format"` // "xhtml", "xbrl", "pdf" or "zip"
+	Data   []byte `json:"-"`
+}
+
+// OrgNr returns the organisationsnummer from the aviseringsfil, or from
+// the parsed report when there is no notice.
+func (f *Filing) OrgNr() string {
+	if f.Notice != nil && f.Notice.OrgNr != "" {
+		return f.Notice.OrgNr
+	}
+	if f.Report != nil {
+		return f.Report.Company.OrgNr
+	}
+	return ""
+}
+
+// Document returns the document of the given kind and format, or nil.
+func (f *Filing) Document(kind, format string) *Document {
+	for i := range f.Documents {
+		if d := &f.Documents[i]; d.Kind == kind && d.Format == format {
+			return d
+		}
+	}
+	return nil
+}
+
+var (
+	// documentName matches report files: kvittensnummer, optional suffix
+	// and file type.
+	documentName = regexp.MustCompile(`^(\d+)(_RB|_FI)?\.(xhtml|xbrl|pdf|zip)$`)
+
+	// packageName matches the documented package names and captures the
+	// date, ÅÅMMDD for AB and ÅÅÅÅMMDD for other company forms.
+	packageName = regexp.MustCompile(`^(?:Arsredovisning_digital_(\d{6})|arsredHFR_digital_(\d{8}))\.zip$`)
+)
+
+// OpenPackage reads the avisering package at path.
+func OpenPackage(path string) (*Package, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	p, err := ReadPackage(f, info.Size(), info.Name())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
+
+// ReadPackage reads an avisering package from a ZIP file of the given
+// size. name is the file name of the package, which carries the
+// publication date; it may be empty. Each filing's .xhtml annual report is
+// parsed with ixbrl.Parse; a report that cannot be parsed sets the
+// filing's ParseError instead of failing the package.
+//
+// ReadPackage holds all documents in memory; use ScanPackage for large
+// packages.
+func ReadPackage(r io.ReaderAt, size int64, name string) (*Package, error) {
+	idx, err := ScanPackage(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Package{Notices: idx.Notices, Other: idx.Other}
+	p.setName(name)
+	filings := map[string]*Filing{}
+	for _, e := range idx.Entries {
+		data, err := e.ReadAll()
+		if err != nil {
+			return nil, err
+		}
+		f := filings[e.Kvittensnummer]
+		if f == nil {
+			f = &Filing{Kvittensnummer: e.Kvittensnummer, Notice: idx.Notice(e.Kvittensnummer)}
+			filings[e.Kvittensnummer] = f
+			p.Filings = append(p.Filings, f)
+		}
+		f.Documents = append(f.Documents, Document{Name: e.Name, Kind: e.Kind, Format: e.Format, Data: data})
+	}
+
+	for _, f := range p.Filings {
+		f.parse()
+	}
+	sort.Slice(p.Filings, func(i, j int) bool { return p.Filings[i].Kvittensnummer < p.Filings[j].Kvittensnummer })
+	return p, nil
+}
+
+// Index lists the contents of a package without reading the documents.
+type Index struct {
+	Entries []Entry  // report documents in ZIP order
+	Notices []Notice // records of the aviseringsfiler
+	Other   []string // files that are neither
+}
+
+// Entry is a report document in a package, read on demand.
+type Entry struct {
+	Name           string // file name, without directories
+	Kvittensnummer string
+	Kind           string // KindAnnualReport, KindAuditReport or KindCertification
+	Format         string // "xhtml", "xbrl", "pdf" or "zip"
+
+	file *zip.File
+}
+
+// Open opens the document for reading. Entries of one package may be
+// opened concurrently.
+func (e Entry) Open() (io.ReadCloser, error) {
+	rc, err := e.file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", e.file.Name, err)
+	}
+	return rc, nil
+}
+
+// ReadAll reads the whole document.
+func (e Entry) ReadAll() ([]byte, error) {
+	return readZipFile(e.file)
+}
+
+// Notice returns the posttyp 920 record with the given kvittensnummer,
+// or nil.
+func (idx *Index) Notice(kvittensnummer string) *Notice {
+	for i := range idx.Notices {
+		if n := &idx.Notices[i]; n.RecordType == RecordTypeAnnualReport && n.Kvittensnummer == kvittensnummer {
+			return n
+		}
+	}
+	return nil
+}
+
+// ScanPackage reads the ZIP directory of a package and its aviseringsfiler,
+// which are small, but leaves the report documents to be opened one at a
+// time through the returned entries. r must stay open while they are used.
+func ScanPackage(r io.ReaderAt, size int64) (*Index, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("reading ZIP: %w", err)
+	}
+
+	idx := &Index{}
+	for _, zf := range zr.File {
+		if zf.FileInfo().IsDir() {
+			continue
+		}
+		name := path.Base(zf.Name)
+		if m := documentName.FindStringSubmatch(name); m != nil {
+			kind := KindAnnualReport
+			switch m[2] {
+			case "_RB":
+				kind = KindAuditReport
+			case "_FI":
+				kind = KindCertification
+			}
+			idx.Entries = append(idx.Entries, Entry{Name: name, Kvittensnummer: m[1], Kind: kind, Format: m[3], file: zf})
+			continue
+		}
+
+		data, err := readZipFile(zf)
+		if err != nil {
+			return nil, err
+		}
+		notices, err := ReadNotices(bytes.NewReader(data))
+		if err != nil {
+			idx.Other = append(idx.Other, zf.Name)
+			continue
+		}
+		idx.Notices = append(idx.Notices, notices...)
+	}
+	return idx, nil
+}
+
+// parse parses the filing's iXBRL annual report, if it has one.
+func (f *Filing) parse() {
+	d := f.Document(KindAnnualReport, "xhtml")
+	if d == nil {
+		return
+	}
+	result, err := ixbrl.Parse(bytes.NewReader(d.Data))
+	if err != nil {
+		f.ParseError = fmt.Sprintf("%s: %v", d.Name, err)
+		return
+	}
+	f.Report = result.Report
+	f.Warnings = result.Warnings
+	for _, e := range result.Errors {
+		f.FactErrors = append(f.FactErrors, e.Error())
+	}
+}
+
+// setName sets the package name and the publication date it carries.
+func (p *Package) setName(name string) {
+	p.Name = name
+	m := packageName.FindStringSubmatch(path.Base(name))
+	if m == nil {
+		return
+	}
+	layout, date := "060102", m[1]
+	if date == "" {
+		layout, date = "20060102", m[2]
+	}
+	if t, err := time.Parse(layout, date); err == nil {
+		p.Date = t.Format(time.DateOnly)
+	}
+}
+
+func readZipFile(zf *zip.File) ([]byte, error) {
+	rc, err := zf.Open()
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", zf.Name, err)
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", zf.Name, err)
+	}
+	return data, nil
+}
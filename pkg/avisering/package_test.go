@@ -0,0 +1,137 @@
+package avisering
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testNotices = "POSTTYP;KVITTENSNR;HELFILMNR;ORGNR;INKOMDATUM;REGDATUM;RAKFROM;RAKTOM\n" +
+	"920;6100000022;;5569999999;20170315;20170320;20160101;20161231\n" +
+	"920;6100000023;;5560000001;2017-03-16;2017-03-21;2016-01-01;2016-12-31\n"
+
+// buildPackage writes a ZIP with the given files to dir.
+func buildPackage(t *testing.T, dir, name string, files map[string][]byte) string {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for n, data := range files {
+		w, err := zw.Create(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	p := filepath.Join(dir, name)
+	if err := os.WriteFile(p, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestOpenPackage(t *testing.T) {
+	xhtml, err := os.ReadFile("../../ref/exempel/faststalld-arsredovisning-exempel-1.xhtml")
+	if err != nil {
+		t.Skipf("reference example not available: %v", err)
+	}
+	path := buildPackage(t, t.TempDir(), "Arsredovisning_digital_170321.zip", map[string][]byte{
+		"aviseringsfil.txt":        []byte(testNotices),
+		"6100000022.xhtml":         xhtml,
+		"6100000022.xbrl":          []byte("<xbrli:xbrl/>"),
+		"6100000022.pdf":           []byte("%PDF-1.4"),
+		"6100000022_RB.xhtml":      []byte("<html/>"),
+		"6100000023.xhtml":         []byte("not xml"),
+		"6100000024.zip":           []byte("PK"),
+		"readme/Bolagsverket.png":  {0x89, 'P', 'N', 'G'},
+		"arsredovisningar/170321/": nil,
+	})
+
+	p, err := OpenPackage(path)
+	if err != nil {
+		t.Fatalf("OpenPackage: %v", err)
+	}
+	if p.Name != "Arsredovisning_digital_170321.zip" || p.Date != "2017-03-21" {
+		t.Errorf("name, date = %q, %q", p.Name, p.Date)
+	}
+	if len(p.Notices) != 2 {
+		t.Fatalf("got %d notices, want 2", len(p.Notices))
+	}
+	if len(p.Other) != 1 || p.Other[0] != "readme/Bolagsverket.png" {
+		t.Errorf("other = %q", p.Other)
+	}
+	if len(p.Filings) != 3 {
+		t.Fatalf("got %d filings, want 3", len(p.Filings))
+	}
+
+	f := p.Filings[0]
+	if f.Kvittensnummer != "6100000022" || f.Notice == nil || f.Notice.RegistrationDate != "2017-03-20" {
+		t.Errorf("filing = %+v", f)
+	}
+	if f.OrgNr() != "556999-9999" {
+		t.Errorf("OrgNr = %q", f.OrgNr())
+	}
+	if f.Report == nil || f.Report.Company.Name != "Exempel 1 AB" {
+		t.Fatalf("report not parsed: %s", f.ParseError)
+	}
+	if len(f.Documents) != 4 || f.Document(KindAuditReport, "xhtml") == nil || f.Document(KindAnnualReport, "pdf") == nil {
+		t.Errorf("documents = %+v", f.Documents)
+	}
+
+	if f := p.Filings[1]; f.Report != nil || !strings.HasPrefix(f.ParseError, "6100000023.xhtml: ") {
+		t.Errorf("unparsable report: parse error %q", f.ParseError)
+	}
+	if f := p.Filings[2]; f.Notice != nil || f.Report != nil || f.ParseError != "" || f.OrgNr() != "" {
+		t.Errorf("ESEF filing = %+v", f)
+	}
+}
+
+func TestReadNotices(t *testing.T) {
+	notices, err := ReadNotices(strings.NewReader(testNotices))
+	if err != nil {
+		t.Fatalf("ReadNotices: %v", err)
+	}
+	n := notices[0]
+	if n.RecordType != "920" || n.Kvittensnummer != "6100000022" || n.Helfilmnummer != "" || n.OrgNr != "556999-9999" {
+		t.Errorf("notice = %+v", n)
+	}
+	if n.ReceivedDate != "2017-03-15" || n.RegistrationDate != "2017-03-20" ||
+		n.FiscalYearStart != "2016-01-01" || n.FiscalYearEnd != "2016-12-31" {
+		t.Errorf("dates = %+v", n)
+	}
+	if notices[1].RegistrationDate != "2017-03-21" {
+		t.Errorf("ISO date = %q", notices[1].RegistrationDate)
+	}
+
+	// Tabs, ISO 8859-1 and other record types.
+	latin1 := []byte("POSTTYP\tHELFILMNR\tORGNR\tFIRMA\n920\t123-456\t5560000002\tR\xe4kna AB\n100\t\t\t\n")
+	notices, err = ReadNotices(bytes.NewReader(latin1))
+	if err != nil {
+		t.Fatalf("ReadNotices: %v", err)
+	}
+	if len(notices) != 2 || notices[0].Helfilmnummer != "123-456" || notices[0].Fields["FIRMA"] != "Räkna AB" {
+		t.Errorf("notices = %+v", notices)
+	}
+	if notices[1].RecordType != "100" || notices[1].Kvittensnummer != "" {
+		t.Errorf("other record = %+v", notices[1])
+	}
+
+	for _, bad := range []string{
+		"",
+		"KVITTENSNR\n6100000022\n",
+		"POSTTYP;KVITTENSNR\n920;\n",
+		"POSTTYP;KVITTENSNR;REGDATUM\n920;1;2017-3-1\n",
+		"POSTTYP;KVITTENSNR\n920;1;extra\n",
+	} {
+		if _, err := ReadNotices(strings.NewReader(bad)); err == nil {
+			t.Errorf("ReadNotices(%q) succeeded", bad)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package bulk
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeArchive writes a daily package with n copies of the reference
+// example, one broken report and an aviseringsfil.
+func writeArchive(t *testing.T, dir string, n int) string {
+	t.Helper()
+	xhtml, err := os.ReadFile("../../ref/exempel/faststalld-arsredovisning-exempel-1.xhtml")
+	if err != nil {
+		t.Skipf("reference example not available: %v", err)
+	}
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	add := func(name string, data []byte) {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write(data)
+	}
+	add("aviseringsfil.txt", []byte("POSTTYP;KVITTENSNR;REGDATUM\n920;6100000001;20170320\n"))
+	for i := 1; i <= n; i++ {
+		add(fmt.Sprintf("%d.xhtml", 6100000000+i), xhtml)
+		add(fmt.Sprintf("%d.pdf", 6100000000+i), []byte("%PDF-1.4"))
+		if i == 2 {
+			add("6100000099.xhtml", []byte("<html><p>unclosed</html>"))
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "Arsredovisning_digital_170321.zip")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	archive := writeArchive(t, dir, 7)
+	notZip := filepath.Join(dir, "Arsredovisning_digital_170322.zip")
+	os.WriteFile(notZip, []byte("not a zip"), 0o644)
+
+	var recs []Record
+	err := Run(context.Background(), []string{archive, notZip}, Options{Workers: 3}, func(r Record) error {
+		recs = append(recs, r)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(recs) != 9 {
+		t.Fatalf("got %d records, want 9", len(recs))
+	}
+
+	// Records come in ZIP order whatever the workers finish first.
+	var files []string
+	for _, r := range recs[:8] {
+		files = append(files, r.File)
+	}
+	want := "6100000001.xhtml 6100000002.xhtml 6100000099.xhtml 6100000003.xhtml 6100000004.xhtml 6100000005.xhtml 6100000006.xhtml 6100000007.xhtml"
+	if got := strings.Join(files, " "); got != want {
+		t.Errorf("order:\n%s\nwant:\n%s", got, want)
+	}
+
+	r := recs[0]
+	if r.Error != "" {
+		t.Fatalf("error: %s", r.Error)
+	}
+	if r.Archive != "Arsredovisning_digital_170321.zip" || r.Kvittensnummer != "6100000001" || r.RegistrationDate != "2017-03-20" {
+		t.Errorf("metadata = %+v", r)
+	}
+	if r.OrgNr != "556999-9999" || r.CompanyName != "Exempel 1 AB" || r.FiscalYearStart != "2016-01-01" || r.FiscalYearEnd != "2016-12-31" {
+		t.Errorf("company = %+v", r)
+	}
+	for _, tt := range []struct {
+		name string
+		got  *int64
+		want int64
+	}{
+		{"netSales", r.NetSales, 2650000},
+		{"netResult", r.NetResult, 1274000},
+		{"totalAssets", r.TotalAssets, 7773000},
+	} {
+		if tt.got == nil || *tt.got != tt.want {
+			t.Errorf("%s = %v, want %d", tt.name, tt.got, tt.want)
+		}
+	}
+	if r.Solidity != "0.337" {
+		t.Errorf("solidity = %q", r.Solidity)
+	}
+
+	if !strings.HasPrefix(recs[2].Error, "reading iXBRL: ") || recs[2].NetSales != nil {
+		t.Errorf("broken report = %+v", recs[2])
+	}
+	if last := recs[8]; last.Archive != "Arsredovisning_digital_170322.zip" || last.File != "" || last.Error == "" {
+		t.Errorf("unreadable archive = %+v", last)
+	}
+}
+
+func TestRun_EmitError(t *testing.T) {
+	archive := writeArchive(t, t.TempDir(), 20)
+	stop := errors.New("stop")
+	n := 0
+	err := Run(context.Background(), []string{archive}, Options{Workers: 4}, func(Record) error {
+		n++
+		if n == 3 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) || n != 3 {
+		t.Errorf("Run = %v after %d records, want stop after 3", err, n)
+	}
+}
+
+func TestWriters(t *testing.T) {
+	sales := int64(2650000)
+	recs := []Record{
+		{Archive: "a.zip", File: "1.xhtml", CompanyName: "Exempel, AB", NetSales: &sales, Solidity: "0.337"},
+		{Archive: "a.zip", File: "2.xhtml", Error: "reading iXBRL: EOF"},
+	}
+
+	var csvOut bytes.Buffer
+	cw := NewCSVWriter(&csvOut)
+	for _, r := range recs {
+		if err := cw.Write(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := cw.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(csvOut.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "archive,file,") {
+		t.Fatalf("CSV:\n%s", csvOut.String())
+	}
+	if lines[1] != `a.zip,1.xhtml,,,,"Exempel, AB",,,2650000,,,,,,0.337,,` {
+		t.Errorf("CSV row = %s", lines[1])
+	}
+	if !strings.HasSuffix(lines[2], ",reading iXBRL: EOF") {
+		t.Errorf("CSV error row = %s", lines[2])
+	}
+
+	var jsonOut bytes.Buffer
+	jw := NewJSONLWriter(&jsonOut)
+	for _, r := range recs {
+		if err := jw.Write(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	lines = strings.Split(strings.TrimSpace(jsonOut.String()), "\n")
+	if len(lines) != 2 || lines[1] != `{"archive":"a.zip","file":"2.xhtml","error":"reading iXBRL: EOF"}` {
+		t.Errorf("JSONL:\n%s", jsonOut.String())
+	}
+}
@@ -0,0 +1,127 @@
+// Package bulk extracts key figures from many annual reports at a time,
+// such as the thousands in one of Bolagsverket's daily avisering packages
+// (Arsredovisning_digital_<ååmmdd>.zip), for benchmarking.
+package bulk
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/redofri/redofri/pkg/ixbrl"
+)
+
+// Record holds the key figures of one report. Amounts are in kronor and
+// are nil when the report does not tag them for the fiscal year.
+type Record struct {
+	Archive          string `json:"archive"`                    // package file name
+	File             string `json:"file"`                       // report file name in the package
+	Kvittensnummer   string `json:"kvittensnummer,omitempty"`   // from the file name
+	RegistrationDate string `json:"registrationDate,omitempty"` // from the aviseringsfil
+
+	OrgNr           string `json:"orgNr,omitempty"`
+	CompanyName     string `json:"companyName,omitempty"`
+	FiscalYearStart string `json:"fiscalYearStart,omitempty"`
+	FiscalYearEnd   string `json:"fiscalYearEnd,omitempty"`
+
+	NetSales                  *int64 `json:"netSales,omitempty"`                  // Nettoomsattning
+	OperatingResult           *int64 `json:"operatingResult,omitempty"`           // Rorelseresultat
+	ResultAfterFinancialItems *int64 `json:"resultAfterFinancialItems,omitempty"` // ResultatEfterFinansiellaPoster
+	NetResult                 *int64 `json:"netResult,omitempty"`                 // AretsResultat
+	TotalAssets               *int64 `json:"totalAssets,omitempty"`               // Tillgangar
+	Equity                    *int64 `json:"equity,omitempty"`                    // EgetKapital
+
+	// Solidity is the equity/assets ratio as tagged, e.g. "0.337", and
+	// Employees the average number of employees, e.g. "4".
+	Solidity  string `json:"solidity,omitempty"`
+	Employees string `json:"employees,omitempty"`
+
+	// Error is set when the report could not be read; the figures are
+	// then empty.
+	Error string `json:"error,omitempty"`
+}
+
+// Namespace URIs, without version date, of the concepts read.
+const (
+	genBase = "http://www.taxonomier.se/se/fr/gen-base/"
+	cdBase  = "http://www.taxonomier.se/se/fr/cd-base/"
+)
+
+// KeyFigures reads the key figures of the fiscal year from a document.
+// The fiscal year is taken from RakenskapsarForstaDag and
+// RakenskapsarSistaDag, or else from the duration context ending last.
+// Only contexts without dimensions are used; of several facts for a
+// concept, the first is taken.
+func KeyFigures(d *ixbrl.Document) Record {
+	var rec Record
+	find := func(ns, local string, match func(*ixbrl.Context) bool) *ixbrl.Fact {
+		for _, f := range d.Facts {
+			if f.Nil || f.Tuple != nil || f.LocalName() != local || !strings.HasPrefix(f.Namespace, ns) {
+				continue
+			}
+			if len(f.Context.Dimensions) == 0 && match(f.Context) {
+				return f
+			}
+		}
+		return nil
+	}
+	anyContext := func(*ixbrl.Context) bool { return true }
+	text := func(ns, local string, match func(*ixbrl.Context) bool) string {
+		if f := find(ns, local, match); f != nil {
+			return strings.TrimSpace(f.Value)
+		}
+		return ""
+	}
+
+	rec.FiscalYearStart = text(cdBase, "RakenskapsarForstaDag", anyContext)
+	rec.FiscalYearEnd = text(cdBase, "RakenskapsarSistaDag", anyContext)
+	if rec.FiscalYearStart == "" || rec.FiscalYearEnd == "" {
+		rec.FiscalYearStart, rec.FiscalYearEnd = lastDuration(d)
+	}
+	period := func(c *ixbrl.Context) bool {
+		return !c.Period.IsInstant() && c.Period.StartDate == rec.FiscalYearStart && c.Period.EndDate == rec.FiscalYearEnd
+	}
+	balance := func(c *ixbrl.Context) bool {
+		return c.Period.IsInstant() && c.Period.Instant == rec.FiscalYearEnd
+	}
+	amount := func(local string, match func(*ixbrl.Context) bool) *int64 {
+		f := find(genBase, local, match)
+		if f == nil {
+			return nil
+		}
+		v, err := strconv.ParseFloat(f.Value, 64)
+		if err != nil {
+			return nil
+		}
+		n := int64(math.Round(v))
+		return &n
+	}
+
+	rec.OrgNr = text(cdBase, "Organisationsnummer", anyContext)
+	rec.CompanyName = text(cdBase, "ForetagetsNamn", anyContext)
+	rec.NetSales = amount("Nettoomsattning", period)
+	rec.OperatingResult = amount("Rorelseresultat", period)
+	rec.ResultAfterFinancialItems = amount("ResultatEfterFinansiellaPoster", period)
+	rec.NetResult = amount("AretsResultat", period)
+	rec.TotalAssets = amount("Tillgangar", balance)
+	rec.Equity = amount("EgetKapital", balance)
+	rec.Solidity = text(genBase, "Soliditet", balance)
+	rec.Employees = text(genBase, "MedelantaletAnstallda", period)
+	return rec
+}
+
+// lastDuration returns the dates of the duration context without
+// dimensions that ends last, the longest if several end on that day.
+func lastDuration(d *ixbrl.Document) (start, end string) {
+	for _, c := range d.Contexts {
+		p := c.Period
+		if len(c.Dimensions) > 0 || p.IsInstant() {
+			continue
+		}
+		// ISO dates compare as strings.
+		if p.EndDate > end || (p.EndDate == end && p.StartDate < start) {
+			start, end = p.StartDate, p.EndDate
+		}
+	}
+	return start, end
+}
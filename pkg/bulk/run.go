@@ -0,0 +1,172 @@
+package bulk
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"github.com/redofri/redofri/pkg/avisering"
+	"github.com/redofri/redofri/pkg/ixbrl"
+)
+
+// Options configures Run.
+type Options struct {
+	// Workers is the number of reports parsed at a time; 0 means one per
+	// CPU.
+	Workers int
+}
+
+// Run reads the annual reports (.xhtml) of each avisering package in
+// archives and calls emit with their key figures, in package and ZIP order.
+//
+// Reports are read straight from the ZIP by a pool of workers, so at most
+// a few reports per worker are in memory at a time. A report that cannot
+// be read, or a package that is not a ZIP file, gives a Record with Error
+// set and does not stop the run. Run stops at the first error from emit
+// or when ctx is done.
+func Run(ctx context.Context, archives []string, opts Options, emit func(Record) error) error {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	for _, path := range archives {
+		if err := runArchive(ctx, path, workers, emit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runArchive parses the reports of one package.
+func runArchive(ctx context.Context, path string, workers int, emit func(Record) error) error {
+	name := filepath.Base(path)
+	idx, closeArchive, err := scanArchive(path)
+	if err != nil {
+		return emit(Record{Archive: name, Error: err.Error()})
+	}
+	defer closeArchive()
+
+	var entries []avisering.Entry
+	for _, e := range idx.Entries {
+		if e.Kind == avisering.KindAnnualReport && e.Format == "xhtml" {
+			entries = append(entries, e)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		i   int
+		rec Record
+	}
+	jobs := make(chan int)
+	results := make(chan result)
+
+	// window bounds the records parsed but not yet emitted, which wait in
+	// pending until the records before them are done.
+	window := make(chan struct{}, 2*workers)
+
+	go func() {
+		defer close(jobs)
+		for i := range entries {
+			select {
+			case window <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				e := entries[i]
+				rec := readEntry(e)
+				rec.Archive = name
+				rec.File = e.Name
+				rec.Kvittensnummer = e.Kvittensnummer
+				if n := idx.Notice(e.Kvittensnummer); n != nil {
+					rec.RegistrationDate = n.RegistrationDate
+				}
+				results <- result{i, rec}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	pending := map[int]Record{}
+	next := 0
+	var emitErr error
+	for res := range results {
+		pending[res.i] = res.rec
+		for emitErr == nil {
+			rec, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+			<-window
+			if err := emit(rec); err != nil {
+				emitErr = err
+				cancel()
+			}
+		}
+	}
+	if emitErr != nil {
+		return emitErr
+	}
+	if next < len(entries) {
+		return ctx.Err()
+	}
+	return nil
+}
+
+// scanArchive opens a package and lists its contents. The returned
+// function closes the file.
+func scanArchive(path string) (*avisering.Index, func(), error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	idx, err := avisering.ScanPackage(f, info.Size())
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	return idx, func() { f.Close() }, nil
+}
+
+// readEntry parses one report with the fact-level parser.
+func readEntry(e avisering.Entry) Record {
+	rc, err := e.Open()
+	if err != nil {
+		return Record{Error: err.Error()}
+	}
+	defer rc.Close()
+	d, err := ixbrl.ReadDocument(rc)
+	if err != nil {
+		return Record{Error: fmt.Sprintf("reading iXBRL: %v", err)}
+	}
+	return KeyFigures(d)
+}
@@ -0,0 +1,89 @@
+package bulk
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+// Writer writes records in an output format.
+type Writer interface {
+	Write(Record) error
+	Flush() error
+}
+
+// csvColumns is the header row of the CSV output.
+var csvColumns = []string{
+	"archive", "file", "kvittensnummer", "registration_date",
+	"org_nr", "company_name", "fiscal_year_start", "fiscal_year_end",
+	"net_sales", "operating_result", "result_after_financial_items", "net_result",
+	"total_assets", "equity", "solidity", "employees", "error",
+}
+
+// CSVWriter writes records as CSV with a header row. Missing amounts are
+// empty.
+type CSVWriter struct {
+	w      *csv.Writer
+	header bool
+}
+
+// NewCSVWriter returns a CSVWriter writing to w.
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{w: csv.NewWriter(w)}
+}
+
+// Write writes one record, preceded by the header row on the first call.
+func (cw *CSVWriter) Write(r Record) error {
+	if !cw.header {
+		cw.header = true
+		if err := cw.w.Write(csvColumns); err != nil {
+			return err
+		}
+	}
+	amount := func(v *int64) string {
+		if v == nil {
+			return ""
+		}
+		return strconv.FormatInt(*v, 10)
+	}
+	return cw.w.Write([]string{
+		r.Archive, r.File, r.Kvittensnummer, r.RegistrationDate,
+		r.OrgNr, r.CompanyName, r.FiscalYearStart, r.FiscalYearEnd,
+		amount(r.NetSales), amount(r.OperatingResult), amount(r.ResultAfterFinancialItems), amount(r.NetResult),
+		amount(r.TotalAssets), amount(r.Equity), r.Solidity, r.Employees, r.Error,
+	})
+}
+
+// Flush writes buffered data, and the header row if no record was
+// written.
+func (cw *CSVWriter) Flush() error {
+	if !cw.header {
+		cw.header = true
+		if err := cw.w.Write(csvColumns); err != nil {
+			return err
+		}
+	}
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+// JSONLWriter writes records as JSON Lines, one object per line.
+type JSONLWriter struct {
+	enc *json.Encoder
+}
+
+// NewJSONLWriter returns a JSONLWriter writing to w.
+func NewJSONLWriter(w io.Writer) *JSONLWriter {
+	return &JSONLWriter{enc: json.NewEncoder(w)}
+}
+
+// Write writes one record.
+func (jw *JSONLWriter) Write(r Record) error {
+	return jw.enc.Encode(r)
+}
+
+// Flush does nothing; records are written as they come.
+func (jw *JSONLWriter) Flush() error {
+	return nil
+}
@@ -0,0 +1,238 @@
+// Package diff compares two annual reports, or two versions of one report,
+// at fact level and at model-field level, e.g. to see which figures a
+// corrected SIE file or an edited JSON input changed.
+package diff
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/redofri/redofri/pkg/ixbrl"
+	"github.com/redofri/redofri/pkg/model"
+)
+
+// Kinds of change.
+const (
+	Added   = "added"
+	Removed = "removed"
+	Changed = "changed"
+)
+
+// Result lists the differences between two reports, A and B. Changes are
+// in the order of A, followed by what B adds in its order.
+type Result struct {
+	Facts  []FactChange  `json:"facts"`
+	Fields []FieldChange `json:"fields"`
+}
+
+// Empty reports whether the reports have no differences.
+func (r *Result) Empty() bool {
+	return len(r.Facts) == 0 && len(r.Fields) == 0
+}
+
+// FactChange is a fact that was added, removed or changed. Facts are
+// matched by concept and period, and for tuple members by the tuple and
+// its position among tuples of the same concept.
+type FactChange struct {
+	Change  string `json:"change"`
+	Concept string `json:"concept"`
+	Period  string `json:"period"`          // "2016-12-31" or "2016-01-01 – 2016-12-31"
+	Tuple   string `json:"tuple,omitempty"` // e.g. "UnderskriftArsredovisningForetradareTuple#2"
+	Numeric bool   `json:"numeric"`
+	Old     string `json:"old,omitempty"`
+	New     string `json:"new,omitempty"`
+}
+
+// FieldChange is a model field that was added, removed or changed. Path
+// follows the JSON input, e.g. "incomeStatement.revenue.netSales.current"
+// or "signatures.representatives[1].name".
+type FieldChange struct {
+	Change string `json:"change"`
+	Path   string `json:"path"`
+	Old    string `json:"old,omitempty"`
+	New    string `json:"new,omitempty"`
+}
+
+// Compare compares two reports. Facts are those Generate tags for each
+// report, so a report read with ixbrl.Parse and its JSON compare equal at
+// fact level.
+func Compare(a, b *model.AnnualReport) (*Result, error) {
+	return CompareInputs(Input{Report: a}, Input{Report: b})
+}
+
+// Input is a report to compare. Document is the iXBRL document or XBRL
+// instance the report was parsed from, read with ixbrl.ReadDocument, or
+// nil for a report given as JSON.
+type Input struct {
+	Report   *model.AnnualReport
+	Document *ixbrl.Document
+}
+
+// CompareInputs compares two reports. Facts are those of each input's
+// document, so that facts the model does not map are compared too, and
+// are generated from the report for an input without one.
+func CompareInputs(a, b Input) (*Result, error) {
+	da, err := a.document()
+	if err != nil {
+		return nil, fmt.Errorf("first report: %w", err)
+	}
+	db, err := b.document()
+	if err != nil {
+		return nil, fmt.Errorf("second report: %w", err)
+	}
+	fields, err := CompareFields(a.Report, b.Report)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Facts: CompareFacts(da, db), Fields: fields}, nil
+}
+
+// document returns the input's document, or generates the report and
+// reads it back at fact level.
+func (in Input) document() (*ixbrl.Document, error) {
+	if in.Document != nil {
+		return in.Document, nil
+	}
+	doc, err := ixbrl.GenerateBytes(in.Report)
+	if err != nil {
+		return nil, fmt.Errorf("generating iXBRL: %w", err)
+	}
+	return ixbrl.ReadDocument(bytes.NewReader(doc))
+}
+
+// CompareFacts compares the facts of two documents. A concept tagged
+// more than once for the same period and tuple, e.g. in the income
+// statement and the multi-year overview, is compared by its distinct
+// values. Numbers equal in value, e.g. "1000" and "1000.00", and texts
+// differing only in whitespace are the same.
+func CompareFacts(a, b *ixbrl.Document) []FactChange {
+	fa, keysA := factIndex(a)
+	fb, keysB := factIndex(b)
+
+	var changes []FactChange
+	for _, k := range keysA {
+		x := fa[k]
+		y, ok := fb[k]
+		switch {
+		case !ok:
+			changes = append(changes, factChange(Removed, k, x, x.value(), ""))
+		case x.value() != y.value():
+			changes = append(changes, factChange(Changed, k, x, x.value(), y.value()))
+		}
+	}
+	for _, k := range keysB {
+		if _, ok := fa[k]; !ok {
+			y := fb[k]
+			changes = append(changes, factChange(Added, k, y, "", y.value()))
+		}
+	}
+	return changes
+}
+
+// factKey identifies a fact across documents.
+type factKey struct {
+	concept, period, tuple string
+}
+
+// factValues holds the distinct values of the facts with one key.
+type factValues struct {
+	numeric bool
+	values  []string
+}
+
+// value returns the values joined by " | ".
+func (v *factValues) value() string {
+	return strings.Join(v.values, " | ")
+}
+
+// factIndex returns the values of each key and the keys in document
+// order.
+func factIndex(d *ixbrl.Document) (map[factKey]*factValues, []factKey) {
+	tuples := tupleLabels(d)
+	facts := map[factKey]*factValues{}
+	var keys []factKey
+	for _, f := range d.Facts {
+		k := factKey{concept: f.Concept, period: periodLabel(f.Context)}
+		if f.Tuple != nil {
+			k.tuple = tuples[f.Tuple]
+		}
+		v, ok := facts[k]
+		if !ok {
+			v = &factValues{numeric: f.Numeric}
+			facts[k] = v
+			keys = append(keys, k)
+		}
+		if value := factValue(f); !slices.Contains(v.values, value) {
+			v.values = append(v.values, value)
+		}
+	}
+	return facts, keys
+}
+
+// tupleLabels names each tuple by its local name and its position among
+// the tuples with the same concept and parent, e.g. "Tuple#2", nested
+// tuples after their parent's label.
+func tupleLabels(d *ixbrl.Document) map[*ixbrl.Tuple]string {
+	labels := map[*ixbrl.Tuple]string{}
+	counts := map[string]int{}
+	for _, t := range d.Tuples {
+		prefix := ""
+		if t.Parent != nil {
+			prefix = labels[t.Parent] + "/"
+		}
+		_, local, ok := strings.Cut(t.Concept, ":")
+		if !ok {
+			local = t.Concept
+		}
+		counts[prefix+local]++
+		labels[t] = prefix + local + "#" + strconv.Itoa(counts[prefix+local])
+	}
+	return labels
+}
+
+// periodLabel formats the period of c, followed by its dimension members
+// if any.
+func periodLabel(c *ixbrl.Context) string {
+	if c == nil {
+		return ""
+	}
+	label := c.Period.Instant
+	if !c.Period.IsInstant() {
+		label = c.Period.StartDate + " – " + c.Period.EndDate
+	}
+	for _, d := range c.Dimensions {
+		label += " " + d.Dimension + "=" + d.Member
+	}
+	return label
+}
+
+// factValue returns the value of f in a form that compares equal for
+// equal values: numbers without trailing zeros, texts with whitespace
+// collapsed, and "nil" for a nil fact.
+func factValue(f *ixbrl.Fact) string {
+	if f.Nil {
+		return "nil"
+	}
+	if f.Numeric {
+		if v, err := strconv.ParseFloat(f.Value, 64); err == nil {
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	}
+	return strings.Join(strings.Fields(f.Value), " ")
+}
+
+// factChange returns the change of the facts with key k.
+func factChange(change string, k factKey, v *factValues, from, to string) FactChange {
+	return FactChange{
+		Change:  change,
+		Concept: k.concept,
+		Period:  k.period,
+		Tuple:   k.tuple,
+		Numeric: v.numeric,
+		Old:     from,
+		New:     to,
+	}
+}
@@ -0,0 +1,195 @@
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/redofri/redofri/pkg/ixbrl"
+	"github.com/redofri/redofri/pkg/model"
+)
+
+// readExample parses the reference example from the .xhtml or .xbrl file.
+func readExample(t *testing.T, ext string) *model.AnnualReport {
+	t.Helper()
+	f, err := os.Open("../../ref/exempel/faststalld-arsredovisning-exempel-1" + ext)
+	if err != nil {
+		t.Skipf("reference example not available: %v", err)
+	}
+	defer f.Close()
+	result, err := ixbrl.Parse(f)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	return result.Report
+}
+
+// clone copies r through JSON, as an edited JSON input would be read.
+func clone(t *testing.T, r *model.AnnualReport) *model.AnnualReport {
+	t.Helper()
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var c model.AnnualReport
+	if err := json.Unmarshal(data, &c); err != nil {
+		t.Fatal(err)
+	}
+	return &c
+}
+
+func TestCompare(t *testing.T) {
+	a := readExample(t, ".xhtml")
+
+	t.Run("equal", func(t *testing.T) {
+		result, err := Compare(a, readExample(t, ".xbrl"))
+		if err != nil {
+			t.Fatalf("Compare: %v", err)
+		}
+		if !result.Empty() {
+			t.Errorf("instance and iXBRL differ: %+v", result)
+		}
+	})
+
+	b := clone(t, a)
+	netSales := int64(2700000)
+	b.IncomeStatement.Revenue.NetSales.Current = &netSales
+	b.ManagementReport.MultiYearOverview.Years[0].NetSales = &netSales
+	b.ManagementReport.SignificantEvents = ""
+	b.ManagementReport.BusinessDescription += " Ny mening."
+
+	result, err := Compare(a, b)
+	if err != nil {
+		t.Fatalf("Compare: %v", err)
+	}
+
+	t.Run("facts", func(t *testing.T) {
+		want := []FactChange{
+			{Change: Changed, Concept: "se-gen-base:AllmantVerksamheten", Period: "2016-01-01 – 2016-12-31",
+				Old: "Bolaget tillverkar och säljer produkter inom skogsnäringen. De viktigaste produkterna är arbetsverktyg inom skogsbruket. Bolaget har sitt säte i Sundsvall, Västernorrlands län, där bolaget även har sin butik.",
+				New: "Bolaget tillverkar och säljer produkter inom skogsnäringen. De viktigaste produkterna är arbetsverktyg inom skogsbruket. Bolaget har sitt säte i Sundsvall, Västernorrlands län, där bolaget även har sin butik. Ny mening."},
+			{Change: Changed, Concept: "se-gen-base:VasentligaHandelserRakenskapsaret", Period: "2016-01-01 – 2016-12-31",
+				Old: a.ManagementReport.SignificantEvents},
+			{Change: Changed, Concept: "se-gen-base:Nettoomsattning", Period: "2016-01-01 – 2016-12-31",
+				Numeric: true, Old: "2650000", New: "2700000"},
+		}
+		if !reflect.DeepEqual(result.Facts, want) {
+			t.Errorf("facts:\n%+v\nwant:\n%+v", result.Facts, want)
+		}
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		var paths []string
+		for _, c := range result.Fields {
+			paths = append(paths, c.Change+" "+c.Path)
+		}
+		want := []string{
+			"changed managementReport.businessDescription",
+			"removed managementReport.significantEvents",
+			"changed managementReport.multiYearOverview.years[0].netSales",
+			"changed incomeStatement.revenue.netSales.current",
+		}
+		if strings.Join(paths, "\n") != strings.Join(want, "\n") {
+			t.Errorf("fields:\n%s\nwant:\n%s", strings.Join(paths, "\n"), strings.Join(want, "\n"))
+		}
+		if c := result.Fields[3]; c.Old != "2650000" || c.New != "2700000" {
+			t.Errorf("netSales change = %+v", c)
+		}
+	})
+}
+
+// TestCompareInputs checks that documents are compared by their own facts.
+func TestCompareInputs(t *testing.T) {
+	load := func(ext string) []byte {
+		data, err := os.ReadFile("../../ref/exempel/faststalld-arsredovisning-exempel-1" + ext)
+		if err != nil {
+			t.Skipf("reference example not available: %v", err)
+		}
+		return data
+	}
+	read := func(data []byte) Input {
+		result, err := ixbrl.Parse(bytes.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		doc, err := ixbrl.ReadDocument(bytes.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return Input{Report: result.Report, Document: doc}
+	}
+	xhtml := load(".xhtml")
+	a, b := read(xhtml), read(load(".xbrl"))
+	result, err := CompareInputs(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Empty() {
+		t.Errorf("instance and iXBRL differ: %+v", result)
+	}
+
+	// Facts are matched by namespace, not by the prefix a document uses.
+	renamed := read(bytes.ReplaceAll(xhtml, []byte("se-gen-base"), []byte("gen")))
+	for _, other := range []Input{a, b} {
+		result, err := CompareInputs(other, renamed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !result.Empty() {
+			t.Errorf("document with other prefixes differs: %d facts, %d fields", len(result.Facts), len(result.Fields))
+		}
+	}
+
+	// A fact changed in the document, not in the model, is found.
+	fact := b.Document.Concept("se-gen-base:Nettoomsattning")[0]
+	fact.Value = "2700000"
+	result, err = CompareInputs(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Facts) != 1 || result.Facts[0].New != "2700000 | 2650000" || len(result.Fields) != 0 {
+		t.Errorf("result = %+v", result)
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	result := &Result{
+		Facts: []FactChange{
+			{Change: Changed, Concept: "se-gen-base:Soliditet", Period: "2016-12-31", Numeric: true, Old: "0.337", New: "0.34"},
+			{Change: Added, Concept: "se-gen-base:Varulager", Period: "2016-12-31", Numeric: true, New: "12000"},
+			{Change: Changed, Concept: "se-bol-base:UnderskriftHandlingTilltalsnamn", Period: "2017-03-10",
+				Tuple: "UnderskriftArsredovisningForetradareTuple#2", Old: "Anna", New: "Anne"},
+		},
+		Fields: []FieldChange{
+			{Change: Removed, Path: "managementReport.significantEvents", Old: "Rad ett\nRad två"},
+		},
+	}
+	var buf bytes.Buffer
+	if err := result.WriteText(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := `Facts (2 changed, 1 added):
+  ~ se-gen-base:Soliditet 2016-12-31: 0.337 → 0.34 (+0.003)
+  + se-gen-base:Varulager 2016-12-31: 12000
+  ~ se-bol-base:UnderskriftHandlingTilltalsnamn 2017-03-10 [UnderskriftArsredovisningForetradareTuple#2]:
+      - Anna
+      + Anne
+
+Fields (1 removed):
+  - managementReport.significantEvents:
+      - Rad ett
+      - Rad två
+`
+	if buf.String() != want {
+		t.Errorf("text:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	(&Result{}).WriteText(&buf)
+	if buf.String() != "No differences.\n" {
+		t.Errorf("empty result: %q", buf.String())
+	}
+}
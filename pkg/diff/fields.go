@@ -0,0 +1,107 @@
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/redofri/redofri/pkg/model"
+)
+
+// CompareFields compares two reports field by field as they are written
+// to JSON. Empty and null fields count as absent.
+func CompareFields(a, b *model.AnnualReport) ([]FieldChange, error) {
+	fa, pathsA, err := Fields(a)
+	if err != nil {
+		return nil, fmt.Errorf("first report: %w", err)
+	}
+	fb, pathsB, err := Fields(b)
+	if err != nil {
+		return nil, fmt.Errorf("second report: %w", err)
+	}
+
+	var changes []FieldChange
+	for _, p := range pathsA {
+		x := fa[p]
+		y, ok := fb[p]
+		switch {
+		case !ok:
+			changes = append(changes, FieldChange{Change: Removed, Path: p, Old: x})
+		case x != y:
+			changes = append(changes, FieldChange{Change: Changed, Path: p, Old: x, New: y})
+		}
+	}
+	for _, p := range pathsB {
+		if _, ok := fa[p]; !ok {
+			changes = append(changes, FieldChange{Change: Added, Path: p, New: fb[p]})
+		}
+	}
+	return changes, nil
+}
+
+// Fields returns the leaf values of r by path, as compared by
+// CompareFields, and the paths in field order.
+func Fields(r *model.AnnualReport) (map[string]string, []string, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	values := map[string]string{}
+	var paths []string
+	var walk func(path string) error
+	walk = func(path string) error {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		var value string
+		switch t := tok.(type) {
+		case json.Delim:
+			if t == '{' {
+				for dec.More() {
+					key, err := dec.Token()
+					if err != nil {
+						return err
+					}
+					sub := key.(string)
+					if path != "" {
+						sub = path + "." + sub
+					}
+					if err := walk(sub); err != nil {
+						return err
+					}
+				}
+			} else {
+				for i := 0; dec.More(); i++ {
+					if err := walk(path + "[" + strconv.Itoa(i) + "]"); err != nil {
+						return err
+					}
+				}
+			}
+			_, err := dec.Token() // closing delimiter
+			return err
+		case nil:
+			return nil
+		case string:
+			if t == "" {
+				return nil
+			}
+			value = t
+		case json.Number:
+			value = t.String()
+		case bool:
+			value = strconv.FormatBool(t)
+		}
+		values[path] = value
+		paths = append(paths, path)
+		return nil
+	}
+	if err := walk(""); err != nil {
+		return nil, nil, err
+	}
+	return values, paths, nil
+}
@@ -0,0 +1,119 @@
+package diff
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// markers are the line prefixes of the kinds of change in text output.
+var markers = map[string]string{
+	Added:   "+",
+	Removed: "-",
+	Changed: "~",
+}
+
+// WriteText writes the differences for reading, facts first. Numbers are
+// shown as old → new with the difference; changed texts are shown in
+// full, the old text on a line starting with "-" and the new with "+".
+func (r *Result) WriteText(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	if r.Empty() {
+		fmt.Fprintln(bw, "No differences.")
+		return bw.Flush()
+	}
+
+	if len(r.Facts) > 0 {
+		fmt.Fprintf(bw, "Facts (%s):\n", summary(len(r.Facts), func(i int) string { return r.Facts[i].Change }))
+		for _, c := range r.Facts {
+			name := c.Concept + " " + c.Period
+			if c.Tuple != "" {
+				name += " [" + c.Tuple + "]"
+			}
+			writeChange(bw, c.Change, name, c.Old, c.New, c.Numeric)
+		}
+	}
+	if len(r.Fields) > 0 {
+		if len(r.Facts) > 0 {
+			fmt.Fprintln(bw)
+		}
+		fmt.Fprintf(bw, "Fields (%s):\n", summary(len(r.Fields), func(i int) string { return r.Fields[i].Change }))
+		for _, c := range r.Fields {
+			_, errOld := strconv.ParseFloat(c.Old, 64)
+			_, errNew := strconv.ParseFloat(c.New, 64)
+			numeric := (c.Old == "" || errOld == nil) && (c.New == "" || errNew == nil)
+			writeChange(bw, c.Change, c.Path, c.Old, c.New, numeric)
+		}
+	}
+	return bw.Flush()
+}
+
+// writeChange writes one change. Texts with line breaks, and changed
+// texts, get lines of their own.
+func writeChange(w io.Writer, change, name, from, to string, numeric bool) {
+	marker := markers[change]
+	value := from
+	if change == Added {
+		value = to
+	}
+	switch {
+	case change == Changed && numeric:
+		fmt.Fprintf(w, "  %s %s: %s → %s%s\n", marker, name, from, to, delta(from, to))
+	case change == Changed:
+		fmt.Fprintf(w, "  %s %s:\n", marker, name)
+		writeText(w, "-", from)
+		writeText(w, "+", to)
+	case strings.Contains(value, "\n"):
+		fmt.Fprintf(w, "  %s %s:\n", marker, name)
+		writeText(w, marker, value)
+	default:
+		fmt.Fprintf(w, "  %s %s: %s\n", marker, name, value)
+	}
+}
+
+// writeText writes a text indented under its change, each line after
+// marker.
+func writeText(w io.Writer, marker, text string) {
+	for line := range strings.SplitSeq(text, "\n") {
+		fmt.Fprintf(w, "      %s %s\n", marker, line)
+	}
+}
+
+// delta returns the difference between two numbers as " (+n)", or "" if
+// either is not a number.
+func delta(from, to string) string {
+	x, errX := strconv.ParseFloat(from, 64)
+	y, errY := strconv.ParseFloat(to, 64)
+	if errX != nil || errY != nil {
+		return ""
+	}
+	// Round to the decimals shown, so that 0.337 → 0.340 gives 0.003.
+	decimals := 0
+	for _, v := range []string{from, to} {
+		if _, frac, ok := strings.Cut(v, "."); ok {
+			decimals = max(decimals, len(frac))
+		}
+	}
+	d := strconv.FormatFloat(y-x, 'f', decimals, 64)
+	if y > x {
+		d = "+" + d
+	}
+	return " (" + d + ")"
+}
+
+// summary counts changes by kind, e.g. "2 changed, 1 added".
+func summary(n int, change func(int) string) string {
+	counts := map[string]int{}
+	for i := range n {
+		counts[change(i)]++
+	}
+	var parts []string
+	for _, kind := range []string{Changed, Added, Removed} {
+		if counts[kind] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[kind], kind))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -16,8 +16,8 @@ func (g *generator) writeBalanceSheetAssets(r *model.AnnualReport) {
 	g.line(`<div class="ar-page wide" id="ar3-page-5">`)
 	g.in()
 	g.pageHeader(r.Company.Name, r.Company.OrgNr, 5, totalPages)
-	g.line(`<h2>Balansräkning</h2>`)
-	g.line(`<p class="ar-amount-note">Balansräkningen visar bolagets tillgångar, eget kapital och skulder på balansdagen, jämfört med föregående år.</p>`)
+	g.linef(`<h2>%s</h2>`, g.t("Balansräkning"))
+	g.linef(`<p class="ar-amount-note">%s</p>`, g.t("Balansräkningen visar bolagets tillgångar, eget kapital och skulder på balansdagen, jämfört med föregående år."))
 
 	g.line(`<table class="ar-balance-sheet ar-financial col-4">`)
 	g.in()
@@ -36,15 +36,15 @@ func (g *generator) writeBalanceSheetAssets(r *model.AnnualReport) {
 	g.in()
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<th scope="col">Balansräkning</th>`)
-	g.line(`<th scope="col">Not</th>`)
-	g.linef(`<th scope="col">%s</th>`, r.FiscalYear.EndDate)
-	g.linef(`<th scope="col">%s</th>`, prevEnd)
+	g.linef(`<th scope="col">%s</th>`, g.t("Balansräkning"))
+	g.linef(`<th scope="col">%s</th>`, g.t("Not"))
+	g.linef(`<th scope="col">%s</th>`, g.date(r.FiscalYear.EndDate))
+	g.linef(`<th scope="col">%s</th>`, g.date(prevEnd))
 	g.out()
 	g.line(`</tr>`)
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<th colspan="4" scope="colgroup">Tillgångar</th>`)
+	g.linef(`<th colspan="4" scope="colgroup">%s</th>`, g.t("Tillgångar"))
 	g.out()
 	g.line(`</tr>`)
 	g.out()
@@ -73,14 +73,14 @@ func (g *generator) writeBSFixedAssets(bs *model.BalanceSheet) {
 	// Section header
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<th colspan="4" scope="rowgroup">Anläggningstillgångar</th>`)
+	g.linef(`<th colspan="4" scope="rowgroup">%s</th>`, g.t("Anläggningstillgångar"))
 	g.out()
 	g.line(`</tr>`)
 
 	// Materiella anläggningstillgångar
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<th colspan="4" scope="rowgroup" class="sub">Materiella anläggningstillgångar</th>`)
+	g.linef(`<th colspan="4" scope="rowgroup" class="sub">%s</th>`, g.t("Materiella anläggningstillgångar"))
 	g.out()
 	g.line(`</tr>`)
 
@@ -107,7 +107,7 @@ func (g *generator) writeBSFixedAssets(bs *model.BalanceSheet) {
 	// Finansiella anläggningstillgångar
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<th colspan="4" scope="rowgroup" class="sub sep">Finansiella anläggningstillgångar</th>`)
+	g.linef(`<th colspan="4" scope="rowgroup" class="sub sep">%s</th>`, g.t("Finansiella anläggningstillgångar"))
 	g.out()
 	g.line(`</tr>`)
 
@@ -141,14 +141,14 @@ func (g *generator) writeBSCurrentAssets(bs *model.BalanceSheet) {
 
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<th colspan="4" scope="rowgroup">Omsättningstillgångar</th>`)
+	g.linef(`<th colspan="4" scope="rowgroup">%s</th>`, g.t("Omsättningstillgångar"))
 	g.out()
 	g.line(`</tr>`)
 
 	// Varulager m.m.
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<th colspan="4" scope="rowgroup" class="sub sep">Varulager <abbr>m.m.</abbr>`)
+	g.linef(`<th colspan="4" scope="rowgroup" class="sub sep">%s`, g.t("Varulager <abbr>m.m.</abbr>"))
 	g.line(`</th>`)
 	g.out()
 	g.line(`</tr>`)
@@ -174,7 +174,7 @@ func (g *generator) writeBSCurrentAssets(bs *model.BalanceSheet) {
 	// Kortfristiga fordringar
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<th colspan="4" scope="rowgroup" class="sub sep">Kortfristiga fordringar</th>`)
+	g.linef(`<th colspan="4" scope="rowgroup" class="sub sep">%s</th>`, g.t("Kortfristiga fordringar"))
 	g.out()
 	g.line(`</tr>`)
 
@@ -199,7 +199,7 @@ func (g *generator) writeBSCurrentAssets(bs *model.BalanceSheet) {
 	// Kassa och bank
 	g.line(`<tr class="sep">`)
 	g.in()
-	g.line(`<th colspan="4" scope="rowgroup" class="sub">Kassa och bank</th>`)
+	g.linef(`<th colspan="4" scope="rowgroup" class="sub">%s</th>`, g.t("Kassa och bank"))
 	g.out()
 	g.line(`</tr>`)
 
@@ -237,7 +237,7 @@ func (g *generator) writeBalanceSheetEquityLiabilities(r *model.AnnualReport) {
 	g.line(`<div class="ar-page wide" id="ar3-page-6">`)
 	g.in()
 	g.pageHeader(r.Company.Name, r.Company.OrgNr, 6, totalPages)
-	g.line(`<h2>Balansräkning</h2>`)
+	g.linef(`<h2>%s</h2>`, g.t("Balansräkning"))
 
 	g.line(`<table class="ar-balance-sheet ar-financial col-4">`)
 	g.in()
@@ -256,15 +256,15 @@ func (g *generator) writeBalanceSheetEquityLiabilities(r *model.AnnualReport) {
 	g.in()
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<th scope="col">Balansräkning</th>`)
-	g.line(`<th scope="col">Not</th>`)
-	g.linef(`<th scope="col">%s</th>`, r.FiscalYear.EndDate)
-	g.linef(`<th scope="col">%s</th>`, prevEnd)
+	g.linef(`<th scope="col">%s</th>`, g.t("Balansräkning"))
+	g.linef(`<th scope="col">%s</th>`, g.t("Not"))
+	g.linef(`<th scope="col">%s</th>`, g.date(r.FiscalYear.EndDate))
+	g.linef(`<th scope="col">%s</th>`, g.date(prevEnd))
 	g.out()
 	g.line(`</tr>`)
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<th colspan="4" scope="colgroup">Eget kapital och skulder</th>`)
+	g.linef(`<th colspan="4" scope="colgroup">%s</th>`, g.t("Eget kapital och skulder"))
 	g.out()
 	g.line(`</tr>`)
 	g.out()
@@ -302,7 +302,7 @@ func (g *generator) writeBSEquity(el *model.EquityAndLiabilities) {
 	// Eget kapital header
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<th scope="colgroup">Eget kapital</th>`)
+	g.linef(`<th scope="colgroup">%s</th>`, g.t("Eget kapital"))
 	g.line(`<td />`)
 	g.line(`<td />`)
 	g.line(`<td />`)
@@ -312,7 +312,7 @@ func (g *generator) writeBSEquity(el *model.EquityAndLiabilities) {
 	// Bundet eget kapital
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<th colspan="4" scope="colgroup" class="sub sep">Bundet eget kapital</th>`)
+	g.linef(`<th colspan="4" scope="colgroup" class="sub sep">%s</th>`, g.t("Bundet eget kapital"))
 	g.out()
 	g.line(`</tr>`)
 
@@ -331,7 +331,7 @@ func (g *generator) writeBSEquity(el *model.EquityAndLiabilities) {
 	// Fritt eget kapital
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<th colspan="4" scope="colgroup" class="sub sep">Fritt eget kapital</th>`)
+	g.linef(`<th colspan="4" scope="colgroup" class="sub sep">%s</th>`, g.t("Fritt eget kapital"))
 	g.out()
 	g.line(`</tr>`)
 
@@ -365,7 +365,7 @@ func (g *generator) writeBSUntaxedReserves(el *model.EquityAndLiabilities) {
 
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<th colspan="4" scope="rowgroup">Obeskattade reserver</th>`)
+	g.linef(`<th colspan="4" scope="rowgroup">%s</th>`, g.t("Obeskattade reserver"))
 	g.out()
 	g.line(`</tr>`)
 
@@ -394,11 +394,11 @@ func (g *generator) writeBSProvisions(el *model.EquityAndLiabilities) {
 
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<th colspan="4" scope="rowgroup">Avsättningar</th>`)
+	g.linef(`<th colspan="4" scope="rowgroup">%s</th>`, g.t("Avsättningar"))
 	g.out()
 	g.line(`</tr>`)
 
-	g.writeBalanceRow("Avsättningar för pensioner och liknande förpliktelser enligt lagen (1967:531)\n          om tryggande av pensionsutfästelse <abbr>m.m.</abbr>", 0, nil,
+	g.writeBalanceRow("Avsättningar för pensioner och liknande förpliktelser enligt lagen (1967:531) om tryggande av pensionsutfästelse <abbr>m.m.</abbr>", 0, nil,
 		"se-gen-base:AvsattningarPensionerLiknandeForpliktelserEnligtLag",
 		ycv(prov.PensionProvisions), false, false, false)
 
@@ -424,7 +424,7 @@ func (g *generator) writeBSLongTermLiabilities(el *model.EquityAndLiabilities) {
 	// Långfristiga skulder header with note ref
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<th colspan="1" scope="rowgroup">Långfristiga skulder</th>`)
+	g.linef(`<th colspan="1" scope="rowgroup">%s</th>`, g.t("Långfristiga skulder"))
 	if lt.LongTermLiabilitiesNote > 0 {
 		g.linef(`<td><a href="#note-%d">%d</a></td>`, lt.LongTermLiabilitiesNote, lt.LongTermLiabilitiesNote)
 	} else {
@@ -463,7 +463,7 @@ func (g *generator) writeBSShortTermLiabilities(el *model.EquityAndLiabilities)
 
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<th colspan="4" scope="rowgroup">Kortfristiga skulder</th>`)
+	g.linef(`<th colspan="4" scope="rowgroup">%s</th>`, g.t("Kortfristiga skulder"))
 	g.out()
 	g.line(`</tr>`)
 
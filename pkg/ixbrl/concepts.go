@@ -0,0 +1,176 @@
+package ixbrl
+
+import (
+	"sort"
+
+	"github.com/redofri/redofri/pkg/taxonomy"
+)
+
+// supportedConcepts lists the taxonomy concepts the generator writes and
+// the parser maps, apart from the fixed asset note concepts, which are
+// formed from knownAssetPrefixes and fixedAssetNoteSuffixes.
+var supportedConcepts = []string{
+	// se-cd-base
+	"se-cd-base:Beloppsformat",
+	"se-cd-base:ForetagetsNamn",
+	"se-cd-base:Land",
+	"se-cd-base:Organisationsnummer",
+	"se-cd-base:RakenskapsarForstaDag",
+	"se-cd-base:RakenskapsarSistaDag",
+	"se-cd-base:Redovisningsvaluta",
+	"se-cd-base:Sprak",
+	// se-gen-base
+	"se-gen-base:AckumuleradeOveravskrivningar",
+	"se-gen-base:Aktiekapital",
+	"se-gen-base:AllmantVerksamheten",
+	"se-gen-base:AndraLangfristigaVardepappersinnehav",
+	"se-gen-base:Anlaggningstillgangar",
+	"se-gen-base:AretsResultat",
+	"se-gen-base:AretsResultatEgetKapital",
+	"se-gen-base:Avsattningar",
+	"se-gen-base:AvsattningarPensionerLiknandeForpliktelserEnligtLag",
+	"se-gen-base:AvskrivningarMateriellaAnlaggningstillgangarByggnaderAr",
+	"se-gen-base:AvskrivningarMateriellaAnlaggningstillgangarInventarierVerktygInstallationerAr",
+	"se-gen-base:AvskrivningarMateriellaAnlaggningstillgangarKommentar",
+	"se-gen-base:AvskrivningarMateriellaAnlaggningstillgangarMaskinerAndraTekniskaAnlaggningarAr",
+	"se-gen-base:AvskrivningarNedskrivningarMateriellaImmateriellaAnlaggningstillgangar",
+	"se-gen-base:BalanseratResultat",
+	"se-gen-base:Bokslutsdispositioner",
+	"se-gen-base:BundetEgetKapital",
+	"se-gen-base:ByggnaderMark",
+	"se-gen-base:EgetKapital",
+	"se-gen-base:EgetKapitalSkulder",
+	"se-gen-base:EventualForpliktelser",
+	"se-gen-base:FinansiellaAnlaggningstillgangar",
+	"se-gen-base:FinansiellaPoster",
+	"se-gen-base:ForandringEgetKapitalAretsResultatAretsResultat",
+	"se-gen-base:ForandringEgetKapitalAretsResultatUtdelning",
+	"se-gen-base:ForandringEgetKapitalTotalt",
+	"se-gen-base:ForandringEgetKapitalTotaltAretsResultat",
+	"se-gen-base:ForandringEgetKapitalTotaltUtdelning",
+	"se-gen-base:ForandringLagerProdukterIArbeteFardigaVarorPagaendeArbetenAnnansRakning",
+	"se-gen-base:ForandringOveravskrivningar",
+	"se-gen-base:ForandringPeriodiseringsfond",
+	"se-gen-base:ForslagDisposition",
+	"se-gen-base:ForslagDispositionBalanserasINyRakning",
+	"se-gen-base:ForslagDispositionUtdelning",
+	"se-gen-base:ForutbetaldaKostnaderUpplupnaIntakter",
+	"se-gen-base:FrittEgetKapital",
+	"se-gen-base:HandelsvarorKostnader",
+	"se-gen-base:InventarierVerktygInstallationer",
+	"se-gen-base:KassaBank",
+	"se-gen-base:KassaBankExklRedovisningsmedel",
+	"se-gen-base:KommentarFlerarsoversikt",
+	"se-gen-base:KortfristigaFordringar",
+	"se-gen-base:KortfristigaSkulder",
+	"se-gen-base:Kundfordringar",
+	"se-gen-base:LagerFardigaVarorHandelsvaror",
+	"se-gen-base:LagerRavarorFornodenheter",
+	"se-gen-base:LagerVarorUnderTillverkning",
+	"se-gen-base:LangfristigaSkulder",
+	"se-gen-base:LangfristigaSkulderForfallerSenare5Ar",
+	"se-gen-base:Leverantorsskulder",
+	"se-gen-base:LopandeBokforingenAvslutasMening",
+	"se-gen-base:MaskinerAndraTekniskaAnlaggningar",
+	"se-gen-base:MateriellaAnlaggningstillgangar",
+	"se-gen-base:MedelDisponera",
+	"se-gen-base:MedelantaletAnstallda",
+	"se-gen-base:Nettoomsattning",
+	"se-gen-base:NotTillgangarAvsattningarSkulderAvserFleraPoster",
+	"se-gen-base:ObeskattadeReserver",
+	"se-gen-base:Omsattningstillgangar",
+	"se-gen-base:OvrigaAvsattningar",
+	"se-gen-base:OvrigaExternaKostnader",
+	"se-gen-base:OvrigaFordringarKortfristiga",
+	"se-gen-base:OvrigaKortfristigaSkulder",
+	"se-gen-base:OvrigaLangfristigaSkulder",
+	"se-gen-base:OvrigaLangfristigaSkulderKreditinstitut",
+	"se-gen-base:OvrigaRanteintakterLiknandeResultatposter",
+	"se-gen-base:OvrigaRorelseintakter",
+	"se-gen-base:OvrigaRorelsekostnader",
+	"se-gen-base:Periodiseringsfonder",
+	"se-gen-base:Personalkostnader",
+	"se-gen-base:RantekostnaderLiknandeResultatposter",
+	"se-gen-base:RavarorFornodenheterKostnader",
+	"se-gen-base:Redovisningsprinciper",
+	"se-gen-base:RedovisningsprinciperAnskaffningsvardeEgentillverkadevaror",
+	"se-gen-base:Reservfond",
+	"se-gen-base:ResultatEfterFinansiellaPoster",
+	"se-gen-base:ResultatForeSkatt",
+	"se-gen-base:ResultatOvrigaFinansiellaAnlaggningstillgangar",
+	"se-gen-base:RorelseintakterLagerforandringarMm",
+	"se-gen-base:Rorelsekostnader",
+	"se-gen-base:Rorelseresultat",
+	"se-gen-base:SkattAretsResultat",
+	"se-gen-base:Skatteskulder",
+	"se-gen-base:Soliditet",
+	"se-gen-base:StalldaSakerheter",
+	"se-gen-base:StalldaSakerheterFastighetsinteckningar",
+	"se-gen-base:StalldaSakerheterForetagsinteckningar",
+	"se-gen-base:StyrelsensYttrandeVinstutdelning",
+	"se-gen-base:Tillgangar",
+	"se-gen-base:TillgangarAvsattningarSkulderBelopp",
+	"se-gen-base:TillgangarAvsattningarSkulderPost",
+	"se-gen-base:TillgangarAvsattningarSkulderTuple",
+	"se-gen-base:UnderskriftArsredovisningForetradareEfternamn",
+	"se-gen-base:UnderskriftArsredovisningForetradareForetradarroll",
+	"se-gen-base:UnderskriftArsredovisningForetradareTilltalsnamn",
+	"se-gen-base:UnderskriftArsredovisningForetradareTuple",
+	"se-gen-base:UndertecknandeArsredovisningDatum",
+	"se-gen-base:UndertecknandeArsredovisningOrt",
+	"se-gen-base:UpplupnaKostnaderForutbetaldaIntakter",
+	"se-gen-base:VarulagerMm",
+	"se-gen-base:VasentligaHandelserRakenskapsaret",
+	// se-bol-base
+	"se-bol-base:Arsstamma",
+	"se-bol-base:ArsstammaIntygande",
+	"se-bol-base:ArsstammaResultatDispositionGodkannaStyrelsensForslag",
+	"se-bol-base:FaststallelseResultatBalansrakning",
+	"se-bol-base:IntygandeOriginalInnehall",
+	"se-bol-base:UnderskriftFastallelseintygDatum",
+	"se-bol-base:UnderskriftFaststallelseintygElektroniskt",
+	"se-bol-base:UnderskriftFaststallelseintygForetradareEfternamn",
+	"se-bol-base:UnderskriftFaststallelseintygForetradareForetradarroll",
+	"se-bol-base:UnderskriftFaststallelseintygForetradareTilltalsnamn",
+}
+
+// fixedAssetNoteSuffixes are appended to a fixed asset's concept, e.g.
+// ByggnaderMark, to give the concepts of its note.
+var fixedAssetNoteSuffixes = []string{
+	"Anskaffningsvarden",
+	"ForandringAnskaffningsvardenInkop",
+	"ForandringAnskaffningsvardenForsaljningar",
+	"Avskrivningar",
+	"ForandringAvskrivningarAretsAvskrivningar",
+}
+
+// Concepts returns the taxonomy concepts redofri supports, as QNames with
+// the canonical prefixes, sorted. A report can only carry these facts
+// through the model; others are kept as passthrough facts.
+func Concepts() []string {
+	concepts := append([]string(nil), supportedConcepts...)
+	for _, ap := range knownAssetPrefixes {
+		for _, suffix := range fixedAssetNoteSuffixes {
+			concepts = append(concepts, nsGen+ap.prefix+suffix)
+		}
+	}
+	sort.Strings(concepts)
+	return concepts
+}
+
+// ConceptsFor returns the concepts redofri writes for a K2 taxonomy
+// version, or nil if the version is unknown. The empty version gives
+// TaxonomyVersion.
+func ConceptsFor(version string) []string {
+	release := taxonomy.K2Release(version)
+	if release == nil {
+		return nil
+	}
+	var concepts []string
+	for _, c := range Concepts() {
+		if release.Declares(c) {
+			concepts = append(concepts, c)
+		}
+	}
+	return concepts
+}
@@ -18,11 +18,18 @@ func (g *generator) writeCoverPage(r *model.AnnualReport) {
 	// Company logo / name block
 	g.line(`<div class="ar-logo">`)
 	g.in()
+	if g.logoURI != "" {
+		alt := r.Company.Logo.Alt
+		if alt == "" {
+			alt = r.Company.Name
+		}
+		g.linef(`<img class="ar-cover-logo" src="%s" alt="%s" />`, g.logoURI, esc(alt))
+	}
 	g.write("\t")
 	g.nonNumeric("se-cd-base:ForetagetsNamn", "period0", r.Company.Name)
 	g.write("\n")
 	g.line(`<br />`)
-	g.line(`<abbr>Org nr</abbr>`)
+	g.linef(`<abbr>%s</abbr>`, g.t("Org nr"))
 	g.write("\t")
 	g.nonNumeric("se-cd-base:Organisationsnummer", "period0", r.Company.OrgNr)
 	g.write("\n")
@@ -30,21 +37,25 @@ func (g *generator) writeCoverPage(r *model.AnnualReport) {
 	g.line(`</div>`)
 
 	// Year heading
-	g.linef(`<h2>Årsredovisning för räkenskapsåret %s</h2>`, yearLabel)
+	if g.themeTpls != nil && g.themeTpls.cover != nil {
+		g.themeTemplate(g.themeTpls.cover, 1, totalPages)
+	} else {
+		g.linef(`<h2>%s</h2>`, g.lbl.Tf("Årsredovisning för räkenskapsåret %s", yearLabel))
+	}
 	g.line(`<div class="ar-cover-meta">`)
 	g.in()
 	g.write(indentStr(g.indent))
-	g.write(`<div class="ar-cover-meta-item"><span>Bolag</span><strong>`)
+	g.writef(`<div class="ar-cover-meta-item"><span>%s</span><strong>`, g.t("Bolag"))
 	g.nonNumeric("se-cd-base:ForetagetsNamn", "period0", r.Company.Name)
 	g.write(`</strong></div>`)
 	g.write("\n")
 	g.write(indentStr(g.indent))
-	g.write(`<div class="ar-cover-meta-item"><span>Organisationsnummer</span><strong>`)
+	g.writef(`<div class="ar-cover-meta-item"><span>%s</span><strong>`, g.t("Organisationsnummer"))
 	g.nonNumeric("se-cd-base:Organisationsnummer", "period0", r.Company.OrgNr)
 	g.write(`</strong></div>`)
 	g.write("\n")
-	g.linef(`<div class="ar-cover-meta-item"><span>Räkenskapsår</span><strong>%s - %s</strong></div>`, esc(r.FiscalYear.StartDate), esc(r.FiscalYear.EndDate))
-	g.linef(`<div class="ar-cover-meta-item"><span>Valuta</span><strong>%s</strong></div>`, esc(r.Meta.Currency))
+	g.linef(`<div class="ar-cover-meta-item"><span>%s</span><strong>%s - %s</strong></div>`, g.t("Räkenskapsår"), esc(g.date(r.FiscalYear.StartDate)), esc(g.date(r.FiscalYear.EndDate)))
+	g.linef(`<div class="ar-cover-meta-item"><span>%s</span><strong>%s</strong></div>`, g.t("Valuta"), esc(r.Meta.Currency))
 	g.out()
 	g.line(`</div>`)
 
@@ -59,7 +70,7 @@ func (g *generator) writeCoverPage(r *model.AnnualReport) {
 	g.writeTOC(r, totalPages)
 
 	// Standard note about amounts
-	g.line(`<p class="ar-amount-note">Om inte annat särskilt anges, redovisas alla belopp i hela kronor. Uppgifter inom parentes avser föregående år.</p>`)
+	g.linef(`<p class="ar-amount-note">%s</p>`, g.t("Om inte annat särskilt anges, redovisas alla belopp i hela kronor. Uppgifter inom parentes avser föregående år."))
 
 	// Fastställelseintyg
 	g.writeCertification(r)
@@ -76,8 +87,8 @@ func (g *generator) writeTOC(r *model.AnnualReport, totalPages int) {
 	g.in()
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<th scope="col">Innehåll</th>`)
-	g.line(`<th scope="col">Sida</th>`)
+	g.linef(`<th scope="col">%s</th>`, g.t("Innehåll"))
+	g.linef(`<th scope="col">%s</th>`, g.t("Sida"))
 	g.out()
 	g.line(`</tr>`)
 	g.out()
@@ -100,7 +111,7 @@ func (g *generator) writeTOC(r *model.AnnualReport, totalPages int) {
 func (g *generator) writeTOCRow(label, pageID string, pageNum int) {
 	g.line(`<tr>`)
 	g.in()
-	g.linef(`<td><span>-</span> %s</td>`, label)
+	g.linef(`<td><span>-</span> %s</td>`, g.t(label))
 	g.linef(`<td><a href="#%s">%d</a></td>`, pageID, pageNum)
 	g.out()
 	g.line(`</tr>`)
@@ -112,28 +123,37 @@ func (g *generator) writeCertification(r *model.AnnualReport) {
 
 	g.line(`<div id="ar-certification">`)
 	g.in()
-	g.line(`<strong>Fastställelseintyg</strong><br/>`)
+	g.linef(`<strong>%s</strong><br/>`, g.t("Fastställelseintyg"))
 
 	// Main certification text with continuation
 	g.write("\t\t\t\t\t")
 	g.write(`<p>`)
 	// ArsstammaIntygande wraps the first part, with continuedAt
-	g.writef(`<ix:nonNumeric name="se-bol-base:ArsstammaIntygande" contextRef="balans0" continuedAt="intygande_forts">`)
+	intygande := g.release.Declares("se-bol-base:ArsstammaIntygande")
+	if intygande {
+		g.writef(`<ix:nonNumeric name="se-bol-base:ArsstammaIntygande" contextRef="balans0" continuedAt="intygande_forts">`)
+	}
 	g.nonNumeric("se-bol-base:FaststallelseResultatBalansrakning", "balans0", cert.ConfirmationText)
 	g.write(` `)
 	g.nonNumeric("se-bol-base:Arsstamma", "balans0", cert.MeetingDate)
 	g.write(`. <br/>`)
 	g.nonNumeric("se-bol-base:ArsstammaResultatDispositionGodkannaStyrelsensForslag", "balans0", cert.DispositionDecision)
-	g.write(`</ix:nonNumeric>`)
+	if intygande {
+		g.write(`</ix:nonNumeric>`)
+	}
 	g.write(`</p>`)
 	g.write("\n")
 
 	// Continuation: original content certification
 	g.write("\t\t\t\t\t")
 	g.write(`<p>`)
-	g.write(`<ix:continuation id="intygande_forts"> `)
+	if intygande {
+		g.write(`<ix:continuation id="intygande_forts"> `)
+	}
 	g.nonNumeric("se-bol-base:IntygandeOriginalInnehall", "balans0", cert.OriginalContentCertification)
-	g.write(`</ix:continuation>`)
+	if intygande {
+		g.write(`</ix:continuation>`)
+	}
 	g.write(`</p>`)
 	g.write("\n")
 
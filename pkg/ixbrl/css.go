@@ -1,9 +1,10 @@
 package ixbrl
 
-// writeCSS writes the inline CSS stylesheet for the annual report.
+import "strings"
+
+// defaultCSS is the standard stylesheet for the annual report.
 // Extracted and cleaned from the reference example file.
-func (g *generator) writeCSS() {
-	g.raw(`
+const defaultCSS = `
 html {
 	font: 100%/1.4 Frutiger, Frutiger Linotype, Univers, DejaVu Sans Condensed, Liberation Sans, Nimbus Sans L, Geneva, Helvetica Neue, Helvetica, Arial, Tahoma, sans-serif; font-size-adjust: none; font-stretch: normal;
 	background: linear-gradient(180deg, rgb(242, 245, 247) 0%, rgb(233, 238, 242) 100%);
@@ -407,5 +408,36 @@ col.kr-2 {
 	background: none;
 }
 }
+`
+
+// writeCSS writes the inline CSS stylesheet for the annual report: the theme's
+// base stylesheet (the default one unless the theme replaces it) followed by
+// any extra theme rules.
+func (g *generator) writeCSS() {
+	base := defaultCSS
+	if g.theme != nil && g.theme.CSS != "" {
+		base = "\n" + strings.TrimLeft(g.theme.CSS, "\n")
+	}
+	g.raw(base)
+	if g.theme != nil && g.theme.ExtraCSS != "" {
+		g.raw(strings.TrimLeft(g.theme.ExtraCSS, "\n"))
+		if !strings.HasSuffix(g.theme.ExtraCSS, "\n") {
+			g.raw("\n")
+		}
+	}
+}
+
+// writeLogoCSS writes the page header logo rule. The data URI is emitted once
+// here and referenced from every page header.
+func (g *generator) writeLogoCSS() {
+	if g.logoURI == "" {
+		return
+	}
+	g.raw(`.ar-cover-logo {
+	display: block; max-height: 6em; width: auto; margin-bottom: 1em;
+}
+.ar-page-hdr-logo {
+	display: inline-block; width: 8em; height: 2.5em; background: url("` + g.logoURI + `") no-repeat left center; background-size: contain;
+}
 `)
 }
@@ -0,0 +1,62 @@
+package ixbrl
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/redofri/redofri/pkg/model"
+)
+
+// csvFactColumns is the header row of the CSV fact export.
+var csvFactColumns = []string{
+	"entity", "concept", "context", "period_start", "period_end", "instant",
+	"unit", "decimals", "value", "tuple", "tuple_id", "tuple_order", "fact_id",
+}
+
+// WriteCSVFacts writes the facts that Generate tags for r as CSV, one row
+// per fact.
+func WriteCSVFacts(w io.Writer, r *model.AnnualReport) error {
+	doc, err := GenerateBytes(r)
+	if err != nil {
+		return err
+	}
+	return ExtractCSVFacts(w, bytes.NewReader(doc))
+}
+
+// ExtractCSVFacts reads an inline XBRL document and writes its facts as
+// CSV with a header row. Whitespace in text values is collapsed to single
+// spaces so that every fact stays on one line; nil facts have an empty
+// value.
+func ExtractCSVFacts(w io.Writer, doc io.Reader) error {
+	d, err := ReadDocument(doc)
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvFactColumns); err != nil {
+		return err
+	}
+	for _, f := range d.Facts {
+		unit, tuple, tupleID := "", "", ""
+		if f.Unit != nil {
+			unit = f.Unit.String()
+		}
+		if f.Tuple != nil {
+			tuple, tupleID = f.Tuple.Concept, f.Tuple.ID
+		}
+		c := f.Context
+		record := []string{
+			c.Entity.Identifier, f.Concept, c.ID, c.Period.StartDate, c.Period.EndDate, c.Period.Instant,
+			unit, f.Decimals, strings.Join(strings.Fields(f.Value), " "),
+			tuple, tupleID, f.Order, f.ID,
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
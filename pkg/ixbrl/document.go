@@ -0,0 +1,358 @@
+package ixbrl
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Document is an inline XBRL document read at fact level. Unlike Parse,
+// which maps facts onto model.AnnualReport, a Document keeps every fact of
+// any K2 iXBRL report together with its resolved context and unit.
+type Document struct {
+	// SchemaRefs lists the taxonomy entry points from ix:references.
+	SchemaRefs []string
+
+	// Namespaces maps the prefixes declared in the document to namespace
+	// URIs, leaving out XHTML and inline XBRL.
+	Namespaces map[string]string
+
+	// Contexts and Units are the xbrli:context and xbrli:unit definitions
+	// from ix:resources, keyed by id.
+	Contexts map[string]*Context
+	Units    map[string]*Unit
+
+	// Facts holds every ix:nonFraction and ix:nonNumeric in document order.
+	Facts []*Fact
+
+	// Tuples holds every ix:tuple in document order.
+	Tuples []*Tuple
+}
+
+// Context is an xbrli:context.
+type Context struct {
+	ID         string
+	Entity     Entity
+	Period     Period
+	Dimensions []Dimension // segment and scenario members, if any
+}
+
+// Entity identifies the reporting entity, e.g. an organisationsnummer with
+// scheme http://www.bolagsverket.se.
+type Entity struct {
+	Scheme     string
+	Identifier string
+}
+
+// Period is either an instant or a duration. Dates are as written in the
+// document, normally YYYY-MM-DD.
+type Period struct {
+	Instant   string
+	StartDate string
+	EndDate   string
+}
+
+// IsInstant reports whether the period is an instant.
+func (p Period) IsInstant() bool {
+	return p.Instant != ""
+}
+
+// Dimension is an explicit or typed dimension member of a context.
+type Dimension struct {
+	Dimension string // QName of the dimension
+	Member    string // QName of the member, or the typed value
+	Typed     bool
+}
+
+// Unit is an xbrli:unit. Simple units have a single numerator measure.
+type Unit struct {
+	ID          string
+	Numerator   []string // measure QNames, e.g. "iso4217:SEK"
+	Denominator []string
+}
+
+// String formats the unit in the OIM unit string syntax, e.g.
+// "iso4217:SEK" or "iso4217:SEK/xbrli:shares".
+func (u *Unit) String() string {
+	join := func(ms []string) string {
+		if len(ms) > 1 {
+			return "(" + strings.Join(ms, "*") + ")"
+		}
+		return strings.Join(ms, "*")
+	}
+	if len(u.Denominator) == 0 {
+		return strings.Join(u.Numerator, "*")
+	}
+	return join(u.Numerator) + "/" + join(u.Denominator)
+}
+
+// Fact is an item fact: an ix:nonFraction or ix:nonNumeric.
+type Fact struct {
+	ID        string // id attribute, if any
+	Concept   string // QName with the canonical prefix, e.g. "se-gen-base:Nettoomsattning"
+	Namespace string // namespace URI of the concept's prefix
+	Numeric   bool   // true for ix:nonFraction
+
+	Context *Context
+	Unit    *Unit // nil for non-numeric facts
+
+	// Value is the XBRL value: for numeric facts the displayed number with
+	// format, scale and sign applied, for text facts the text including
+	// continuations. Nil facts have an empty value.
+	Value string
+	Nil   bool
+
+	// Display is the text shown in the document, before transformation.
+	Display string
+
+	// Numeric presentation attributes.
+	Format   string // transformation, e.g. "ixt:numspacecomma"
+	Scale    int
+	Sign     string // "-" when the displayed number is negated
+	Decimals string
+
+	// Tuple is the tuple the fact belongs to, or nil. Order is the
+	// position within it.
+	Tuple *Tuple
+	Order string
+}
+
+// LocalName returns the concept name without prefix.
+func (f *Fact) LocalName() string {
+	_, local, ok := strings.Cut(f.Concept, ":")
+	if !ok {
+		return f.Concept
+	}
+	return local
+}
+
+// Tuple is an ix:tuple with its members sorted by order.
+type Tuple struct {
+	ID      string // tupleID attribute, or a generated "tupleN"
+	Concept string
+	Order   string
+
+	Parent *Tuple // enclosing tuple, if nested
+	Facts  []*Fact
+	Tuples []*Tuple
+}
+
+// ReadDocument reads an inline XBRL document, or an XBRL instance, and
+// resolves the contexts, units, values and tuple membership of all its
+// facts.
+func ReadDocument(r io.Reader) (*Document, error) {
+	root, err := parseXMLTree(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading XML: %w", err)
+	}
+	if isInstance(root) {
+		return newInstanceDocument(root)
+	}
+	return newDocument(newInlineDocument(root))
+}
+
+// Concept returns the facts for a concept QName in document order.
+func (d *Document) Concept(name string) []*Fact {
+	var out []*Fact
+	for _, f := range d.Facts {
+		if f.Concept == name {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func newDocument(in *inlineDocument) (*Document, error) {
+	d := &Document{
+		SchemaRefs: in.schemaRefs,
+		Namespaces: map[string]string{},
+		Contexts:   map[string]*Context{},
+		Units:      map[string]*Unit{},
+	}
+	for prefix, uri := range in.namespaces {
+		if prefix == "" || uri == ixNS || uri == xhtmlNS || strings.HasPrefix(uri, ixtNSPrefix) {
+			continue
+		}
+		d.Namespaces[prefix] = uri
+	}
+	for _, res := range in.resources {
+		switch res.name.Local {
+		case "context":
+			c := readContext(res)
+			d.Contexts[c.ID] = c
+		case "unit":
+			u := readUnit(res)
+			d.Units[u.ID] = u
+		}
+	}
+
+	// Tuples first, so facts can point to them. Tuples without a tupleID
+	// are numbered in document order.
+	tuples := map[*xmlNode]*Tuple{}
+	for _, n := range in.facts {
+		if n.name.Local != "tuple" {
+			continue
+		}
+		t := &Tuple{
+			ID:      n.attr("", "tupleID"),
+			Concept: canonicalName(in, n.attr("", "name")),
+			Order:   n.attr("", "order"),
+		}
+		if t.ID == "" {
+			t.ID = "tuple" + strconv.Itoa(len(d.Tuples)+1)
+		}
+		tuples[n] = t
+		d.Tuples = append(d.Tuples, t)
+	}
+
+	for _, n := range in.facts {
+		parent, err := in.parentTuple(n)
+		if err != nil {
+			return nil, fmt.Errorf("fact %s: %w", n.attr("", "name"), err)
+		}
+		if n.name.Local == "tuple" {
+			if parent != nil {
+				t := tuples[n]
+				t.Parent = tuples[parent]
+				t.Parent.Tuples = append(t.Parent.Tuples, t)
+			}
+			continue
+		}
+
+		f, err := d.readFact(in, n)
+		if err != nil {
+			return nil, err
+		}
+		if parent != nil {
+			f.Tuple = tuples[parent]
+			f.Tuple.Facts = append(f.Tuple.Facts, f)
+		}
+		d.Facts = append(d.Facts, f)
+	}
+
+	for _, t := range d.Tuples {
+		sort.SliceStable(t.Facts, func(i, j int) bool { return orderValue(t.Facts[i].Order) < orderValue(t.Facts[j].Order) })
+		sort.SliceStable(t.Tuples, func(i, j int) bool { return orderValue(t.Tuples[i].Order) < orderValue(t.Tuples[j].Order) })
+	}
+	return d, nil
+}
+
+// readFact resolves a single ix:nonFraction or ix:nonNumeric element.
+func (d *Document) readFact(in *inlineDocument, n *xmlNode) (*Fact, error) {
+	name := n.attr("", "name")
+	prefix, _, _ := strings.Cut(name, ":")
+	f := &Fact{
+		ID:        n.attr("", "id"),
+		Concept:   canonicalName(in, name),
+		Namespace: in.namespaces[prefix],
+		Numeric:   n.name.Local == "nonFraction",
+		Nil:       n.attr(xsiNS, "nil") == "true",
+		Display:   strings.TrimSpace(textContent(n, false)),
+		Format:    n.attr("", "format"),
+		Sign:      n.attr("", "sign"),
+		Decimals:  n.attr("", "decimals"),
+		Order:     n.attr("", "order"),
+	}
+	if s := n.attr("", "scale"); s != "" {
+		scale, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, fmt.Errorf("fact %s: invalid scale %q", name, s)
+		}
+		f.Scale = scale
+	}
+
+	ctxRef := n.attr("", "contextRef")
+	ctx, ok := d.Contexts[ctxRef]
+	if !ok {
+		return nil, fmt.Errorf("fact %s: context %q not defined", name, ctxRef)
+	}
+	f.Context = ctx
+
+	if f.Numeric {
+		unitRef := n.attr("", "unitRef")
+		unit, ok := d.Units[unitRef]
+		if !ok {
+			return nil, fmt.Errorf("fact %s: unit %q not defined", name, unitRef)
+		}
+		f.Unit = unit
+	}
+
+	if !f.Nil {
+		v, err := in.value(n)
+		if err != nil {
+			return nil, err
+		}
+		f.Value = v
+	}
+	return f, nil
+}
+
+// readContext reads an xbrli:context element.
+func readContext(n *xmlNode) *Context {
+	c := &Context{ID: n.attr("", "id")}
+	var walk func(n *xmlNode)
+	walk = func(n *xmlNode) {
+		for _, ch := range n.children {
+			e, ok := ch.(*xmlNode)
+			if !ok {
+				continue
+			}
+			text := strings.TrimSpace(textContent(e, false))
+			switch e.name.Local {
+			case "identifier":
+				c.Entity = Entity{Scheme: e.attr("", "scheme"), Identifier: text}
+			case "startDate":
+				c.Period.StartDate = text
+			case "endDate":
+				c.Period.EndDate = text
+			case "instant":
+				c.Period.Instant = text
+			case "explicitMember":
+				c.Dimensions = append(c.Dimensions, Dimension{Dimension: e.attr("", "dimension"), Member: text})
+			case "typedMember":
+				c.Dimensions = append(c.Dimensions, Dimension{Dimension: e.attr("", "dimension"), Member: text, Typed: true})
+			default:
+				walk(e)
+			}
+		}
+	}
+	walk(n)
+	return c
+}
+
+// readUnit reads an xbrli:unit element.
+func readUnit(n *xmlNode) *Unit {
+	u := &Unit{ID: n.attr("", "id")}
+	var walk func(n *xmlNode, dst *[]string)
+	walk = func(n *xmlNode, dst *[]string) {
+		for _, ch := range n.children {
+			e, ok := ch.(*xmlNode)
+			if !ok {
+				continue
+			}
+			switch e.name.Local {
+			case "measure":
+				*dst = append(*dst, strings.TrimSpace(textContent(e, false)))
+			case "unitNumerator":
+				walk(e, &u.Numerator)
+			case "unitDenominator":
+				walk(e, &u.Denominator)
+			default:
+				walk(e, dst)
+			}
+		}
+	}
+	walk(n, &u.Numerator)
+	return u
+}
+
+// orderValue parses a tuple order attribute; missing orders sort first.
+func orderValue(s string) float64 {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
@@ -0,0 +1,334 @@
+package ixbrl
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+func readReferenceExample(t *testing.T) []byte {
+	t.Helper()
+	doc, err := os.ReadFile("../../ref/exempel/faststalld-arsredovisning-exempel-1.xhtml")
+	if err != nil {
+		t.Skipf("reference example not available: %v", err)
+	}
+	return doc
+}
+
+type testOIMFact struct {
+	Value      *string           `json:"value"`
+	Decimals   *int              `json:"decimals"`
+	Dimensions map[string]string `json:"dimensions"`
+	Tuple      *struct {
+		Concept string `json:"concept"`
+		ID      string `json:"id"`
+		Order   string `json:"order"`
+	} `json:"redofri:tuple"`
+}
+
+func TestExtractJSONFacts_ReferenceExample(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExtractJSONFacts(&buf, bytes.NewReader(readReferenceExample(t))); err != nil {
+		t.Fatalf("ExtractJSONFacts: %v", err)
+	}
+
+	var report struct {
+		DocumentInfo struct {
+			DocumentType string            `json:"documentType"`
+			Namespaces   map[string]string `json:"namespaces"`
+			Taxonomy     []string          `json:"taxonomy"`
+		} `json:"documentInfo"`
+		Facts map[string]testOIMFact `json:"facts"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	info := report.DocumentInfo
+	if info.DocumentType != "https://xbrl.org/2021/xbrl-json" {
+		t.Errorf("documentType = %q", info.DocumentType)
+	}
+	if info.Namespaces["scheme"] != "http://www.bolagsverket.se" {
+		t.Errorf("scheme namespace = %q", info.Namespaces["scheme"])
+	}
+	if info.Namespaces["se-gen-base"] != "http://www.taxonomier.se/se/fr/gen-base/2017-09-30" {
+		t.Errorf("se-gen-base namespace = %q", info.Namespaces["se-gen-base"])
+	}
+	if len(info.Taxonomy) != 2 {
+		t.Errorf("taxonomy = %v, want 2 schemas", info.Taxonomy)
+	}
+	if len(report.Facts) != 261 {
+		t.Errorf("facts = %d, want 261", len(report.Facts))
+	}
+
+	var netSales []testOIMFact
+	var signDate, tupleMember *testOIMFact
+	for id, f := range report.Facts {
+		switch {
+		case f.Dimensions["concept"] == "se-gen-base:Nettoomsattning" && f.Dimensions["period"] == "2016-01-01T00:00:00/2017-01-01T00:00:00":
+			netSales = append(netSales, f)
+		case id == "ID_DATUM_UNDERTECKNANDE_FASTSTALLELSEINTYG":
+			signDate = &f
+		case f.Dimensions["concept"] == "se-gen-base:UnderskriftArsredovisningForetradareTilltalsnamn" && f.Value != nil && *f.Value == "Karl":
+			tupleMember = &f
+		}
+	}
+
+	// Net sales is tagged in the income statement (decimals="INF") and in
+	// the flerårsöversikt in tkr (decimals="-3").
+	if len(netSales) != 2 {
+		t.Fatalf("Nettoomsattning for 2016 tagged %d times, want 2", len(netSales))
+	}
+	for _, f := range netSales {
+		if *f.Value != "2650000" || f.Dimensions["unit"] != "iso4217:SEK" || f.Dimensions["entity"] != "scheme:556999-9999" {
+			t.Errorf("Nettoomsattning = %+v", f)
+		}
+	}
+	if (netSales[0].Decimals == nil) == (netSales[1].Decimals == nil) {
+		t.Error("decimals should be omitted for INF and kept for -3")
+	}
+
+	if signDate == nil {
+		t.Fatal("fact id from the document not kept")
+	}
+	if signDate.Dimensions["period"] != "2017-01-01T00:00:00" || signDate.Dimensions["unit"] != "" {
+		t.Errorf("signing date = %+v", signDate)
+	}
+
+	if tupleMember == nil || tupleMember.Tuple == nil {
+		t.Fatal("missing tuple membership")
+	}
+	if tupleMember.Tuple.Concept != "se-gen-base:UnderskriftArsredovisningForetradareTuple" || tupleMember.Tuple.ID == "" {
+		t.Errorf("tuple = %+v", tupleMember.Tuple)
+	}
+}
+
+func TestExtractCSVFacts_ReferenceExample(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExtractCSVFacts(&buf, bytes.NewReader(readReferenceExample(t))); err != nil {
+		t.Fatalf("ExtractCSVFacts: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if got := strings.Join(records[0], ","); got != strings.Join(csvFactColumns, ",") {
+		t.Errorf("header = %q", got)
+	}
+	if len(records) != 262 {
+		t.Errorf("rows = %d, want 261 facts and a header", len(records))
+	}
+
+	col := map[string]int{}
+	for i, name := range records[0] {
+		col[name] = i
+	}
+	found := false
+	for _, rec := range records[1:] {
+		if strings.Contains(rec[col["value"]], "\n") {
+			t.Errorf("value of %s spans several lines", rec[col["concept"]])
+		}
+		if rec[col["concept"]] == "se-gen-base:Soliditet" && rec[col["instant"]] == "2016-12-31" {
+			found = true
+			if rec[col["value"]] != "0.337" || rec[col["unit"]] != "xbrli:pure" || rec[col["entity"]] != "556999-9999" {
+				t.Errorf("Soliditet row = %v", rec)
+			}
+		}
+	}
+	if !found {
+		t.Error("missing Soliditet for 2016-12-31")
+	}
+}
+
+func TestWriteFactExports(t *testing.T) {
+	r := loadTestReport(t)
+
+	var jsonBuf, csvBuf bytes.Buffer
+	if err := WriteJSONFacts(&jsonBuf, r); err != nil {
+		t.Fatalf("WriteJSONFacts: %v", err)
+	}
+	if err := WriteCSVFacts(&csvBuf, r); err != nil {
+		t.Fatalf("WriteCSVFacts: %v", err)
+	}
+
+	var report struct {
+		Facts map[string]testOIMFact `json:"facts"`
+	}
+	if err := json.Unmarshal(jsonBuf.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	records, err := csv.NewReader(&csvBuf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(records)-1 != len(report.Facts) {
+		t.Errorf("CSV has %d facts, xBRL-JSON has %d", len(records)-1, len(report.Facts))
+	}
+}
+
+func TestReadDocument_ReferenceExample(t *testing.T) {
+	d, err := ReadDocument(bytes.NewReader(readReferenceExample(t)))
+	if err != nil {
+		t.Fatalf("ReadDocument: %v", err)
+	}
+
+	if len(d.Facts) != 261 {
+		t.Errorf("got %d facts, want 261", len(d.Facts))
+	}
+	if len(d.SchemaRefs) == 0 {
+		t.Error("no schema refs")
+	}
+	if d.Namespaces["se-gen-base"] == "" {
+		t.Error("se-gen-base namespace not resolved")
+	}
+
+	ctx := d.Contexts["period0"]
+	if ctx == nil {
+		t.Fatal("context period0 missing")
+	}
+	if ctx.Entity.Identifier != "556999-9999" || ctx.Entity.Scheme != "http://www.bolagsverket.se" {
+		t.Errorf("period0 entity = %+v", ctx.Entity)
+	}
+	if ctx.Period.IsInstant() || ctx.Period.StartDate != "2016-01-01" || ctx.Period.EndDate != "2016-12-31" {
+		t.Errorf("period0 period = %+v", ctx.Period)
+	}
+	if p := d.Contexts["balans0"].Period; !p.IsInstant() || p.Instant != "2016-12-31" {
+		t.Errorf("balans0 period = %+v", p)
+	}
+
+	t.Run("numeric", func(t *testing.T) {
+		facts := d.Concept("se-gen-base:Soliditet")
+		if len(facts) != 4 {
+			t.Fatalf("got %d Soliditet facts, want 4", len(facts))
+		}
+		f := facts[0]
+		if f.Context.ID != "balans0" || f.Unit == nil || f.Unit.String() != "xbrli:pure" {
+			t.Errorf("Soliditet context/unit = %s/%v", f.Context.ID, f.Unit)
+		}
+		if !f.Numeric || f.Display != "33,7" || f.Format != "ixt:numcomma" || f.Scale != -2 || f.Value != "0.337" {
+			t.Errorf("Soliditet = %+v", f)
+		}
+		if f.Namespace != d.Namespaces["se-gen-base"] || f.LocalName() != "Soliditet" {
+			t.Errorf("Soliditet namespace/local name = %s/%s", f.Namespace, f.LocalName())
+		}
+		for _, f := range d.Concept("se-gen-base:Nettoomsattning") {
+			if f.Unit.String() != "iso4217:SEK" {
+				t.Errorf("Nettoomsattning unit = %s", f.Unit)
+			}
+		}
+	})
+
+	t.Run("tuples", func(t *testing.T) {
+		if len(d.Tuples) != 4 {
+			t.Fatalf("got %d tuples, want 4", len(d.Tuples))
+		}
+		var signer *Tuple
+		for _, tu := range d.Tuples {
+			if tu.ID == "UnderskriftArsredovisningForetradareTuple1" {
+				signer = tu
+			}
+		}
+		if signer == nil {
+			t.Fatal("signer tuple missing")
+		}
+		if len(signer.Facts) < 2 {
+			t.Fatalf("signer tuple has %d facts", len(signer.Facts))
+		}
+		first, second := signer.Facts[0], signer.Facts[1]
+		if first.LocalName() != "UnderskriftArsredovisningForetradareTilltalsnamn" || first.Value != "Karl" {
+			t.Errorf("first member = %s %q", first.Concept, first.Value)
+		}
+		if second.Value != "Karlsson" || second.Tuple != signer {
+			t.Errorf("second member = %q in %v", second.Value, second.Tuple)
+		}
+	})
+}
+
+// TestReadDocument_Instance checks that the reference example's XBRL
+// instance has the facts of its iXBRL document.
+func TestReadDocument_Instance(t *testing.T) {
+	inline, err := ReadDocument(bytes.NewReader(readReferenceExample(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile("../../ref/exempel/faststalld-arsredovisning-exempel-1.xbrl")
+	if err != nil {
+		t.Skipf("reference instance not available: %v", err)
+	}
+	d, err := ReadDocument(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("ReadDocument: %v", err)
+	}
+	if len(d.Facts) != len(inline.Facts) || len(d.Tuples) != len(inline.Tuples) || len(d.SchemaRefs) != 2 {
+		t.Fatalf("got %d facts, %d tuples, schemaRefs %v", len(d.Facts), len(d.Tuples), d.SchemaRefs)
+	}
+
+	key := func(f *Fact) string {
+		tuple := ""
+		if f.Tuple != nil {
+			tuple = f.Tuple.Concept
+		}
+		return strings.Join([]string{f.Concept, f.Context.ID, tuple, strings.Join(strings.Fields(f.Value), " ")}, "|")
+	}
+	inlineFacts := map[string]bool{}
+	for _, f := range inline.Facts {
+		inlineFacts[key(f)] = true
+	}
+	for _, f := range d.Facts {
+		if !inlineFacts[key(f)] {
+			t.Errorf("instance fact %s is not in the iXBRL document", key(f))
+		}
+	}
+
+	f := d.Concept("se-gen-base:Soliditet")[0]
+	if !f.Numeric || f.Unit.String() != "xbrli:pure" || f.Value != "0.337" || f.Namespace != d.Namespaces["se-gen-base"] {
+		t.Errorf("Soliditet = %+v", f)
+	}
+	signer := d.Concept("se-gen-base:UnderskriftArsredovisningForetradareTilltalsnamn")[0]
+	if signer.Tuple == nil || signer.Order != "1" || signer.Tuple.Facts[0] != signer {
+		t.Errorf("tuple member = %+v", signer)
+	}
+}
+
+func TestUnitString(t *testing.T) {
+	tests := []struct {
+		unit Unit
+		want string
+	}{
+		{Unit{Numerator: []string{"iso4217:SEK"}}, "iso4217:SEK"},
+		{Unit{Numerator: []string{"iso4217:SEK"}, Denominator: []string{"xbrli:shares"}}, "iso4217:SEK/xbrli:shares"},
+		{Unit{Numerator: []string{"a:m", "a:n"}, Denominator: []string{"a:s"}}, "(a:m*a:n)/a:s"},
+	}
+	for _, tt := range tests {
+		if got := tt.unit.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.unit, got, tt.want)
+		}
+	}
+}
+
+func TestReadDocument_UndefinedContext(t *testing.T) {
+	doc := `<html xmlns:ix="http://www.xbrl.org/2013/inlineXBRL"><ix:nonNumeric name="a:X" contextRef="nope">x</ix:nonNumeric></html>`
+	if _, err := ReadDocument(strings.NewReader(doc)); err == nil || !strings.Contains(err.Error(), `context "nope" not defined`) {
+		t.Fatalf("ReadDocument error = %v, want undefined context", err)
+	}
+}
+
+func TestOIMPeriod(t *testing.T) {
+	tests := []struct {
+		period Period
+		want   string
+	}{
+		{Period{Instant: "2016-12-31"}, "2017-01-01T00:00:00"},
+		{Period{StartDate: "2016-01-01", EndDate: "2016-12-31"}, "2016-01-01T00:00:00/2017-01-01T00:00:00"},
+		{Period{StartDate: "2024-02-01", EndDate: "2024-02-29"}, "2024-02-01T00:00:00/2024-03-01T00:00:00"},
+	}
+	for _, tt := range tests {
+		if got := oimPeriod(tt.period); got != tt.want {
+			t.Errorf("oimPeriod(%+v) = %q, want %q", tt.period, got, tt.want)
+		}
+	}
+}
@@ -5,37 +5,35 @@ import (
 	"strings"
 )
 
-// formatAmount formats an int64 (whole kronor) as "1 234 567" using space as
-// thousands separator and comma as decimal separator (ixt:numspacecomma).
-// For zero, returns "0".
-func formatAmount(v int64) string {
-	if v == 0 {
-		return "0"
-	}
-	negative := v < 0
-	if negative {
-		v = -v
-	}
-	s := fmt.Sprintf("%d", v)
-	// Insert space every 3 digits from the right
-	var parts []string
-	for len(s) > 3 {
-		parts = append([]string{s[len(s)-3:]}, parts...)
-		s = s[:len(s)-3]
-	}
-	parts = append([]string{s}, parts...)
-	result := strings.Join(parts, " ")
-	if negative {
-		result = "-" + result
-	}
-	return result
+// formatAmount formats an int64 (whole kronor) for display in the report
+// language: "1 234 567" in Swedish (ixt:numspacecomma), "1,234,567" in
+// English (ixt:numcommadot). For zero, returns "0".
+func (g *generator) formatAmount(v int64) string {
+	return g.lbl.Int(v)
 }
 
 // formatAmountTkr formats an int64 (whole kronor) in tkr (thousands).
 // 2650000 → "2 650", 0 → "0"
-func formatAmountTkr(v int64) string {
-	tkr := v / 1000
-	return formatAmount(tkr)
+func (g *generator) formatAmountTkr(v int64) string {
+	return g.formatAmount(v / 1000)
+}
+
+// numberFormat returns the ixt transformation matching formatAmount.
+func (g *generator) numberFormat() string {
+	if g.lbl.DecimalSeparator() == "." {
+		return "ixt:numcommadot"
+	}
+	return "ixt:numspacecomma"
+}
+
+// decimalFormat returns the ixt transformation for decimal numbers without
+// thousands separators, such as percentages. Both are in TR1, the
+// registry the generator declares.
+func (g *generator) decimalFormat() string {
+	if g.lbl.DecimalSeparator() == "." {
+		return "ixt:numcommadot"
+	}
+	return "ixt:numcomma"
 }
 
 // nonFraction writes an ix:nonFraction element for a monetary amount.
@@ -48,16 +46,16 @@ func (g *generator) nonFraction(name, contextRef, unitRef string, value int64, o
 	o := nfOptions{
 		decimals: "INF",
 		scale:    "0",
-		format:   "ixt:numspacecomma",
+		format:   g.numberFormat(),
 	}
 	for _, fn := range opts {
 		fn(&o)
 	}
 
-	displayValue := formatAmount(value)
+	displayValue := g.formatAmount(value)
 	if o.scale == "3" {
 		// tkr display
-		displayValue = formatAmountTkr(value)
+		displayValue = g.formatAmountTkr(value)
 	}
 
 	attrs := fmt.Sprintf(`contextRef="%s" name="%s" unitRef="%s" decimals="%s" scale="%s"`,
@@ -76,6 +74,9 @@ func (g *generator) nonFraction(name, contextRef, unitRef string, value int64, o
 	}
 
 	tag := fmt.Sprintf(`<ix:nonFraction %s>%s</ix:nonFraction>`, attrs, displayValue)
+	if !g.tagged(name, o.tupleRef) {
+		tag = displayValue
+	}
 
 	// Handle negative prefix display (expenses show "-" outside the tag)
 	if o.negPrefix {
@@ -90,6 +91,22 @@ func (g *generator) nonFraction(name, contextRef, unitRef string, value int64, o
 	g.write(tag)
 }
 
+// tagged reports whether a fact of a concept is written as an ix element:
+// the release must declare the concept and, for a tuple member, the tuple.
+func (g *generator) tagged(name, tupleRef string) bool {
+	return g.release.Declares(name) && (tupleRef == "" || g.tuples[tupleRef])
+}
+
+// tuple writes an ix:tuple declaration, unless the release does not
+// declare the tuple concept, in which case its members are left untagged.
+func (g *generator) tuple(name, tupleID string) {
+	if !g.release.Declares(name) {
+		return
+	}
+	g.tuples[tupleID] = true
+	g.linef(`<ix:tuple name="%s" tupleID="%s" />`, name, tupleID)
+}
+
 // nonFractionLine writes a nonFraction on its own indented line.
 func (g *generator) nonFractionLine(name, contextRef, unitRef string, value int64, opts ...nfOpt) {
 	g.write(strings.Repeat("\t", g.indent))
@@ -118,6 +135,10 @@ func (g *generator) nonNumeric(name, contextRef, value string, opts ...nnOpt) {
 		attrs += fmt.Sprintf(` tupleRef="%s"`, o.tupleRef)
 	}
 
+	if !g.tagged(name, o.tupleRef) {
+		g.write(esc(value))
+		return
+	}
 	g.writef(`<ix:nonNumeric %s>%s</ix:nonNumeric>`, attrs, esc(value))
 }
 
@@ -142,6 +163,10 @@ func (g *generator) nonNumericRaw(name, contextRef, rawContent string, opts ...n
 		attrs += fmt.Sprintf(` tupleRef="%s"`, o.tupleRef)
 	}
 
+	if !g.tagged(name, o.tupleRef) {
+		g.write(rawContent)
+		return
+	}
 	g.writef(`<ix:nonNumeric %s>%s</ix:nonNumeric>`, attrs, rawContent)
 }
 
@@ -149,7 +174,7 @@ func (g *generator) nonNumericRaw(name, contextRef, rawContent string, opts ...n
 type nfOptions struct {
 	decimals  string // default "INF"
 	scale     string // default "0"
-	format    string // default "ixt:numspacecomma"
+	format    string // default g.numberFormat()
 	sign      string // e.g. "-" for sign inversion
 	negPrefix bool   // show "-" before the tag in display
 	wrapClass string // wrap in <span class="...">
@@ -206,9 +231,9 @@ func (g *generator) writeYearComparisonRow(label string, noteRef int, concept, c
 	g.linef(`<tr>`)
 	g.in()
 	if tdClass != "" {
-		g.linef(`<td%s>%s</td>`, tdClass, label)
+		g.linef(`<td%s>%s</td>`, tdClass, g.label(label, concept))
 	} else {
-		g.linef(`<td>%s</td>`, label)
+		g.linef(`<td>%s</td>`, g.label(label, concept))
 	}
 
 	// Note column
@@ -284,9 +309,9 @@ func (g *generator) writeBalanceRow(label string, noteRef int, noteRefs []int, c
 	g.line(`<tr>`)
 	g.in()
 	if tdClass != "" {
-		g.linef(`<td%s>%s</td>`, tdClass, label)
+		g.linef(`<td%s>%s</td>`, tdClass, g.label(label, concept))
 	} else {
-		g.linef(`<td>%s</td>`, label)
+		g.linef(`<td>%s</td>`, g.label(label, concept))
 	}
 
 	// Note column
@@ -352,8 +377,19 @@ type YearComparisonVal struct {
 func (g *generator) pageHeader(companyName, orgNr string, pageNum, totalPages int) {
 	g.linef(`<div class="ar-page-hdr">`)
 	g.in()
+	if g.themeTpls != nil && g.themeTpls.header != nil {
+		g.themeTemplate(g.themeTpls.header, pageNum, totalPages)
+		g.out()
+		g.line(`</div>`)
+		return
+	}
+	if g.logoURI != "" {
+		// The logo image is defined once in the stylesheet to avoid
+		// repeating the data URI on every page.
+		g.line(`<span class="ar-page-hdr-logo"></span>`)
+	}
 	g.linef(`<span class="ar-page-hdr-company">%s<br />%s</span>`, esc(companyName), esc(orgNr))
-	g.linef(`<span class="ar-page-hdr-page">Sida %d av %d</span>`, pageNum, totalPages)
+	g.linef(`<span class="ar-page-hdr-page">%s</span>`, g.lbl.Tf("Sida %d av %d", pageNum, totalPages))
 	g.out()
 	g.line(`</div>`)
 }
@@ -12,26 +12,69 @@ import (
 	"strings"
 	"time"
 
+	"github.com/redofri/redofri/pkg/labels"
 	"github.com/redofri/redofri/pkg/model"
+	"github.com/redofri/redofri/pkg/taxonomy"
 )
 
-// TaxonomyVersion is the K2 taxonomy version we target.
-const TaxonomyVersion = "2024-09-12"
+// TaxonomyVersion is the K2 taxonomy version used when the report's
+// Meta.TaxonomyVersion is empty.
+const TaxonomyVersion = taxonomy.CurrentK2
+
+// Options controls optional aspects of document generation.
+type Options struct {
+	// Theme changes the visual presentation. Nil uses the standard look.
+	Theme *Theme
+
+	// Labels, if set, gives the texts of the income statement and balance
+	// sheet rows from the taxonomy instead of redofri's own texts. Rows
+	// without a label in the report language keep redofri's text.
+	Labels Labeler
+
+	// Release, if set, is used instead of the built-in K2 release of the
+	// report's version, e.g. one narrowed to a taxonomy package with
+	// taxonomy.Release.WithPackage. Concepts it does not declare are left
+	// untagged. Its version must be the report's.
+	Release *taxonomy.Release
+}
+
+// Labeler gives concept labels, e.g. from a *taxonomy.Taxonomy.
+type Labeler interface {
+	// Label returns the label of a concept ("se-gen-base:Nettoomsattning")
+	// in a language ("sv", "en"), or "" if it has none.
+	Label(concept, language string) string
+}
 
 // Generate writes a complete iXBRL document for the given annual report.
 func Generate(w io.Writer, r *model.AnnualReport) error {
+	return GenerateWithOptions(w, r, Options{})
+}
+
+// GenerateWithOptions writes a complete iXBRL document using the given options.
+func GenerateWithOptions(w io.Writer, r *model.AnnualReport, opts Options) error {
 	g := &generator{
-		w:      w,
-		report: r,
-		indent: 0,
+		w:       w,
+		report:  r,
+		indent:  0,
+		theme:   opts.Theme,
+		lbl:     labels.For(r.Meta.Language),
+		labeler: opts.Labels,
+		custom:  opts.Release,
+		tuples:  map[string]bool{},
 	}
 	return g.generate()
 }
 
 // GenerateBytes returns a complete iXBRL document as bytes.
 func GenerateBytes(r *model.AnnualReport) ([]byte, error) {
+	return GenerateBytesWithOptions(r, Options{})
+}
+
+// GenerateBytesWithOptions returns a complete iXBRL document as bytes using
+// the given options.
+func GenerateBytesWithOptions(r *model.AnnualReport, opts Options) ([]byte, error) {
 	var buf bytes.Buffer
-	if err := Generate(&buf, r); err != nil {
+	if err := GenerateWithOptions(&buf, r, opts); err != nil {
 		return nil, err
 	}
 	return buf.Bytes(), nil
@@ -42,6 +85,39 @@ type generator struct {
 	report *model.AnnualReport
 	indent int
 	err    error // sticky error
+
+	logoURI string // data URI for the company logo, "" if none
+
+	theme     *Theme         // nil for the standard look
+	themeTpls *compiledTheme // parsed theme templates
+
+	lbl     *labels.Catalogue // display texts for r.Meta.Language
+	labeler Labeler           // taxonomy labels, or nil
+
+	release *taxonomy.Release // K2 release of r.Meta.TaxonomyVersion
+	custom  *taxonomy.Release // Options.Release, or nil
+	tuples  map[string]bool   // tupleIDs of the ix:tuple declarations written
+}
+
+// t translates a Swedish display text into the report language.
+func (g *generator) t(sv string) string {
+	return g.lbl.T(sv)
+}
+
+// label returns the text of a row showing concept: the taxonomy label if
+// there is one in the report language, else sv translated.
+func (g *generator) label(sv, concept string) string {
+	if g.labeler != nil {
+		if s := g.labeler.Label(concept, g.lbl.Language); s != "" {
+			return esc(s)
+		}
+	}
+	return g.t(sv)
+}
+
+// date formats an ISO date for display in the report language.
+func (g *generator) date(iso string) string {
+	return g.lbl.Date(iso)
 }
 
 // write outputs a string, tracking errors.
@@ -92,6 +168,33 @@ func (g *generator) out() {
 func (g *generator) generate() error {
 	r := g.report
 
+	g.release = taxonomy.K2Release(r.Meta.TaxonomyVersion)
+	if g.release == nil {
+		return fmt.Errorf("unknown taxonomy version %q (known: %s)",
+			r.Meta.TaxonomyVersion, strings.Join(taxonomy.K2Versions(), ", "))
+	}
+	if g.custom != nil {
+		if g.custom.Version != g.release.Version {
+			return fmt.Errorf("K2 release %s given for a report of version %s", g.custom.Version, g.release.Version)
+		}
+		g.release = g.custom
+	}
+
+	if r.Company.Logo != nil {
+		mediaType, err := CheckImage(r.Company.Logo)
+		if err != nil {
+			return fmt.Errorf("company logo: %w", err)
+		}
+		g.logoURI = imageDataURI(mediaType, r.Company.Logo.Data)
+	}
+	if g.theme != nil {
+		tpls, err := g.theme.compile()
+		if err != nil {
+			return err
+		}
+		g.themeTpls = tpls
+	}
+
 	g.writeXMLDeclaration()
 	g.writeHTMLOpen(r)
 	g.writeHead(r)
@@ -113,15 +216,20 @@ func (g *generator) writeHTMLOpen(r *model.AnnualReport) {
 	g.in()
 	g.in()
 	g.line(`xmlns:iso4217="http://www.xbrl.org/2003/iso4217"`)
-	g.line(`xmlns:ixt="http://www.xbrl.org/inlineXBRL/transformation/2010-04-20"`)
+	g.linef(`xmlns:ixt="%s"`, transformsTR1)
 	g.line(`xmlns:xlink="http://www.w3.org/1999/xlink"`)
 	g.line(`xmlns:link="http://www.xbrl.org/2003/linkbase"`)
 	g.line(`xmlns:xbrli="http://www.xbrl.org/2003/instance"`)
 	g.line(`xmlns:ix="http://www.xbrl.org/2013/inlineXBRL"`)
-	g.line(`xmlns:se-gen-base="http://www.taxonomier.se/se/fr/gen-base/2021-10-31"`)
-	g.line(`xmlns:se-cd-base="http://www.taxonomier.se/se/fr/cd-base/2021-10-31"`)
-	g.line(`xmlns:se-bol-base="http://www.bolagsverket.se/se/fr/comp-base/2017-09-30"`)
-	g.line(`xmlns:se-k2-type="http://www.taxonomier.se/se/fr/k2/datatype">`)
+	for _, prefix := range []string{"se-gen-base", "se-cd-base", "se-bol-base"} {
+		g.linef(`xmlns:%s="%s"`, prefix, g.release.Namespaces[prefix])
+	}
+	g.line(`xmlns:se-k2-type="http://www.taxonomier.se/se/fr/k2/datatype"`)
+	// Namespaces of passthrough concepts from other taxonomies
+	for _, ns := range passthroughNamespaces(r) {
+		g.linef(`xmlns:%s="%s"`, ns[0], esc(ns[1]))
+	}
+	g.line(`>`)
 	g.out()
 	g.out()
 }
@@ -134,11 +242,12 @@ func (g *generator) writeHead(r *model.AnnualReport) {
 	g.in()
 	g.line(`<head>`)
 	g.in()
-	g.linef(`<title> %s %s - Årsredovisning</title>`, esc(r.Company.OrgNr), esc(r.Company.Name))
+	g.linef(`<title> %s %s - %s</title>`, esc(r.Company.OrgNr), esc(r.Company.Name), g.t("Årsredovisning"))
 	g.linef(`<meta name="programvara" content="%s"/>`, esc(r.Meta.Software))
 	g.linef(`<meta name="programversion" content="%s"/>`, esc(r.Meta.SoftwareVersion))
 	g.line(`<style type="text/css">`)
 	g.writeCSS()
+	g.writeLogoCSS()
 	g.line(`</style>`)
 	g.out()
 	g.line(`</head>`)
@@ -175,17 +284,6 @@ func (g *generator) writeWrapper(r *model.AnnualReport) {
 	g.out()
 }
 
-// schemaURL returns the entry point schema URL for the given variant.
-func schemaURL(variant string) string {
-	return fmt.Sprintf("http://xbrl.taxonomier.se/se/fr/gaap/k2-all/ab/%s/%s/se-k2-ab-%s-%s.xsd",
-		variant, TaxonomyVersion, variant, TaxonomyVersion)
-}
-
-// certSchemaURL returns the fastställelseintyg schema URL.
-func certSchemaURL() string {
-	return "http://xbrl.taxonomier.se/se/fr/gaap/k2/rcoa/2020-12-01/se-k2-rcoa-2020-12-01.xsd"
-}
-
 // fiscalYearLabel returns a label like "2016" or "2015/16" for display.
 func fiscalYearLabel(startDate, endDate string) string {
 	// Parse the end date to get the year
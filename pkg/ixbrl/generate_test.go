@@ -5,10 +5,14 @@ import (
 	"encoding/json"
 	"encoding/xml"
 	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
+	"testing/fstest"
 
 	"github.com/redofri/redofri/pkg/model"
+	"github.com/redofri/redofri/pkg/taxonomy"
 )
 
 // loadTestReport loads the example test data from testdata/exempel1.json.
@@ -695,3 +699,469 @@ func TestGenerate_RoundtripStructure(t *testing.T) {
 		lastIdx = idx
 	}
 }
+
+// testPNG is a minimal 1x1 PNG image.
+var testPNG = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x01\x00\x00\x00\x01\x08\x06\x00\x00\x00\x1f\x15\xc4\x89\x00\x00\x00\rIDATx\x9cc\xf8\x0f\x00\x00\x01\x01\x00\x05\x18\xd8N\x00\x00\x00\x00IEND\xaeB`\x82")
+
+func TestGenerate_Logo(t *testing.T) {
+	r := loadTestReport(t)
+	r.Company.Logo = &model.Image{Data: testPNG, Alt: "Exempel-logga"}
+	output := generateOutput(t, r)
+
+	assertContains(t, output, `<img class="ar-cover-logo" src="data:image/png;base64,`, "cover logo")
+	assertContains(t, output, `alt="Exempel-logga"`, "logo alt text")
+	assertContains(t, output, `<span class="ar-page-hdr-logo"></span>`, "page header logo")
+	if got := strings.Count(output, "data:image/png;base64,"); got != 2 {
+		t.Errorf("logo data URI embedded %d times, want 2 (cover + stylesheet)", got)
+	}
+	if err := CheckDocument([]byte(output)); err != nil {
+		t.Errorf("CheckDocument: %v", err)
+	}
+}
+
+func TestGenerate_LogoRejected(t *testing.T) {
+	tests := []struct {
+		name string
+		img  model.Image
+	}{
+		{"no data", model.Image{Path: "logo.png"}},
+		{"unsupported format", model.Image{Data: []byte("BM\x00\x00 not an allowed format")}},
+		{"media type mismatch", model.Image{MediaType: "image/jpeg", Data: testPNG}},
+		{"too large", model.Image{Data: append(append([]byte{}, testPNG...), make([]byte, MaxImageSize)...)}},
+		{"svg with script", model.Image{Data: []byte(`<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`)}},
+		{"svg with external link", model.Image{Data: []byte(`<svg xmlns="http://www.w3.org/2000/svg"><image href="https://example.com/x.png"/></svg>`)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := loadTestReport(t)
+			img := tt.img
+			r.Company.Logo = &img
+			var buf bytes.Buffer
+			if err := Generate(&buf, r); err == nil {
+				t.Fatal("expected error for invalid logo")
+			}
+		})
+	}
+}
+
+func TestCheckDocument(t *testing.T) {
+	t.Run("too large", func(t *testing.T) {
+		doc := bytes.Repeat([]byte("x"), MaxDocumentSize)
+		if err := CheckDocument(doc); err == nil {
+			t.Fatal("expected error for oversized document")
+		}
+	})
+
+	t.Run("disallowed image type", func(t *testing.T) {
+		doc := []byte(`<img src="data:image/bmp;base64,Qk0="/>`)
+		if err := CheckDocument(doc); err == nil {
+			t.Fatal("expected error for BMP image")
+		}
+	})
+
+	t.Run("svg image", func(t *testing.T) {
+		doc := []byte(`<img src="data:image/svg+xml;base64,PHN2ZyB4bWxucz0iaHR0cDovL3d3dy53My5vcmcvMjAwMC9zdmciLz4="/>`)
+		if err := CheckDocument(doc); err != nil {
+			t.Fatalf("CheckDocument: %v", err)
+		}
+	})
+}
+
+// ixTagRe matches inline XBRL elements together with their direct text content.
+var ixTagRe = regexp.MustCompile(`</?ix:[^>]*>[^<]*`)
+
+func TestGenerate_ThemesKeepTagging(t *testing.T) {
+	r := loadTestReport(t)
+	plain := generateOutput(t, r)
+	plainTags := ixTagRe.FindAllString(plain, -1)
+
+	for _, name := range BuiltinThemes() {
+		theme, _ := BuiltinTheme(name)
+		theme.CoverTemplate = `<h1 class="my-title">{{.Title}}</h1><p>{{.CompanyName}}, {{.StartDate}} – {{.EndDate}}</p>`
+		theme.HeaderTemplate = `<span class="ar-page-hdr-company">{{.CompanyName}}</span><span class="ar-page-hdr-page">{{.Page}}/{{.TotalPages}}</span>`
+
+		doc, err := GenerateBytesWithOptions(r, Options{Theme: theme})
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		out := string(doc)
+		if err := xml.Unmarshal(doc, new(any)); err != nil {
+			t.Errorf("%s: output is not well-formed XML: %v", name, err)
+		}
+		if theme.ExtraCSS != "" && !strings.Contains(out, theme.ExtraCSS) {
+			t.Errorf("%s: theme CSS not included", name)
+		}
+		if !strings.Contains(out, `<h1 class="my-title">Årsredovisning för räkenskapsåret`) {
+			t.Errorf("%s: cover template not applied", name)
+		}
+		if !strings.Contains(out, `<span class="ar-page-hdr-page">2/`) {
+			t.Errorf("%s: header template not applied", name)
+		}
+
+		tags := ixTagRe.FindAllString(out, -1)
+		if strings.Join(tags, "\n") != strings.Join(plainTags, "\n") {
+			t.Errorf("%s: tagged content differs from the unthemed document", name)
+		}
+	}
+}
+
+func TestGenerate_ThemeReplacesCSS(t *testing.T) {
+	r := loadTestReport(t)
+	doc, err := GenerateBytesWithOptions(r, Options{Theme: &Theme{Name: "custom", CSS: ".ar-page { color: rgb(1, 2, 3); }"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(doc)
+	if !strings.Contains(out, "rgb(1, 2, 3)") {
+		t.Error("custom CSS not included")
+	}
+	if strings.Contains(out, defaultCSS) {
+		t.Error("default CSS should be replaced")
+	}
+}
+
+func TestTheme_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		theme Theme
+		ok    bool
+	}{
+		{"builtin", Theme{Name: "print", ExtraCSS: builtinThemes["print"].ExtraCSS}, true},
+		{"data url", Theme{ExtraCSS: `.x { background: url("data:image/png;base64,AAAA"); }`}, true},
+		{"external url", Theme{ExtraCSS: `.x { background: url(https://example.com/a.png); }`}, false},
+		{"import", Theme{CSS: `@import "x.css";`}, false},
+		{"style break-out", Theme{ExtraCSS: `</style><script>alert(1)</script>`}, false},
+		{"expression", Theme{ExtraCSS: `.x { width: expression(alert(1)); }`}, false},
+		{"plain cover", Theme{CoverTemplate: `<h2>{{.Title}}</h2>`}, true},
+		{"script", Theme{CoverTemplate: `<script>alert(1)</script>`}, false},
+		{"event handler", Theme{CoverTemplate: `<h2 onclick="x()">{{.Title}}</h2>`}, false},
+		{"external image", Theme{HeaderTemplate: `<img src="https://example.com/logo.png" alt="" />`}, false},
+		{"external link", Theme{HeaderTemplate: `<a href="https://example.com">x</a>`}, false},
+		{"fragment link", Theme{HeaderTemplate: `<a href="#ar3-page-1">x</a>`}, true},
+		{"link to the xhtml namespace", Theme{HeaderTemplate: `<a href="http://www.w3.org/1999/xhtml">x</a>`}, false},
+		{"xhtml namespace", Theme{HeaderTemplate: `<span xmlns="http://www.w3.org/1999/xhtml">x</span>`}, true},
+		{"other namespace declaration", Theme{HeaderTemplate: `<span xmlns:x="https://example.com/">x</span>`}, false},
+		{"id", Theme{CoverTemplate: `<h2 id="ar3-page-1">x</h2>`}, false},
+		{"ix tagging", Theme{CoverTemplate: `<ix:nonNumeric xmlns:ix="http://www.xbrl.org/2013/inlineXBRL" name="se-cd-base:ForetagetsNamn" contextRef="period0">x</ix:nonNumeric>`}, false},
+		{"undeclared prefix", Theme{CoverTemplate: `<ix:nonNumeric>x</ix:nonNumeric>`}, false},
+		{"not well-formed", Theme{CoverTemplate: `<h2>{{.Title}}`}, false},
+		{"bad template", Theme{CoverTemplate: `{{.Nope`}, false},
+	}
+	for _, tt := range tests {
+		err := tt.theme.Validate()
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: Validate() = %v, want ok=%v", tt.name, err, tt.ok)
+		}
+	}
+}
+
+func TestGenerate_RejectsUnsafeTheme(t *testing.T) {
+	r := loadTestReport(t)
+	_, err := GenerateBytesWithOptions(r, Options{Theme: &Theme{Name: "bad", HeaderTemplate: `<iframe src="#"></iframe>`}})
+	if err == nil {
+		t.Fatal("expected error for unsafe theme")
+	}
+}
+
+func TestGenerate_English(t *testing.T) {
+	r := loadTestReport(t)
+	sv := generateOutput(t, r)
+
+	r.Meta.Language = "en"
+	out := generateOutput(t, r)
+
+	if err := xml.Unmarshal([]byte(out), new(any)); err != nil {
+		t.Fatalf("output is not well-formed XML: %v", err)
+	}
+	for _, want := range []string{
+		`<h2>Income statement</h2>`,
+		`<h2>Balance sheet</h2>`,
+		`<h2>Management report</h2>`,
+		`Page 1 of`,
+		`Note 1</span> Accounting and valuation policies`,
+		`1 January 2016<br />–31 December 2016`,
+		`format="ixt:numcommadot"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("English output missing %q", want)
+		}
+	}
+	for _, unwanted := range []string{`<h2>Resultaträkning</h2>`, `Sida 1 av`, `>Summa `, `ixt:numspacecomma`} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("English output contains Swedish text %q", unwanted)
+		}
+	}
+
+	// The tagged values must be the same in both languages.
+	svResult, err := Parse(strings.NewReader(sv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	enResult, err := Parse(strings.NewReader(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	svReport, enReport := svResult.Report, enResult.Report
+	enReport.Meta.Language = svReport.Meta.Language
+	svJSON, _ := json.Marshal(svReport)
+	enJSON, _ := json.Marshal(enReport)
+	if string(svJSON) != string(enJSON) {
+		t.Error("parsed English report differs from the Swedish one")
+	}
+}
+
+// TestGenerate_FormatsInRegistry checks that every format the generator
+// writes is a rule of the registry it declares for the ixt prefix.
+func TestGenerate_FormatsInRegistry(t *testing.T) {
+	formatAttr := regexp.MustCompile(`format="([^"]*)"`)
+	for _, lang := range []string{"sv", "en"} {
+		r := loadTestReport(t)
+		r.Meta.Language = lang
+		out := generateOutput(t, r)
+		if !strings.Contains(out, `xmlns:ixt="`+transformsTR1+`"`) {
+			t.Fatalf("%s: ixt is not declared as %s", lang, transformsTR1)
+		}
+		formats := formatAttr.FindAllStringSubmatch(out, -1)
+		if len(formats) == 0 {
+			t.Fatalf("%s: no formats", lang)
+		}
+		for _, m := range formats {
+			prefix, name, _ := strings.Cut(m[1], ":")
+			if prefix != "ixt" || !TransformDefined(transformsTR1, name) {
+				t.Errorf("%s: format %q is not in %s", lang, m[1], transformsTR1)
+			}
+		}
+	}
+}
+
+// mapLabeler is a Labeler for tests: labels by language, then concept.
+type mapLabeler map[string]map[string]string
+
+func (m mapLabeler) Label(concept, language string) string {
+	return m[language][concept]
+}
+
+func TestGenerate_TaxonomyLabels(t *testing.T) {
+	r := loadTestReport(t)
+	labels := mapLabeler{"sv": {
+		"se-gen-base:Nettoomsattning":                "Nettoomsättning (taxonomi)",
+		"se-gen-base:Rorelseresultat":                "Rörelseresultat & liknande",
+		"se-gen-base:KassaBankExklRedovisningsmedel": "Kassa och bank (taxonomi)",
+	}}
+	var buf bytes.Buffer
+	if err := GenerateWithOptions(&buf, r, Options{Labels: labels}); err != nil {
+		t.Fatalf("GenerateWithOptions: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"<td>Nettoomsättning (taxonomi)</td>",
+		"<td>Rörelseresultat &amp; liknande</td>",
+		"<td>Kassa och bank (taxonomi)</td>",
+		"<td>Handelsvaror</td>", // no taxonomy label
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+
+	// Labels in another language than the report's are not used.
+	r.Meta.Language = "en"
+	buf.Reset()
+	if err := GenerateWithOptions(&buf, r, Options{Labels: labels}); err != nil {
+		t.Fatalf("GenerateWithOptions: %v", err)
+	}
+	if strings.Contains(buf.String(), "(taxonomi)") {
+		t.Error("English report uses Swedish taxonomy labels")
+	}
+}
+
+// conceptLiteral matches the concept names written in the package source.
+var conceptLiteral = regexp.MustCompile(`"(se-(?:gen|cd|bol)-base:)([A-Za-z0-9]+)"|ns(Gen|Cd|Bol) *\+ *"([A-Za-z0-9]+)"`)
+
+func TestConcepts(t *testing.T) {
+	supported := map[string]bool{}
+	for _, c := range Concepts() {
+		supported[c] = true
+	}
+
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	prefixes := map[string]string{"Gen": nsGen, "Cd": nsCd, "Bol": nsBol}
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, m := range conceptLiteral.FindAllStringSubmatch(string(src), -1) {
+			name := m[1] + m[2]
+			if m[3] != "" {
+				name = prefixes[m[3]] + m[4]
+			}
+			if !supported[name] {
+				t.Errorf("%s: %s is missing from Concepts", file, name)
+			}
+		}
+	}
+
+	data, err := GenerateBytes(loadTestReport(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, err := ReadDocument(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range doc.Facts {
+		if !supported[f.Concept] {
+			t.Errorf("generated fact %s is missing from Concepts", f.Concept)
+		}
+	}
+}
+
+func TestGenerate_TaxonomyVersion(t *testing.T) {
+	r := loadTestReport(t)
+	r.Meta.TaxonomyVersion = "2017-09-30"
+	output := generateOutput(t, r)
+	for _, want := range []string{
+		`xmlns:se-gen-base="http://www.taxonomier.se/se/fr/gen-base/2017-09-30"`,
+		`xmlns:se-cd-base="http://www.taxonomier.se/se/fr/cd-base/2017-09-30"`,
+		`xlink:href="http://xbrl.taxonomier.se/se/fr/gaap/k2/risbs/2017-09-30/se-k2-risbs-2017-09-30.xsd"`,
+		`xlink:href="http://xbrl.taxonomier.se/se/fr/gaap/k2/rcoa/2017-09-30/se-k2-rcoa-2017-09-30.xsd"`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output is missing %s", want)
+		}
+	}
+
+	result, err := Parse(strings.NewReader(output))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if got := result.Report.Meta.TaxonomyVersion; got != "2017-09-30" {
+		t.Errorf("parsed taxonomy version %q", got)
+	}
+
+	r.Meta.TaxonomyVersion = "2019-01-01"
+	if err := Generate(&bytes.Buffer{}, r); err == nil || !strings.Contains(err.Error(), "unknown taxonomy version") {
+		t.Errorf("unknown version: %v", err)
+	}
+}
+
+func TestGenerate_ReleaseMissingConcept(t *testing.T) {
+	// A 2017-09-30 gen-base schema without Nettoomsattning.
+	ns := taxonomy.K2Release("2017-09-30").Namespaces["se-gen-base"]
+	var schema strings.Builder
+	schema.WriteString(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema" xmlns:se-gen-base="` + ns +
+		`" targetNamespace="` + ns + `">`)
+	for _, c := range Concepts() {
+		local, ok := strings.CutPrefix(c, "se-gen-base:")
+		if ok && local != "Nettoomsattning" {
+			schema.WriteString(`<xs:element name="` + local + `"/>`)
+		}
+	}
+	schema.WriteString(`</xs:schema>`)
+	pkg, err := taxonomy.LoadFS(fstest.MapFS{"se-gen-base-2017-09-30.xsd": {Data: []byte(schema.String())}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	release, err := taxonomy.K2Release("2017-09-30").WithPackage(pkg, Concepts())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := loadTestReport(t)
+	r.Meta.TaxonomyVersion = "2017-09-30"
+	var buf bytes.Buffer
+	if err := GenerateWithOptions(&buf, r, Options{Release: release}); err != nil {
+		t.Fatal(err)
+	}
+	output := buf.String()
+	if strings.Contains(output, `name="se-gen-base:Nettoomsattning"`) {
+		t.Error("concept missing from the release is tagged")
+	}
+	if !strings.Contains(output, `name="se-gen-base:Rorelseresultat"`) {
+		t.Error("other concepts are not tagged")
+	}
+	if !strings.Contains(output, `name="se-bol-base:UnderskriftFastallelseintygDatum"`) {
+		t.Error("concepts of namespaces outside the package are not tagged")
+	}
+	if !strings.Contains(generateOutput(t, r), `name="se-gen-base:Nettoomsattning"`) {
+		t.Error("concept is left untagged without the package")
+	}
+
+	r.Meta.TaxonomyVersion = "2024-09-12"
+	if err := GenerateWithOptions(&bytes.Buffer{}, r, Options{Release: release}); err == nil {
+		t.Error("release of another version accepted")
+	}
+	if ConceptsFor("2019-01-01") != nil {
+		t.Error("ConceptsFor: unknown version gives concepts")
+	}
+}
+
+func TestGenerate_ReleaseMissingFixedConcepts(t *testing.T) {
+	// Concepts written outside the nonFraction and nonNumeric helpers.
+	release := *taxonomy.K2Release("")
+	release.Missing = []string{
+		"se-gen-base:Soliditet",
+		"se-gen-base:UnderskriftArsredovisningForetradareTuple",
+		"se-cd-base:Sprak",
+		"se-bol-base:ArsstammaIntygande",
+	}
+	var buf bytes.Buffer
+	if err := GenerateWithOptions(&buf, loadTestReport(t), Options{Release: &release}); err != nil {
+		t.Fatal(err)
+	}
+	output := buf.String()
+	for _, name := range append(release.Missing, "se-gen-base:UnderskriftArsredovisningForetradareTilltalsnamn") {
+		if strings.Contains(output, `name="`+name+`"`) {
+			t.Errorf("%s is tagged", name)
+		}
+	}
+	if strings.Contains(output, "intygande_forts") {
+		t.Error("continuation of the untagged ArsstammaIntygande is written")
+	}
+	if !strings.Contains(output, `name="se-cd-base:Land"`) || !strings.Contains(output, `name="se-bol-base:Arsstamma"`) {
+		t.Error("declared concepts are not tagged")
+	}
+	if _, err := ReadDocument(strings.NewReader(output)); err != nil {
+		t.Errorf("reading the document: %v", err)
+	}
+}
+
+func TestConceptsFor_ReferenceExamples(t *testing.T) {
+	// Bolagsverket's example filings of the 2017-09-30 release.
+	files, err := filepath.Glob("../../ref/Taxonomi K2 – Taxonomier – Taxonomier.se_files/*.xhtml")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("reference examples: %v", err)
+	}
+	declared := map[string]bool{}
+	for _, c := range ConceptsFor("2017-09-30") {
+		declared[c] = true
+	}
+	supported := map[string]bool{}
+	for _, c := range Concepts() {
+		supported[c] = true
+	}
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		doc, err := ReadDocument(f)
+		f.Close()
+		if err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+		for _, fact := range doc.Facts {
+			if supported[fact.Concept] && !declared[fact.Concept] {
+				t.Errorf("%s: %s is tagged but not declared by K2 2017-09-30", filepath.Base(file), fact.Concept)
+			}
+		}
+	}
+}
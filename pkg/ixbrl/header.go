@@ -31,14 +31,28 @@ func (g *generator) writeIXHeader(r *model.AnnualReport) {
 
 // writeHiddenFacts writes the ix:hidden block with metadata facts.
 func (g *generator) writeHiddenFacts(r *model.AnnualReport) {
+	facts := []struct{ name, value string }{
+		{"se-cd-base:Sprak", r.Meta.Language},
+		{"se-cd-base:Land", r.Meta.Country},
+		{"se-cd-base:Redovisningsvaluta", r.Meta.Currency},
+		{"se-cd-base:Beloppsformat", r.Meta.AmountFormat},
+		{"se-cd-base:RakenskapsarForstaDag", r.FiscalYear.StartDate},
+		{"se-cd-base:RakenskapsarSistaDag", r.FiscalYear.EndDate},
+	}
+	var declared []string
+	for _, f := range facts {
+		if g.release.Declares(f.name) {
+			declared = append(declared, fmt.Sprintf(`<ix:nonNumeric name="%s" contextRef="period0">%s</ix:nonNumeric>`, f.name, esc(f.value)))
+		}
+	}
+	if len(declared) == 0 {
+		return // ix:hidden may not be empty
+	}
 	g.line(`<ix:hidden>`)
 	g.in()
-	g.linef(`<ix:nonNumeric name="se-cd-base:Sprak" contextRef="period0">%s</ix:nonNumeric>`, esc(r.Meta.Language))
-	g.linef(`<ix:nonNumeric name="se-cd-base:Land" contextRef="period0">%s</ix:nonNumeric>`, esc(r.Meta.Country))
-	g.linef(`<ix:nonNumeric name="se-cd-base:Redovisningsvaluta" contextRef="period0">%s</ix:nonNumeric>`, esc(r.Meta.Currency))
-	g.linef(`<ix:nonNumeric name="se-cd-base:Beloppsformat" contextRef="period0">%s</ix:nonNumeric>`, esc(r.Meta.AmountFormat))
-	g.linef(`<ix:nonNumeric name="se-cd-base:RakenskapsarForstaDag" contextRef="period0">%s</ix:nonNumeric>`, esc(r.FiscalYear.StartDate))
-	g.linef(`<ix:nonNumeric name="se-cd-base:RakenskapsarSistaDag" contextRef="period0">%s</ix:nonNumeric>`, esc(r.FiscalYear.EndDate))
+	for _, fact := range declared {
+		g.line(fact)
+	}
 	g.out()
 	g.line(`</ix:hidden>`)
 }
@@ -48,9 +62,9 @@ func (g *generator) writeSchemaRefs(r *model.AnnualReport) {
 	g.line(`<ix:references>`)
 	g.in()
 	// Entry point schema (e.g. risbs)
-	g.linef(`<link:schemaRef xlink:type="simple" xlink:href="%s" />`, schemaURL(r.Meta.EntryPoint))
+	g.linef(`<link:schemaRef xlink:type="simple" xlink:href="%s" />`, g.release.EntryPointURL(r.Meta.EntryPoint))
 	// Fastställelseintyg schema
-	g.linef(`<link:schemaRef xlink:type="simple" xlink:href="%s"/>`, certSchemaURL())
+	g.linef(`<link:schemaRef xlink:type="simple" xlink:href="%s"/>`, g.release.CertificationSchema())
 	g.out()
 	g.line(`</ix:references>`)
 }
@@ -76,13 +90,15 @@ func (g *generator) writeResources(r *model.AnnualReport) {
 	// period1: previous fiscal year (duration)
 	g.writeDurationContext("period1", orgNr, prevStart, prevEnd)
 
-	// Multi-year overview may need period2/period3 and balans2/balans3
-	if len(r.ManagementReport.MultiYearOverview.Years) > 2 {
+	// Multi-year overview and passthrough facts may need period2/period3
+	// and balans2/balans3
+	years := max(len(r.ManagementReport.MultiYearOverview.Years), passthroughYears(r))
+	if years > 2 {
 		s2, e2 := yearNDates(start, end, 2)
 		g.writeDurationContext("period2", orgNr, s2, e2)
 		g.writeInstantContext("balans2", orgNr, e2)
 	}
-	if len(r.ManagementReport.MultiYearOverview.Years) > 3 {
+	if years > 3 {
 		s3, e3 := yearNDates(start, end, 3)
 		g.writeDurationContext("period3", orgNr, s3, e3)
 		g.writeInstantContext("balans3", orgNr, e3)
@@ -92,6 +108,10 @@ func (g *generator) writeResources(r *model.AnnualReport) {
 	g.writeUnit("SEK", "iso4217:SEK")
 	g.writeUnit("procent", "xbrli:pure")
 	g.writeUnit("antal-anstallda", "se-k2-type:AntalAnstallda")
+	units, ids := passthroughUnits(r)
+	for _, u := range units {
+		g.writeUnitString(ids[u], u)
+	}
 
 	g.out()
 	g.line(`</ix:resources>`)
@@ -0,0 +1,108 @@
+package ixbrl
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/redofri/redofri/pkg/model"
+)
+
+// Size limits from Bolagsverket's tillämpningsanvisningar (4.2.1, 4.2.2).
+// Both limits say "understiga", so we use decimal megabytes to stay on the
+// safe side of either interpretation.
+const (
+	MaxDocumentSize = 5_000_000 // whole annual report document
+	MaxImageSize    = 1_000_000 // each embedded image
+)
+
+// allowedImageTypes lists the image formats Bolagsverket accepts (3.5.3).
+var allowedImageTypes = map[string]bool{
+	"image/jpeg":    true,
+	"image/png":     true,
+	"image/gif":     true,
+	"image/svg+xml": true,
+}
+
+// svgUnsafeRe matches script elements, event handler attributes and external
+// references inside an SVG image.
+var svgUnsafeRe = regexp.MustCompile(`(?i)<script|\son[a-z]+\s*=|(?:xlink:)?href\s*=\s*["'](?:https?:|//|javascript:)`)
+
+// detectImageType returns the MIME type of the image data, or "" if the
+// format is not recognised.
+func detectImageType(data []byte) string {
+	ct := http.DetectContentType(data)
+	switch ct {
+	case "image/jpeg", "image/png", "image/gif":
+		return ct
+	}
+	head := data
+	if len(head) > 1024 {
+		head = head[:1024]
+	}
+	if bytes.Contains(head, []byte("<svg")) {
+		return "image/svg+xml"
+	}
+	return ""
+}
+
+// CheckImage verifies that img is an allowed format and within the size limit.
+// It returns the MIME type to use in the data URI.
+func CheckImage(img *model.Image) (string, error) {
+	if len(img.Data) == 0 {
+		if img.Path != "" {
+			return "", fmt.Errorf("image %s has not been loaded", img.Path)
+		}
+		return "", fmt.Errorf("image has no data")
+	}
+	if len(img.Data) >= MaxImageSize {
+		return "", fmt.Errorf("image is %d bytes, must be smaller than %d bytes", len(img.Data), MaxImageSize)
+	}
+
+	detected := detectImageType(img.Data)
+	if detected == "" {
+		return "", fmt.Errorf("unsupported image format, must be JPEG, PNG, SVG or GIF")
+	}
+	if img.MediaType != "" && img.MediaType != detected {
+		return "", fmt.Errorf("image media type %q does not match its content (%s)", img.MediaType, detected)
+	}
+	if detected == "image/svg+xml" && svgUnsafeRe.Match(img.Data) {
+		return "", fmt.Errorf("SVG image contains script, event handlers or external references")
+	}
+	return detected, nil
+}
+
+// imageDataURI returns the base64 data URI for an image of the given type.
+func imageDataURI(mediaType string, data []byte) string {
+	return "data:" + mediaType + ";base64," + base64.StdEncoding.EncodeToString(data)
+}
+
+// dataURIRe matches base64 image data URIs in a generated document.
+var dataURIRe = regexp.MustCompile(`data:(image/[a-z+.-]+);base64,([A-Za-z0-9+/=\s]+)`)
+
+// CheckDocument verifies the size limits and image rules for a complete
+// iXBRL document: the document must be smaller than MaxDocumentSize, and every
+// embedded image must be an allowed format smaller than MaxImageSize.
+func CheckDocument(doc []byte) error {
+	if len(doc) >= MaxDocumentSize {
+		return fmt.Errorf("document is %d bytes, must be smaller than %d bytes", len(doc), MaxDocumentSize)
+	}
+	for i, m := range dataURIRe.FindAllSubmatch(doc, -1) {
+		mediaType := string(m[1])
+		if !allowedImageTypes[mediaType] {
+			return fmt.Errorf("image %d: unsupported media type %s", i+1, mediaType)
+		}
+		encoded := strings.Join(strings.Fields(string(m[2])), "")
+		data, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return fmt.Errorf("image %d: invalid base64 data: %w", i+1, err)
+		}
+		if _, err := CheckImage(&model.Image{MediaType: mediaType, Data: data}); err != nil {
+			return fmt.Errorf("image %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
@@ -14,8 +14,8 @@ func (g *generator) writeIncomeStatement(r *model.AnnualReport) {
 	g.line(`<div class="ar-page wide" id="ar3-page-4">`)
 	g.in()
 	g.pageHeader(r.Company.Name, r.Company.OrgNr, 4, totalPages)
-	g.line(`<h2>Resultaträkning</h2>`)
-	g.line(`<p class="ar-amount-note">Rapporten visar bolagets intäkter, kostnader och resultat för aktuellt och föregående räkenskapsår.</p>`)
+	g.linef(`<h2>%s</h2>`, g.t("Resultaträkning"))
+	g.linef(`<p class="ar-amount-note">%s</p>`, g.t("Rapporten visar bolagets intäkter, kostnader och resultat för aktuellt och föregående räkenskapsår."))
 
 	g.line(`<table class="ar-profit-loss ar-financial col-4">`)
 	g.in()
@@ -34,11 +34,11 @@ func (g *generator) writeIncomeStatement(r *model.AnnualReport) {
 	g.in()
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<th scope="col">Resultaträkning</th>`)
-	g.line(`<th scope="col">Not</th>`)
-	g.linef(`<th scope="col">%s<br />–%s</th>`, r.FiscalYear.StartDate, r.FiscalYear.EndDate)
+	g.linef(`<th scope="col">%s</th>`, g.t("Resultaträkning"))
+	g.linef(`<th scope="col">%s</th>`, g.t("Not"))
+	g.linef(`<th scope="col">%s<br />–%s</th>`, g.date(r.FiscalYear.StartDate), g.date(r.FiscalYear.EndDate))
 	g.linef(`<th scope="col">%s<br />–%s</th>`,
-		prevStart(r.FiscalYear.StartDate), prevEnd)
+		g.date(prevStart(r.FiscalYear.StartDate)), g.date(prevEnd))
 	g.out()
 	g.line(`</tr>`)
 	g.out()
@@ -75,7 +75,7 @@ func (g *generator) writeISRevenue(is *model.IncomeStatement) {
 
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<th colspan="4" scope="rowgroup">Rörelseintäkter, lagerförändringar <abbr>m.m.</abbr>`)
+	g.linef(`<th colspan="4" scope="rowgroup">%s`, g.t("Rörelseintäkter, lagerförändringar <abbr>m.m.</abbr>"))
 	g.line(`</th>`)
 	g.out()
 	g.line(`</tr>`)
@@ -117,7 +117,7 @@ func (g *generator) writeISExpenses(is *model.IncomeStatement) {
 
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<th colspan="4" scope="rowgroup">Rörelsekostnader</th>`)
+	g.linef(`<th colspan="4" scope="rowgroup">%s</th>`, g.t("Rörelsekostnader"))
 	g.out()
 	g.line(`</tr>`)
 
@@ -182,7 +182,7 @@ func (g *generator) writeISFinancialItems(is *model.IncomeStatement) {
 
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<th colspan="4" scope="rowgroup">Finansiella poster</th>`)
+	g.linef(`<th colspan="4" scope="rowgroup">%s</th>`, g.t("Finansiella poster"))
 	g.out()
 	g.line(`</tr>`)
 
@@ -229,7 +229,7 @@ func (g *generator) writeISAppropriations(is *model.IncomeStatement) {
 
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<th colspan="4" scope="rowgroup">Bokslutsdispositioner</th>`)
+	g.linef(`<th colspan="4" scope="rowgroup">%s</th>`, g.t("Bokslutsdispositioner"))
 	g.out()
 	g.line(`</tr>`)
 
@@ -267,7 +267,7 @@ func (g *generator) writeISTax(is *model.IncomeStatement) {
 
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<th colspan="4" scope="rowgroup">Skatter</th>`)
+	g.linef(`<th colspan="4" scope="rowgroup">%s</th>`, g.t("Skatter"))
 	g.out()
 	g.line(`</tr>`)
 
@@ -291,7 +291,7 @@ func (g *generator) writeISTax(is *model.IncomeStatement) {
 func (g *generator) writeISSubTotal(label, concept string, current, previous *int64, isExpense bool) {
 	g.line(`<tr>`)
 	g.in()
-	g.linef(`<td class="sum">%s</td>`, label)
+	g.linef(`<td class="sum">%s</td>`, g.label(label, concept))
 	g.line(`<td />`)
 
 	g.writeISCell(concept, "period0", current, isExpense, false, false)
@@ -305,7 +305,7 @@ func (g *generator) writeISSubTotal(label, concept string, current, previous *in
 func (g *generator) writeISResultRow(label, concept string, current, previous *int64, isTotal bool) {
 	g.line(`<tr class="result">`)
 	g.in()
-	g.linef(`<td>%s</td>`, label)
+	g.linef(`<td>%s</td>`, g.label(label, concept))
 	g.line(`<td />`)
 
 	g.writeISCell(concept, "period0", current, false, false, isTotal)
@@ -323,7 +323,7 @@ func (g *generator) writeISAppropriationRow(label, concept string, current, prev
 
 	g.line(`<tr>`)
 	g.in()
-	g.linef(`<td>%s</td>`, label)
+	g.linef(`<td>%s</td>`, g.label(label, concept))
 	g.line(`<td />`)
 
 	g.writeISAppropriationCell(concept, "period0", current, isLastInGroup)
@@ -337,7 +337,7 @@ func (g *generator) writeISAppropriationRow(label, concept string, current, prev
 func (g *generator) writeISAppropriationSubTotal(label, concept string, current, previous *int64) {
 	g.line(`<tr>`)
 	g.in()
-	g.linef(`<td class="sum">%s</td>`, label)
+	g.linef(`<td class="sum">%s</td>`, g.label(label, concept))
 	g.line(`<td />`)
 
 	g.writeISAppropriationCell(concept, "period0", current, false)
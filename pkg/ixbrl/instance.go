@@ -0,0 +1,551 @@
+package ixbrl
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/redofri/redofri/pkg/model"
+)
+
+// Namespaces used when writing an XBRL instance.
+const (
+	xbrliNS = "http://www.xbrl.org/2003/instance"
+	linkNS  = "http://www.xbrl.org/2003/linkbase"
+	xlinkNS = "http://www.w3.org/1999/xlink"
+	xsiNS   = "http://www.w3.org/2001/XMLSchema-instance"
+
+	// ixtNSPrefix is the common prefix of the transformation registry namespaces.
+	ixtNSPrefix = "http://www.xbrl.org/inlineXBRL/transformation/"
+)
+
+// WriteInstance writes the annual report as an XBRL 2.1 instance document
+// (.xbrl). The instance holds the same contexts, units, facts and tuples
+// that Generate tags in the inline document.
+func WriteInstance(w io.Writer, r *model.AnnualReport) error {
+	doc, err := GenerateBytes(r)
+	if err != nil {
+		return err
+	}
+	return ExtractInstance(w, bytes.NewReader(doc))
+}
+
+// ExtractInstance reads an inline XBRL document and writes the XBRL 2.1
+// instance document it represents: the referenced schemas, contexts and
+// units from ix:header, and every ix:nonFraction, ix:nonNumeric and
+// ix:tuple as an instance fact. Numeric values have their format, scale
+// and sign applied; text values follow continuedAt chains and leave out
+// ix:exclude content.
+func ExtractInstance(w io.Writer, doc io.Reader) error {
+	root, err := parseXMLTree(doc)
+	if err != nil {
+		return fmt.Errorf("reading XML: %w", err)
+	}
+	d := newInlineDocument(root)
+
+	var buf bytes.Buffer
+	iw := &instanceWriter{buf: &buf, doc: d}
+	if err := iw.write(); err != nil {
+		return err
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// ---------- XML tree ----------
+
+// xmlNode is an element of a parsed XML document. Children are *xmlNode
+// elements or string character data.
+type xmlNode struct {
+	name     xml.Name
+	attrs    []xml.Attr
+	children []any
+}
+
+// attr returns the value of an unqualified attribute, or of a qualified
+// one when space is given.
+func (n *xmlNode) attr(space, local string) string {
+	for _, a := range n.attrs {
+		if a.Name.Local == local && a.Name.Space == space {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// is reports whether the element has the given namespace and local name.
+func (n *xmlNode) is(space, local string) bool {
+	return n.name.Space == space && n.name.Local == local
+}
+
+// parseXMLTree reads a complete XML document into a tree.
+func parseXMLTree(r io.Reader) (*xmlNode, error) {
+	decoder := xml.NewDecoder(r)
+	var stack []*xmlNode
+	var root *xmlNode
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &xmlNode{name: t.Name, attrs: t.Attr}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			} else if root == nil {
+				root = n
+			}
+			stack = append(stack, n)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, string(t))
+			}
+		}
+	}
+	if root == nil {
+		return nil, fmt.Errorf("empty document")
+	}
+	return root, nil
+}
+
+// ---------- inline document ----------
+
+// inlineDocument holds the parts of an inline XBRL document that make up
+// the target instance.
+type inlineDocument struct {
+	namespaces    map[string]string // prefix → URI, from xmlns declarations
+	prefixes      map[string]string // URI → first declared prefix
+	schemaRefs    []string
+	resources     []*xmlNode // xbrli:context and xbrli:unit
+	facts         []*xmlNode // ix facts and tuples in document order
+	tuple         map[*xmlNode]*xmlNode
+	tupleIDs      map[string]*xmlNode
+	continuations map[string]*xmlNode
+}
+
+func newInlineDocument(root *xmlNode) *inlineDocument {
+	d := &inlineDocument{
+		namespaces:    map[string]string{},
+		prefixes:      map[string]string{},
+		tuple:         map[*xmlNode]*xmlNode{},
+		tupleIDs:      map[string]*xmlNode{},
+		continuations: map[string]*xmlNode{},
+	}
+	d.walk(root, nil)
+	return d
+}
+
+// walk collects namespace declarations, header resources, continuations
+// and facts. tuple is the nearest enclosing ix:tuple, if any.
+func (d *inlineDocument) walk(n *xmlNode, tuple *xmlNode) {
+	for _, a := range n.attrs {
+		if a.Name.Space == "xmlns" {
+			if _, ok := d.namespaces[a.Name.Local]; !ok {
+				d.namespaces[a.Name.Local] = a.Value
+			}
+			if _, ok := d.prefixes[a.Value]; !ok {
+				d.prefixes[a.Value] = a.Name.Local
+			}
+		}
+	}
+
+	if n.name.Space == ixNS {
+		switch n.name.Local {
+		case "references":
+			for _, c := range n.children {
+				if ref, ok := c.(*xmlNode); ok && ref.is(linkNS, "schemaRef") {
+					d.schemaRefs = append(d.schemaRefs, ref.attr(xlinkNS, "href"))
+				}
+			}
+			return
+		case "resources":
+			for _, c := range n.children {
+				if res, ok := c.(*xmlNode); ok && res.name.Space == xbrliNS {
+					d.resources = append(d.resources, res)
+				}
+			}
+			return
+		case "continuation":
+			d.continuations[n.attr("", "id")] = n
+		case "nonFraction", "nonNumeric":
+			d.facts = append(d.facts, n)
+			d.tuple[n] = tuple
+		case "tuple":
+			d.facts = append(d.facts, n)
+			d.tuple[n] = tuple
+			if id := n.attr("", "tupleID"); id != "" {
+				d.tupleIDs[id] = n
+			}
+			tuple = n
+		}
+	}
+
+	for _, c := range n.children {
+		if child, ok := c.(*xmlNode); ok {
+			d.walk(child, tuple)
+		}
+	}
+}
+
+// parentTuple returns the tuple a fact belongs to: the one named by its
+// tupleRef, or else the ix:tuple element that contains it.
+func (d *inlineDocument) parentTuple(f *xmlNode) (*xmlNode, error) {
+	if ref := f.attr("", "tupleRef"); ref != "" {
+		t, ok := d.tupleIDs[ref]
+		if !ok {
+			return nil, fmt.Errorf("tupleRef %q does not match any ix:tuple", ref)
+		}
+		return t, nil
+	}
+	return d.tuple[f], nil
+}
+
+// tupleMembers returns the facts outside any tuple and, for each tuple,
+// its members sorted by their order attribute.
+func (d *inlineDocument) tupleMembers() (top []*xmlNode, members map[*xmlNode][]*xmlNode, err error) {
+	members = map[*xmlNode][]*xmlNode{}
+	for _, f := range d.facts {
+		t, err := d.parentTuple(f)
+		if err != nil {
+			return nil, nil, fmt.Errorf("fact %s: %w", f.attr("", "name"), err)
+		}
+		if t == nil {
+			top = append(top, f)
+		} else {
+			members[t] = append(members[t], f)
+		}
+	}
+	for t, m := range members {
+		sort.SliceStable(m, func(i, j int) bool {
+			return orderValue(m[i].attr("", "order")) < orderValue(m[j].attr("", "order"))
+		})
+		members[t] = m
+	}
+	return top, members, nil
+}
+
+// ---------- instance writer ----------
+
+type instanceWriter struct {
+	buf *bytes.Buffer
+	doc *inlineDocument
+
+	members map[*xmlNode][]*xmlNode // tuple → member facts in order
+	usesXSI bool
+}
+
+// write emits the complete instance document.
+func (iw *instanceWriter) write() error {
+	d := iw.doc
+	top, members, err := d.tupleMembers()
+	if err != nil {
+		return err
+	}
+	iw.members = members
+	for _, f := range d.facts {
+		if f.attr(xsiNS, "nil") == "true" {
+			iw.usesXSI = true
+		}
+	}
+
+	iw.buf.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	iw.buf.WriteString("<xbrli:xbrl")
+	for _, ns := range iw.namespaceDecls() {
+		fmt.Fprintf(iw.buf, ` xmlns:%s="%s"`, ns[0], esc(ns[1]))
+	}
+	iw.buf.WriteString(">\n")
+
+	for _, href := range d.schemaRefs {
+		fmt.Fprintf(iw.buf, "  <link:schemaRef xlink:type=\"simple\" xlink:href=\"%s\"/>\n", esc(href))
+	}
+	for _, res := range d.resources {
+		iw.writeElement(res, 1)
+	}
+	for _, f := range top {
+		if err := iw.writeFact(f, 1); err != nil {
+			return err
+		}
+	}
+	iw.buf.WriteString("</xbrli:xbrl>\n")
+	return nil
+}
+
+// namespaceDecls returns the prefix and URI pairs declared on the instance
+// root: every namespace of the source document except XHTML and inline
+// XBRL, plus the ones the instance itself needs.
+func (iw *instanceWriter) namespaceDecls() [][2]string {
+	need := map[string]string{"xbrli": xbrliNS, "link": linkNS, "xlink": xlinkNS}
+	if iw.usesXSI {
+		need["xsi"] = xsiNS
+	}
+	decls := map[string]string{}
+	for prefix, uri := range iw.doc.namespaces {
+		if prefix == "" || uri == ixNS || uri == xhtmlNS || strings.HasPrefix(uri, ixtNSPrefix) {
+			continue
+		}
+		decls[prefix] = uri
+	}
+	for prefix, uri := range need {
+		if decls[prefix] != uri {
+			decls[prefix] = uri
+		}
+	}
+	var out [][2]string
+	for prefix, uri := range decls {
+		out = append(out, [2]string{prefix, uri})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i][0] < out[j][0] })
+	return out
+}
+
+// qname returns the prefixed name of an element from the source document.
+func (iw *instanceWriter) qname(name xml.Name) string {
+	switch name.Space {
+	case xbrliNS:
+		return "xbrli:" + name.Local
+	case linkNS:
+		return "link:" + name.Local
+	case xlinkNS:
+		return "xlink:" + name.Local
+	case xsiNS:
+		return "xsi:" + name.Local
+	case "":
+		return name.Local
+	}
+	if prefix, ok := iw.doc.prefixes[name.Space]; ok && prefix != "" {
+		return prefix + ":" + name.Local
+	}
+	return name.Local
+}
+
+// writeElement copies a header element such as xbrli:context into the instance.
+func (iw *instanceWriter) writeElement(n *xmlNode, depth int) {
+	indent := strings.Repeat("  ", depth)
+	fmt.Fprintf(iw.buf, "%s<%s", indent, iw.qname(n.name))
+	for _, a := range n.attrs {
+		if a.Name.Space == "xmlns" || (a.Name.Space == "" && a.Name.Local == "xmlns") {
+			continue
+		}
+		fmt.Fprintf(iw.buf, ` %s="%s"`, iw.qname(a.Name), esc(a.Value))
+	}
+
+	var elems []*xmlNode
+	var text strings.Builder
+	for _, c := range n.children {
+		switch c := c.(type) {
+		case *xmlNode:
+			elems = append(elems, c)
+		case string:
+			text.WriteString(c)
+		}
+	}
+	switch {
+	case len(elems) > 0:
+		iw.buf.WriteString(">\n")
+		for _, c := range elems {
+			iw.writeElement(c, depth+1)
+		}
+		fmt.Fprintf(iw.buf, "%s</%s>\n", indent, iw.qname(n.name))
+	case text.Len() > 0:
+		fmt.Fprintf(iw.buf, ">%s</%s>\n", esc(strings.TrimSpace(text.String())), iw.qname(n.name))
+	default:
+		iw.buf.WriteString("/>\n")
+	}
+}
+
+// writeFact writes an item or tuple fact.
+func (iw *instanceWriter) writeFact(f *xmlNode, depth int) error {
+	indent := strings.Repeat("  ", depth)
+	name := f.attr("", "name")
+	if name == "" {
+		return fmt.Errorf("ix:%s without name", f.name.Local)
+	}
+
+	if f.name.Local == "tuple" {
+		fmt.Fprintf(iw.buf, "%s<%s%s>\n", indent, name, copyAttrs(f, "id"))
+		for _, m := range iw.members[f] {
+			if err := iw.writeFact(m, depth+1); err != nil {
+				return err
+			}
+		}
+		fmt.Fprintf(iw.buf, "%s</%s>\n", indent, name)
+		return nil
+	}
+
+	if f.attr(xsiNS, "nil") == "true" {
+		fmt.Fprintf(iw.buf, "%s<%s%s xsi:nil=\"true\"/>\n", indent, name,
+			copyAttrs(f, "id", "contextRef", "unitRef", "decimals", "precision"))
+		return nil
+	}
+
+	v, err := iw.doc.value(f)
+	if err != nil {
+		return err
+	}
+	attrs := copyAttrs(f, "id", "contextRef")
+	if f.name.Local == "nonFraction" {
+		attrs = copyAttrs(f, "id", "decimals", "precision", "contextRef", "unitRef")
+	}
+	fmt.Fprintf(iw.buf, "%s<%s%s>%s</%s>\n", indent, name, attrs, esc(v), name)
+	return nil
+}
+
+// value returns the XBRL value of an ix:nonFraction or ix:nonNumeric fact.
+func (d *inlineDocument) value(f *xmlNode) (string, error) {
+	name := f.attr("", "name")
+	if f.name.Local == "nonFraction" {
+		v, err := numericValue(textContent(f, false), f.attr("", "format"), f.attr("", "scale"), f.attr("", "sign"))
+		if err != nil {
+			return "", fmt.Errorf("fact %s: %w", name, err)
+		}
+		return v, nil
+	}
+	escape := f.attr("", "escape") == "true" || f.attr("", "escape") == "1"
+	v, err := d.nonNumericValue(f, escape)
+	if err == nil && f.attr("", "format") != "" {
+		v, err = applyTransform(f.attr("", "format"), v)
+	}
+	if err != nil {
+		return "", fmt.Errorf("fact %s: %w", name, err)
+	}
+	return v, nil
+}
+
+// nonNumericValue returns the text of a nonNumeric fact including the
+// ix:continuation elements it is continued at. With markup set, XHTML
+// elements are kept as tags.
+func (d *inlineDocument) nonNumericValue(f *xmlNode, markup bool) (string, error) {
+	var sb strings.Builder
+	sb.WriteString(textContent(f, markup))
+
+	seen := map[string]bool{}
+	next := f.attr("", "continuedAt")
+	for next != "" {
+		if seen[next] {
+			return "", fmt.Errorf("continuation %q forms a cycle", next)
+		}
+		seen[next] = true
+		cont, ok := d.continuations[next]
+		if !ok {
+			return "", fmt.Errorf("continuation %q not found", next)
+		}
+		sb.WriteString(textContent(cont, markup))
+		next = cont.attr("", "continuedAt")
+	}
+	return sb.String(), nil
+}
+
+// copyAttrs formats the named unqualified attributes that are present on n.
+func copyAttrs(n *xmlNode, names ...string) string {
+	var sb strings.Builder
+	for _, name := range names {
+		if v := n.attr("", name); v != "" {
+			fmt.Fprintf(&sb, ` %s="%s"`, name, esc(v))
+		}
+	}
+	return sb.String()
+}
+
+// textContent returns the character data of n and its descendants, leaving
+// out ix:exclude elements. With markup set, XHTML elements are kept as
+// tags, as required for nonNumeric facts with escape="true".
+func textContent(n *xmlNode, markup bool) string {
+	var sb strings.Builder
+	var walk func(n *xmlNode)
+	walk = func(n *xmlNode) {
+		for _, c := range n.children {
+			switch c := c.(type) {
+			case string:
+				if markup {
+					sb.WriteString(esc(c))
+				} else {
+					sb.WriteString(c)
+				}
+			case *xmlNode:
+				if c.is(ixNS, "exclude") {
+					continue
+				}
+				if !markup || c.name.Space == ixNS {
+					walk(c)
+					continue
+				}
+				sb.WriteString("<" + c.name.Local)
+				for _, a := range c.attrs {
+					if a.Name.Space == "" && a.Name.Local != "xmlns" {
+						fmt.Fprintf(&sb, ` %s="%s"`, a.Name.Local, esc(a.Value))
+					}
+				}
+				sb.WriteString(">")
+				walk(c)
+				sb.WriteString("</" + c.name.Local + ">")
+			}
+		}
+	}
+	walk(n)
+	return sb.String()
+}
+
+// ---------- numeric values ----------
+
+// numericValue converts the displayed text of an ix:nonFraction into its
+// XBRL value by applying the transformation format, scale and sign.
+func numericValue(display, format, scale, sign string) (string, error) {
+	if format == "" {
+		format = "num-dot-decimal"
+	}
+	num, err := applyTransform(format, display)
+	if err != nil {
+		return "", err
+	}
+	intPart, fracPart, _ := strings.Cut(num, ".")
+	if strings.Trim(intPart+fracPart, "0123456789") != "" || intPart+fracPart == "" {
+		return "", fmt.Errorf("format %q does not produce a number", format)
+	}
+
+	n := 0
+	if scale != "" {
+		n, err = strconv.Atoi(scale)
+		if err != nil {
+			return "", fmt.Errorf("invalid scale %q", scale)
+		}
+	}
+	v := shiftDecimal(intPart, fracPart, n)
+	if sign == "-" && strings.Trim(v, "0.") != "" {
+		v = "-" + v
+	}
+	return v, nil
+}
+
+// shiftDecimal multiplies the decimal number intPart.fracPart by 10^scale
+// without loss of precision.
+func shiftDecimal(intPart, fracPart string, scale int) string {
+	digits := intPart + fracPart
+	point := len(intPart) + scale
+	if point < 0 {
+		digits = strings.Repeat("0", -point) + digits
+		point = 0
+	}
+	if point > len(digits) {
+		digits += strings.Repeat("0", point-len(digits))
+	}
+	i := strings.TrimLeft(digits[:point], "0")
+	if i == "" {
+		i = "0"
+	}
+	if f := digits[point:]; f != "" {
+		return i + "." + f
+	}
+	return i
+}
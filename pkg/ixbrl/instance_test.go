@@ -0,0 +1,261 @@
+package ixbrl
+
+import (
+	"bytes"
+	"encoding/xml"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/redofri/redofri/pkg/taxonomy"
+)
+
+// instanceFacts returns the facts of an XBRL instance as sorted strings of
+// concept, context, unit and value. Tuples list their members in brackets.
+// Numbers are normalized so that 1.00 and 1.000 compare equal.
+func instanceFacts(t *testing.T, data []byte, localNames bool) (facts []string, contexts int) {
+	t.Helper()
+	root, err := parseXMLTree(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("instance is not well-formed XML: %v", err)
+	}
+	if !root.is(xbrliNS, "xbrl") {
+		t.Fatalf("root element is %v, want xbrli:xbrl", root.name)
+	}
+
+	var describe func(n *xmlNode) string
+	describe = func(n *xmlNode) string {
+		name := n.name.Space + "#" + n.name.Local
+		if localNames {
+			name = n.name.Local
+		}
+		var members []string
+		for _, c := range n.children {
+			if m, ok := c.(*xmlNode); ok {
+				members = append(members, describe(m))
+			}
+		}
+		if len(members) > 0 {
+			sort.Strings(members)
+			return name + "[" + strings.Join(members, "; ") + "]"
+		}
+		value := strings.Join(strings.Fields(textContent(n, false)), " ")
+		if unit := n.attr("", "unitRef"); unit != "" {
+			if v, err := strconv.ParseFloat(value, 64); err == nil {
+				value = strconv.FormatFloat(v, 'f', -1, 64)
+			}
+		}
+		return strings.Join([]string{name, n.attr("", "contextRef"), n.attr("", "unitRef"), n.attr("", "decimals"), value}, "|")
+	}
+
+	for _, c := range root.children {
+		n, ok := c.(*xmlNode)
+		if !ok {
+			continue
+		}
+		switch {
+		case n.is(xbrliNS, "context"):
+			contexts++
+		case n.is(xbrliNS, "unit"), n.is(linkNS, "schemaRef"):
+		default:
+			facts = append(facts, describe(n))
+		}
+	}
+	sort.Strings(facts)
+	return facts, contexts
+}
+
+func compareFacts(t *testing.T, got, want []string) {
+	t.Helper()
+	gotSet := map[string]int{}
+	for _, f := range got {
+		gotSet[f]++
+	}
+	for _, f := range want {
+		if gotSet[f] == 0 {
+			t.Errorf("missing fact %s", f)
+			continue
+		}
+		gotSet[f]--
+	}
+	for f, n := range gotSet {
+		for ; n > 0; n-- {
+			t.Errorf("unexpected fact %s", f)
+		}
+	}
+}
+
+func TestExtractInstance_ReferenceExample(t *testing.T) {
+	doc, err := os.ReadFile("../../ref/exempel/faststalld-arsredovisning-exempel-1.xhtml")
+	if err != nil {
+		t.Skipf("reference example not available: %v", err)
+	}
+	want, err := os.ReadFile("../../ref/exempel/faststalld-arsredovisning-exempel-1.xbrl")
+	if err != nil {
+		t.Skipf("reference instance not available: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := ExtractInstance(&buf, bytes.NewReader(doc)); err != nil {
+		t.Fatalf("ExtractInstance: %v", err)
+	}
+
+	gotFacts, gotContexts := instanceFacts(t, buf.Bytes(), false)
+	wantFacts, wantContexts := instanceFacts(t, want, false)
+	if gotContexts != wantContexts {
+		t.Errorf("contexts = %d, want %d", gotContexts, wantContexts)
+	}
+	if len(gotFacts) != len(wantFacts) {
+		t.Errorf("facts = %d, want %d", len(gotFacts), len(wantFacts))
+	}
+	compareFacts(t, gotFacts, wantFacts)
+
+	for _, ref := range []string{"se-k2-risbs-2017-09-30.xsd", "se-k2-rcoa-2017-09-30.xsd"} {
+		if !strings.Contains(buf.String(), ref) {
+			t.Errorf("missing schemaRef %s", ref)
+		}
+	}
+}
+
+func TestWriteInstance(t *testing.T) {
+	r := loadTestReport(t)
+
+	var buf bytes.Buffer
+	if err := WriteInstance(&buf, r); err != nil {
+		t.Fatalf("WriteInstance: %v", err)
+	}
+	out := buf.String()
+
+	if !strings.HasPrefix(out, `<?xml version="1.0" encoding="UTF-8"?>`) {
+		t.Error("missing XML declaration")
+	}
+	for _, want := range []string{
+		`xmlns:xbrli="http://www.xbrl.org/2003/instance"`,
+		`<link:schemaRef xlink:type="simple" xlink:href="` + taxonomy.K2Release(TaxonomyVersion).EntryPointURL(r.Meta.EntryPoint) + `"/>`,
+		`<xbrli:context id="period0">`,
+		`<xbrli:identifier scheme="http://www.bolagsverket.se">556999-9999</xbrli:identifier>`,
+		`<xbrli:unit id="SEK">`,
+		`<se-gen-base:Nettoomsattning decimals="INF" contextRef="period0" unitRef="SEK">2650000</se-gen-base:Nettoomsattning>`,
+		`<se-gen-base:UnderskriftArsredovisningForetradareTuple>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %s", want)
+		}
+	}
+	if strings.Contains(out, "ix:") || strings.Contains(out, "<div") {
+		t.Error("inline XBRL or XHTML markup in instance")
+	}
+
+	// Every fact tagged by the generator appears in the instance, and the
+	// facts agree with the reference instance for the same report.
+	doc, err := GenerateBytes(r)
+	if err != nil {
+		t.Fatalf("GenerateBytes: %v", err)
+	}
+	tagged, err := ReadDocument(bytes.NewReader(doc))
+	if err != nil {
+		t.Fatalf("ReadDocument: %v", err)
+	}
+	items := len(tagged.Facts)
+	if got := strings.Count(out, ` contextRef="`); got != items {
+		t.Errorf("instance has %d facts, generator tagged %d", got, items)
+	}
+
+	ref, err := os.ReadFile("../../ref/exempel/faststalld-arsredovisning-exempel-1.xbrl")
+	if err != nil {
+		t.Skipf("reference instance not available: %v", err)
+	}
+	gotFacts, _ := instanceFacts(t, buf.Bytes(), true)
+	wantFacts, _ := instanceFacts(t, ref, true)
+	numeric := func(facts []string) []string {
+		var out []string
+		for _, f := range facts {
+			if parts := strings.Split(f, "|"); len(parts) == 5 && parts[2] != "" {
+				out = append(out, f)
+			}
+		}
+		return out
+	}
+	compareFacts(t, numeric(gotFacts), numeric(wantFacts))
+}
+
+func TestExtractInstance_Features(t *testing.T) {
+	doc := `<html xmlns="http://www.w3.org/1999/xhtml" xmlns:ix="http://www.xbrl.org/2013/inlineXBRL"
+ xmlns:ixt="http://www.xbrl.org/inlineXBRL/transformation/2010-04-20"
+ xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:link="http://www.xbrl.org/2003/linkbase"
+ xmlns:xlink="http://www.w3.org/1999/xlink" xmlns:iso4217="http://www.xbrl.org/2003/iso4217"
+ xmlns:se-gen-base="http://www.taxonomier.se/se/fr/gen-base/2021-10-31">
+<body>
+<ix:header><ix:references><link:schemaRef xlink:type="simple" xlink:href="s.xsd"/></ix:references>
+<ix:resources>
+<xbrli:context id="c"><xbrli:entity><xbrli:identifier scheme="http://www.bolagsverket.se">1</xbrli:identifier></xbrli:entity><xbrli:period><xbrli:instant>2024-12-31</xbrli:instant></xbrli:period></xbrli:context>
+<xbrli:unit id="SEK"><xbrli:measure>iso4217:SEK</xbrli:measure></xbrli:unit>
+</ix:resources></ix:header>
+<ix:tuple name="se-gen-base:T" tupleID="t1"/>
+<p><ix:nonNumeric name="se-gen-base:B" contextRef="c" tupleRef="t1" order="2">second</ix:nonNumeric>
+<ix:nonNumeric name="se-gen-base:A" contextRef="c" tupleRef="t1" order="1">first</ix:nonNumeric></p>
+<ix:nonFraction name="se-gen-base:N" contextRef="c" unitRef="SEK" decimals="INF" scale="3" sign="-" format="ixt:numspacecomma">1 234,5</ix:nonFraction>
+<ix:nonFraction name="se-gen-base:P" contextRef="c" unitRef="SEK" decimals="INF" scale="-2" format="ixt:numcomma">7,5</ix:nonFraction>
+<ix:nonFraction name="se-gen-base:Z" contextRef="c" unitRef="SEK" decimals="INF" format="ixt:numdash">-</ix:nonFraction>
+<ix:nonNumeric name="se-gen-base:X" contextRef="c" continuedAt="k1">A <b>&amp; B</b><ix:exclude> (hidden)</ix:exclude></ix:nonNumeric>
+<ix:continuation id="k1"> and C</ix:continuation>
+</body></html>`
+
+	var buf bytes.Buffer
+	if err := ExtractInstance(&buf, strings.NewReader(doc)); err != nil {
+		t.Fatalf("ExtractInstance: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`<link:schemaRef xlink:type="simple" xlink:href="s.xsd"/>`,
+		`<se-gen-base:N decimals="INF" contextRef="c" unitRef="SEK">-1234500</se-gen-base:N>`,
+		`<se-gen-base:P decimals="INF" contextRef="c" unitRef="SEK">0.075</se-gen-base:P>`,
+		`<se-gen-base:Z decimals="INF" contextRef="c" unitRef="SEK">0</se-gen-base:Z>`,
+		`<se-gen-base:X contextRef="c">A &amp; B and C</se-gen-base:X>`,
+		"<se-gen-base:T>\n    <se-gen-base:A contextRef=\"c\">first</se-gen-base:A>\n    <se-gen-base:B contextRef=\"c\">second</se-gen-base:B>\n  </se-gen-base:T>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %s in\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "xmlns:ixt") || strings.Contains(out, "xmlns:ix=") {
+		t.Error("inline XBRL namespaces declared in instance")
+	}
+	if err := xml.Unmarshal(buf.Bytes(), new(struct{})); err != nil {
+		t.Errorf("instance is not well-formed: %v", err)
+	}
+}
+
+func TestExtractInstance_Errors(t *testing.T) {
+	for name, doc := range map[string]string{
+		"bad number":           `<html xmlns:ix="http://www.xbrl.org/2013/inlineXBRL"><ix:nonFraction name="a:N" contextRef="c" unitRef="u">12x</ix:nonFraction></html>`,
+		"missing continuation": `<html xmlns:ix="http://www.xbrl.org/2013/inlineXBRL"><ix:nonNumeric name="a:X" contextRef="c" continuedAt="nope">x</ix:nonNumeric></html>`,
+		"unknown tuple":        `<html xmlns:ix="http://www.xbrl.org/2013/inlineXBRL"><ix:nonNumeric name="a:X" contextRef="c" tupleRef="t9">x</ix:nonNumeric></html>`,
+	} {
+		if err := ExtractInstance(new(bytes.Buffer), strings.NewReader(doc)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestShiftDecimal(t *testing.T) {
+	tests := []struct {
+		intPart, fracPart string
+		scale             int
+		want              string
+	}{
+		{"2193", "", 3, "2193000"},
+		{"33", "7", -2, "0.337"},
+		{"100", "0", -2, "1.000"},
+		{"0", "5", 0, "0.5"},
+		{"5", "", -3, "0.005"},
+		{"007", "", 0, "7"},
+	}
+	for _, tt := range tests {
+		if got := shiftDecimal(tt.intPart, tt.fracPart, tt.scale); got != tt.want {
+			t.Errorf("shiftDecimal(%q, %q, %d) = %q, want %q", tt.intPart, tt.fracPart, tt.scale, got, tt.want)
+		}
+	}
+}
@@ -17,9 +17,9 @@ func (g *generator) writeManagementReport(r *model.AnnualReport) {
 	g.in()
 	g.pageHeader(r.Company.Name, r.Company.OrgNr, 2, totalPages)
 
-	g.line(`<h2>Förvaltningsberättelse</h2>`)
-	g.line(`<h3>Verksamheten</h3>`)
-	g.line(`<h4>Allmänt om verksamheten</h4>`)
+	g.linef(`<h2>%s</h2>`, g.t("Förvaltningsberättelse"))
+	g.linef(`<h3>%s</h3>`, g.t("Verksamheten"))
+	g.linef(`<h4>%s</h4>`, g.t("Allmänt om verksamheten"))
 
 	// Business description (contains raw HTML)
 	g.write(strings.Repeat("\t", g.indent))
@@ -27,7 +27,7 @@ func (g *generator) writeManagementReport(r *model.AnnualReport) {
 	g.write("\n")
 
 	// Significant events
-	g.line(`<h4 class="join">Väsentliga händelser under räkenskapsåret</h4>`)
+	g.linef(`<h4 class="join">%s</h4>`, g.t("Väsentliga händelser under räkenskapsåret"))
 	g.line(`<p>`)
 	g.in()
 	g.write(strings.Repeat("\t", g.indent))
@@ -57,7 +57,7 @@ func (g *generator) writeManagementReport(r *model.AnnualReport) {
 
 	// Board statement on dividend (if any)
 	if mr.BoardDividendStatement != "" {
-		g.line(`<h3>Styrelsens yttrande över den föreslagna vinstutdelningen</h3>`)
+		g.linef(`<h3>%s</h3>`, g.t("Styrelsens yttrande över den föreslagna vinstutdelningen"))
 		g.write(strings.Repeat("\t", g.indent))
 		g.nonNumericRaw("se-gen-base:StyrelsensYttrandeVinstutdelning", "balans0",
 			"\n"+mr.BoardDividendStatement+"\n"+strings.Repeat("\t", g.indent))
@@ -76,7 +76,7 @@ func (g *generator) writeMultiYearOverview(r *model.AnnualReport) {
 	}
 
 	numCols := len(myo.Years) + 1 // +1 for label column
-	g.line(`<h3>Flerårsöversikt</h3>`)
+	g.linef(`<h3>%s</h3>`, g.t("Flerårsöversikt"))
 	g.linef(`<table class="ar-overview ar-financial col-%d">`, numCols)
 	g.in()
 
@@ -108,7 +108,7 @@ func (g *generator) writeMultiYearOverview(r *model.AnnualReport) {
 	// Nettoomsättning row
 	g.line(`<tr>`)
 	g.in()
-	g.linef(`<td>Nettoomsättning, <abbr>tkr</abbr></td>`)
+	g.linef(`<td>%s</td>`, g.t("Nettoomsättning, <abbr>tkr</abbr>"))
 	for i, y := range myo.Years {
 		g.write(strings.Repeat("\t", g.indent))
 		g.write("<td>")
@@ -129,7 +129,7 @@ func (g *generator) writeMultiYearOverview(r *model.AnnualReport) {
 	// Resultat efter finansiella poster row
 	g.line(`<tr>`)
 	g.in()
-	g.linef(`<td>Resultat efter finansiella poster, <abbr>tkr</abbr></td>`)
+	g.linef(`<td>%s</td>`, g.t("Resultat efter finansiella poster, <abbr>tkr</abbr>"))
 	for i, y := range myo.Years {
 		g.write(strings.Repeat("\t", g.indent))
 		g.write("<td>")
@@ -150,14 +150,19 @@ func (g *generator) writeMultiYearOverview(r *model.AnnualReport) {
 	// Soliditet row
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<td>Soliditet, %</td>`)
+	g.linef(`<td>%s</td>`, g.t("Soliditet, %"))
 	for i, y := range myo.Years {
 		g.write(strings.Repeat("\t", g.indent))
 		g.write("<td>")
 		if y.Solidity != nil {
 			ctx := contextRefForOverviewYear(i, true)
-			g.writef(`<ix:nonFraction contextRef="%s" name="se-gen-base:Soliditet" unitRef="procent" format="ixt:numcomma" scale="-2" decimals="INF">%s</ix:nonFraction>`,
-				ctx, esc(*y.Solidity))
+			display := esc(g.lbl.Decimal(*y.Solidity))
+			if g.release.Declares("se-gen-base:Soliditet") {
+				g.writef(`<ix:nonFraction contextRef="%s" name="se-gen-base:Soliditet" unitRef="procent" format="%s" scale="-2" decimals="INF">%s</ix:nonFraction>`,
+					ctx, g.decimalFormat(), display)
+			} else {
+				g.write(display)
+			}
 		}
 		g.write("</td>\n")
 	}
@@ -185,7 +190,7 @@ func (g *generator) writeMultiYearOverview(r *model.AnnualReport) {
 func (g *generator) writeEquityChanges(r *model.AnnualReport) {
 	ec := &r.ManagementReport.EquityChanges
 
-	g.line(`<h3>Förändringar i eget kapital</h3>`)
+	g.linef(`<h3>%s</h3>`, g.t("Förändringar i eget kapital"))
 	g.line(`<table class="ar-equity ar-financial col-5">`)
 	g.in()
 
@@ -202,11 +207,9 @@ func (g *generator) writeEquityChanges(r *model.AnnualReport) {
 	g.line(`<tr>`)
 	g.in()
 	g.line(`<th />`)
-	g.line(`<th scope="col">Aktiekapital</th>`)
-	g.line(`<th scope="col">Reservfond</th>`)
-	g.line(`<th scope="col">Balanserat resultat</th>`)
-	g.line(`<th scope="col">Årets resultat</th>`)
-	g.line(`<th scope="col">Totalt</th>`)
+	for _, col := range []string{"Aktiekapital", "Reservfond", "Balanserat resultat", "Årets resultat", "Totalt"} {
+		g.linef(`<th scope="col">%s</th>`, g.t(col))
+	}
 	g.out()
 	g.line(`</tr>`)
 	g.out()
@@ -218,7 +221,7 @@ func (g *generator) writeEquityChanges(r *model.AnnualReport) {
 	// Opening balances
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<td>Belopp vid årets ingång</td>`)
+	g.linef(`<td>%s</td>`, g.t("Belopp vid årets ingång"))
 	g.writeEquityCell("se-gen-base:Aktiekapital", "balans1", ec.OpeningShareCapital, "")
 	g.writeEquityCell("se-gen-base:Reservfond", "balans1", ec.OpeningReserveFund, "")
 	g.writeEquityCell("se-gen-base:BalanseratResultat", "balans1", ec.OpeningRetainedEarnings, "")
@@ -230,7 +233,7 @@ func (g *generator) writeEquityChanges(r *model.AnnualReport) {
 	// Resultatdisposition header row
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<td>Resultatdisposition enligt årsstämman</td>`)
+	g.linef(`<td>%s</td>`, g.t("Resultatdisposition enligt årsstämman"))
 	g.line(`<td colspan="5" />`)
 	g.out()
 	g.line(`</tr>`)
@@ -239,7 +242,7 @@ func (g *generator) writeEquityChanges(r *model.AnnualReport) {
 	if ec.DividendNetIncome != nil || ec.DividendTotal != nil {
 		g.line(`<tr>`)
 		g.in()
-		g.line(`<td>– Utdelning</td>`)
+		g.linef(`<td>%s</td>`, g.t("– Utdelning"))
 		g.line(`<td>–</td>`)
 		g.line(`<td>–</td>`)
 		g.line(`<td>–</td>`)
@@ -266,7 +269,7 @@ func (g *generator) writeEquityChanges(r *model.AnnualReport) {
 	// Year's result row
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<td>Årets resultat</td>`)
+	g.linef(`<td>%s</td>`, g.t("Årets resultat"))
 	g.line(`<td>–</td>`)
 	g.line(`<td>–</td>`)
 	g.line(`<td>–</td>`)
@@ -278,7 +281,7 @@ func (g *generator) writeEquityChanges(r *model.AnnualReport) {
 	// Closing balances
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<td>Belopp vid årets utgång</td>`)
+	g.linef(`<td>%s</td>`, g.t("Belopp vid årets utgång"))
 	g.writeEquityCell("se-gen-base:Aktiekapital", "balans0", ec.ClosingShareCapital, "total")
 	g.writeEquityCell("se-gen-base:Reservfond", "balans0", ec.ClosingReserveFund, "total")
 	g.writeEquityCell("se-gen-base:BalanseratResultat", "balans0", ec.ClosingRetainedEarnings, "total")
@@ -311,8 +314,8 @@ func (g *generator) writeEquityCell(concept, contextRef string, value *int64, wr
 func (g *generator) writeProfitDispositionPart1(r *model.AnnualReport) {
 	pd := &r.ManagementReport.ProfitDisposition
 
-	g.line(`<h3>Resultatdisposition</h3>`)
-	g.line(`<p class="ar-disp">Till årsstämmans förfogande står följande vinstmedel:</p>`)
+	g.linef(`<h3>%s</h3>`, g.t("Resultatdisposition"))
+	g.linef(`<p class="ar-disp">%s</p>`, g.t("Till årsstämmans förfogande står följande vinstmedel:"))
 	g.line(`<table class="ar-disp ar-financial">`)
 	g.in()
 	g.line(`<colgroup>`)
@@ -327,7 +330,7 @@ func (g *generator) writeProfitDispositionPart1(r *model.AnnualReport) {
 	// Balanserat resultat
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<td>Balanserat resultat</td>`)
+	g.linef(`<td>%s</td>`, g.t("Balanserat resultat"))
 	g.writeDispCell("se-gen-base:BalanseratResultat", "balans0", pd.RetainedEarnings, "")
 	g.out()
 	g.line(`</tr>`)
@@ -335,7 +338,7 @@ func (g *generator) writeProfitDispositionPart1(r *model.AnnualReport) {
 	// Årets resultat
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<td>Årets resultat</td>`)
+	g.linef(`<td>%s</td>`, g.t("Årets resultat"))
 	g.writeDispCell("se-gen-base:AretsResultatEgetKapital", "balans0", pd.NetIncome, "sum")
 	g.out()
 	g.line(`</tr>`)
@@ -343,7 +346,7 @@ func (g *generator) writeProfitDispositionPart1(r *model.AnnualReport) {
 	// Totalt
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<td>Totalt</td>`)
+	g.linef(`<td>%s</td>`, g.t("Totalt"))
 	g.writeDispCell("se-gen-base:MedelDisponera", "balans0", pd.TotalAvailable, "total")
 	g.out()
 	g.line(`</tr>`)
@@ -358,7 +361,7 @@ func (g *generator) writeProfitDispositionPart1(r *model.AnnualReport) {
 func (g *generator) writeProfitDispositionPart2(r *model.AnnualReport) {
 	pd := &r.ManagementReport.ProfitDisposition
 
-	g.line(`<p>Styrelsen och verkställande direktören föreslår att vinstmedlen disponeras enligt följande</p>`)
+	g.linef(`<p>%s</p>`, g.t("Styrelsen och verkställande direktören föreslår att vinstmedlen disponeras enligt följande"))
 	g.line(`<table class="ar-disp ar-financial">`)
 	g.in()
 	g.line(`<colgroup>`)
@@ -374,7 +377,7 @@ func (g *generator) writeProfitDispositionPart2(r *model.AnnualReport) {
 	if pd.Dividend != nil {
 		g.line(`<tr>`)
 		g.in()
-		g.line(`<td>Utdelning till ägarna</td>`)
+		g.linef(`<td>%s</td>`, g.t("Utdelning till ägarna"))
 		g.writeDispCell("se-gen-base:ForslagDispositionUtdelning", "balans0", pd.Dividend, "")
 		g.out()
 		g.line(`</tr>`)
@@ -383,7 +386,7 @@ func (g *generator) writeProfitDispositionPart2(r *model.AnnualReport) {
 	// Balanseras i ny räkning
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<td>Balanseras i ny räkning</td>`)
+	g.linef(`<td>%s</td>`, g.t("Balanseras i ny räkning"))
 	g.writeDispCell("se-gen-base:ForslagDispositionBalanserasINyRakning", "balans0", pd.CarriedForward, "sum")
 	g.out()
 	g.line(`</tr>`)
@@ -391,7 +394,7 @@ func (g *generator) writeProfitDispositionPart2(r *model.AnnualReport) {
 	// Totalt
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<td>Totalt</td>`)
+	g.linef(`<td>%s</td>`, g.t("Totalt"))
 	g.writeDispCell("se-gen-base:ForslagDisposition", "balans0", pd.TotalDisposition, "total")
 	g.out()
 	g.line(`</tr>`)
@@ -26,8 +26,8 @@ func (g *generator) writeNotes(r *model.AnnualReport) {
 	g.line(`<div class="ar-page wide" id="ar3-page-7">`)
 	g.in()
 	g.pageHeader(r.Company.Name, r.Company.OrgNr, 7, totalPages)
-	g.line(`<h2>Noter</h2>`)
-	g.line(`<p class="ar-amount-note">Noterna förklarar de belopp och bedömningar som ligger bakom resultat- och balansräkningen.</p>`)
+	g.linef(`<h2>%s</h2>`, g.t("Noter"))
+	g.linef(`<p class="ar-amount-note">%s</p>`, g.t("Noterna förklarar de belopp och bedömningar som ligger bakom resultat- och balansräkningen."))
 
 	g.writeAccountingPoliciesNote(r, &notes.AccountingPolicies)
 	if notes.Employees != nil {
@@ -85,7 +85,7 @@ func (g *generator) writeNotes(r *model.AnnualReport) {
 		g.line(`</div>`)
 	}
 
-	// Last page: multi-post note (if any) + signatures
+	// Last page: multi-post note (if any), passthrough facts + signatures
 	// This page div is opened here; writeSignatures() will add its content
 	// and the page div will be closed after writeSignatures() returns.
 	g.line(`<div class="ar-page wide" id="ar3-page-9">`)
@@ -95,6 +95,7 @@ func (g *generator) writeNotes(r *model.AnnualReport) {
 	if notes.MultiPostNote != nil {
 		g.writeMultiPostNote(r, notes.MultiPostNote)
 	}
+	g.writePassthrough(r)
 
 	// Signatures are written here inside the last notes page.
 	g.writeSignatures(r)
@@ -120,7 +121,7 @@ func splitFixedAssetNotes(notes []model.FixedAssetNote) (tangible, financial []m
 func (g *generator) writeAccountingPoliciesNote(r *model.AnnualReport, ap *model.AccountingPolicies) {
 	g.linef(`<h3 id="note-%d">`, ap.NoteNumber)
 	g.in()
-	g.linef(`<span class="note">Not %d</span> Redovisnings- och värderingsprinciper</h3>`, ap.NoteNumber)
+	g.linef(`<span class="note">%s</span> %s</h3>`, g.lbl.Tf("Not %d", ap.NoteNumber), g.t("Redovisnings- och värderingsprinciper"))
 	g.out()
 
 	// Main description
@@ -134,8 +135,8 @@ func (g *generator) writeAccountingPoliciesNote(r *model.AnnualReport, ap *model
 
 	// Depreciation table
 	if len(ap.Depreciations) > 0 {
-		g.line(`<h4 class="join">Avskrivningar</h4>`)
-		g.line(`<p class="join">Tillämpade avskrivningstider:</p>`)
+		g.linef(`<h4 class="join">%s</h4>`, g.t("Avskrivningar"))
+		g.linef(`<p class="join">%s</p>`, g.t("Tillämpade avskrivningstider:"))
 		g.line(`<table class="ar-depreciation">`)
 		g.in()
 		g.line(`<tbody>`)
@@ -149,7 +150,7 @@ func (g *generator) writeAccountingPoliciesNote(r *model.AnnualReport, ap *model
 			g.in()
 			g.write(indentStr(g.indent))
 			g.nonNumeric(dep.Concept, "period0", fmt.Sprintf("%d", dep.Years))
-			g.write(" år</td>\n")
+			g.writef(" %s</td>\n", g.t("år"))
 			g.out()
 			g.out()
 			g.line(`</tr>`)
@@ -174,7 +175,7 @@ func (g *generator) writeAccountingPoliciesNote(r *model.AnnualReport, ap *model
 
 	// Manufactured goods policy
 	if ap.ManufacturedGoodsPolicy != "" {
-		g.line(`<h4 class="join">Anskaffningsvärde för egentillverkade varor</h4>`)
+		g.linef(`<h4 class="join">%s</h4>`, g.t("Anskaffningsvärde för egentillverkade varor"))
 		g.line(`<p>`)
 		g.in()
 		g.write(indentStr(g.indent))
@@ -184,13 +185,20 @@ func (g *generator) writeAccountingPoliciesNote(r *model.AnnualReport, ap *model
 		g.line(`</p>`)
 	}
 
+	// Restated comparatives
+	if len(ap.Restatements) > 0 {
+		g.linef(`<h4 class="join">%s</h4>`, g.t("Ändrade jämförelsetal"))
+		for _, rs := range ap.Restatements {
+			g.linef(`<p>%s</p>`, esc(rs.Justification))
+		}
+	}
+
 	// Key figure definitions (hardcoded for K2)
-	g.line(`<h4 class="join">Nyckeltalsdefinitioner</h4>`)
+	g.linef(`<h4 class="join">%s</h4>`, g.t("Nyckeltalsdefinitioner"))
 	g.line(`<dl>`)
 	g.in()
-	g.line(`<dt>Soliditet</dt>`)
-	g.line(`<dd>Eget kapital och obeskattade reserver (med avdrag för uppskjuten skatt) i förhållande till`)
-	g.line(`    balansomslutningen.</dd>`)
+	g.linef(`<dt>%s</dt>`, g.t("Soliditet"))
+	g.linef(`<dd>%s</dd>`, g.t("Eget kapital och obeskattade reserver (med avdrag för uppskjuten skatt) i förhållande till balansomslutningen."))
 	g.out()
 	g.line(`</dl>`)
 }
@@ -199,9 +207,9 @@ func (g *generator) writeAccountingPoliciesNote(r *model.AnnualReport, ap *model
 func (g *generator) writeEmployeesNote(r *model.AnnualReport, emp *model.EmployeesNote) {
 	_, prevEnd := prevYearDates(r.FiscalYear.StartDate, r.FiscalYear.EndDate)
 
-	g.linef(`<h3 id="note-%d">Upplysningar till resultaträkningen`, emp.NoteNumber)
+	g.linef(`<h3 id="note-%d">%s`, emp.NoteNumber, g.t("Upplysningar till resultaträkningen"))
 	g.line(`    <br />`)
-	g.linef(`<span class="note">Not %d</span> Medelantalet anställda</h3>`, emp.NoteNumber)
+	g.linef(`<span class="note">%s</span> %s</h3>`, g.lbl.Tf("Not %d", emp.NoteNumber), g.t("Medelantalet anställda"))
 
 	g.line(`<table class="ar-note">`)
 	g.in()
@@ -222,12 +230,12 @@ func (g *generator) writeEmployeesNote(r *model.AnnualReport, emp *model.Employe
 	g.line(`<th />`)
 	g.line(`<th scope="col">`)
 	g.in()
-	g.linef(`<span>%s<br />–%s</span>`, r.FiscalYear.StartDate, r.FiscalYear.EndDate)
+	g.linef(`<span>%s<br />–%s</span>`, g.date(r.FiscalYear.StartDate), g.date(r.FiscalYear.EndDate))
 	g.out()
 	g.line(`</th>`)
 	g.line(`<th scope="col">`)
 	g.in()
-	g.linef(`<span>%s<br />–%s</span>`, prevStart(r.FiscalYear.StartDate), prevEnd)
+	g.linef(`<span>%s<br />–%s</span>`, g.date(prevStart(r.FiscalYear.StartDate)), g.date(prevEnd))
 	g.out()
 	g.line(`</th>`)
 	g.out()
@@ -240,7 +248,7 @@ func (g *generator) writeEmployeesNote(r *model.AnnualReport, emp *model.Employe
 	g.in()
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<td>Medelantalet anställda</td>`)
+	g.linef(`<td>%s</td>`, g.t("Medelantalet anställda"))
 
 	// Current year — note: no format attribute for antal-anstallda
 	g.line(`<td>`)
@@ -278,13 +286,13 @@ func (g *generator) writeFixedAssetNote(r *model.AnnualReport, fan *model.FixedA
 
 	// Heading
 	if isFirstOnPage {
-		g.linef(`<h3 id="note-%d">Upplysningar till balansräkningen`, fan.NoteNumber)
+		g.linef(`<h3 id="note-%d">%s`, fan.NoteNumber, g.t("Upplysningar till balansräkningen"))
 		g.line(`    <br />`)
-		g.linef(`<span class="note">Not %d</span> %s</h3>`, fan.NoteNumber, esc(fan.Title))
+		g.linef(`<span class="note">%s</span> %s</h3>`, g.lbl.Tf("Not %d", fan.NoteNumber), esc(fan.Title))
 	} else {
 		g.linef(`<h3 id="note-%d">`, fan.NoteNumber)
 		g.in()
-		g.linef(`<span class="note">Not %d</span> %s</h3>`, fan.NoteNumber, esc(fan.Title))
+		g.linef(`<span class="note">%s</span> %s</h3>`, g.lbl.Tf("Not %d", fan.NoteNumber), esc(fan.Title))
 		g.out()
 	}
 
@@ -307,12 +315,12 @@ func (g *generator) writeFixedAssetNote(r *model.AnnualReport, fan *model.FixedA
 	g.line(`<th />`)
 	g.line(`<th scope="col">`)
 	g.in()
-	g.linef(`<span>%s</span>`, r.FiscalYear.EndDate)
+	g.linef(`<span>%s</span>`, g.date(r.FiscalYear.EndDate))
 	g.out()
 	g.line(`</th>`)
 	g.line(`<th scope="col">`)
 	g.in()
-	g.linef(`<span>%s</span>`, prevEnd)
+	g.linef(`<span>%s</span>`, g.date(prevEnd))
 	g.out()
 	g.line(`</th>`)
 	g.out()
@@ -420,7 +428,7 @@ func (g *generator) writeNoteRow(label, concept, currentCtx, prevCtx string,
 
 	g.line(`<tr>`)
 	g.in()
-	g.linef(`<td>%s</td>`, label)
+	g.linef(`<td>%s</td>`, g.t(label))
 
 	// Current year
 	g.write(indentStr(g.indent))
@@ -470,7 +478,7 @@ func (g *generator) writeNoteSalesRow(label, concept, currentCtx, prevCtx string
 
 	g.line(`<tr>`)
 	g.in()
-	g.linef(`<td>%s</td>`, label)
+	g.linef(`<td>%s</td>`, g.t(label))
 
 	// Current year
 	g.write(indentStr(g.indent))
@@ -502,7 +510,7 @@ func (g *generator) writeNoteDepreciationRow(label, concept, currentCtx, prevCtx
 
 	g.line(`<tr>`)
 	g.in()
-	g.linef(`<td>%s</td>`, label)
+	g.linef(`<td>%s</td>`, g.t(label))
 
 	// Current year
 	g.write(indentStr(g.indent))
@@ -558,7 +566,7 @@ func (g *generator) writeNoteCarryingValueRow(conceptPrefix string, current, pre
 
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<td>Redovisat värde</td>`)
+	g.linef(`<td>%s</td>`, g.t("Redovisat värde"))
 
 	// Current year (balans0)
 	g.write(indentStr(g.indent))
@@ -604,7 +612,7 @@ func (g *generator) writeLongTermLiabilitiesNote(r *model.AnnualReport, note *mo
 
 	g.linef(`<h3 id="note-%d">`, note.NoteNumber)
 	g.in()
-	g.linef(`<span class="note">Not %d</span> Långfristiga skulder</h3>`, note.NoteNumber)
+	g.linef(`<span class="note">%s</span> %s</h3>`, g.lbl.Tf("Not %d", note.NoteNumber), g.t("Långfristiga skulder"))
 	g.out()
 
 	g.line(`<table class="ar-note">`)
@@ -617,7 +625,7 @@ func (g *generator) writeLongTermLiabilitiesNote(r *model.AnnualReport, note *mo
 	g.in()
 	g.line(`<tr>`)
 	g.in()
-	g.line(`<td>Långfristiga skulder som förfaller till betalning senare än fem år efter balansdagen:</td>`)
+	g.linef(`<td>%s</td>`, g.t("Långfristiga skulder som förfaller till betalning senare än fem år efter balansdagen:"))
 	g.line(`<td />`)
 	g.line(`<td />`)
 	g.out()
@@ -646,7 +654,7 @@ func (g *generator) writePledgesNote(r *model.AnnualReport, note *model.PledgesN
 
 	g.linef(`<h3 id="note-%d">`, note.NoteNumber)
 	g.in()
-	g.linef(`<span class="note">Not %d</span> Ställda säkerheter</h3>`, note.NoteNumber)
+	g.linef(`<span class="note">%s</span> %s</h3>`, g.lbl.Tf("Not %d", note.NoteNumber), g.t("Ställda säkerheter"))
 	g.out()
 
 	g.line(`<table class="ar-note">`)
@@ -698,7 +706,7 @@ func (g *generator) writeContingentLiabilitiesNote(r *model.AnnualReport, note *
 
 	g.linef(`<h3 id="note-%d">`, note.NoteNumber)
 	g.in()
-	g.linef(`<span class="note">Not %d</span> Eventualförpliktelser</h3>`, note.NoteNumber)
+	g.linef(`<span class="note">%s</span> %s</h3>`, g.lbl.Tf("Not %d", note.NoteNumber), g.t("Eventualförpliktelser"))
 	g.out()
 
 	g.line(`<table class="ar-note">`)
@@ -725,7 +733,7 @@ func (g *generator) writeContingentLiabilitiesNote(r *model.AnnualReport, note *
 func (g *generator) writeMultiPostNote(r *model.AnnualReport, note *model.MultiPostNote) {
 	g.linef(`<h3 id="note-%d">`, note.NoteNumber)
 	g.in()
-	g.linef(`<span class="note">Not %d</span> Tillgångar, avsättningar och skulder som avser flera poster</h3>`, note.NoteNumber)
+	g.linef(`<span class="note">%s</span> %s</h3>`, g.lbl.Tf("Not %d", note.NoteNumber), g.t("Tillgångar, avsättningar och skulder som avser flera poster"))
 	g.out()
 
 	// Description paragraph
@@ -759,7 +767,7 @@ func (g *generator) writeMultiPostNote(r *model.AnnualReport, note *model.MultiP
 
 	// Write tuple declarations for all entries
 	for i := range note.Entries {
-		g.linef(`<ix:tuple name="se-gen-base:TillgangarAvsattningarSkulderTuple" tupleID="TillgangarAvsattningarSkulderTuple%d" />`, i+1)
+		g.tuple("se-gen-base:TillgangarAvsattningarSkulderTuple", fmt.Sprintf("TillgangarAvsattningarSkulderTuple%d", i+1))
 	}
 
 	// Write each group
@@ -850,12 +858,12 @@ func (g *generator) writeNoteInstantHeader(currentEnd, prevEnd string) {
 	g.line(`<th />`)
 	g.line(`<th scope="col">`)
 	g.in()
-	g.linef(`<span>%s</span>`, currentEnd)
+	g.linef(`<span>%s</span>`, g.date(currentEnd))
 	g.out()
 	g.line(`</th>`)
 	g.line(`<th scope="col">`)
 	g.in()
-	g.linef(`<span>%s</span>`, prevEnd)
+	g.linef(`<span>%s</span>`, g.date(prevEnd))
 	g.out()
 	g.line(`</th>`)
 	g.out()
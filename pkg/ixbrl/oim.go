@@ -0,0 +1,198 @@
+package ixbrl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/redofri/redofri/pkg/model"
+)
+
+// xbrlJSONDocumentType identifies an xBRL-JSON report.
+const xbrlJSONDocumentType = "https://xbrl.org/2021/xbrl-json"
+
+// redofriOIMNS is the namespace of the redofri:tuple extension property,
+// which records tuple membership that the OIM model cannot express.
+const redofriOIMNS = "urn:redofri:oim"
+
+// WriteJSONFacts writes the facts that Generate tags for r as an xBRL-JSON
+// report following the OIM report format.
+func WriteJSONFacts(w io.Writer, r *model.AnnualReport) error {
+	doc, err := GenerateBytes(r)
+	if err != nil {
+		return err
+	}
+	return ExtractJSONFacts(w, bytes.NewReader(doc))
+}
+
+// ExtractJSONFacts reads an inline XBRL document and writes its facts as an
+// xBRL-JSON report. Each fact carries its concept, entity, period and, for
+// numeric facts, unit and decimals. Tuple members have a redofri:tuple
+// property naming the tuple, its instance and the member order.
+func ExtractJSONFacts(w io.Writer, doc io.Reader) error {
+	d, err := ReadDocument(doc)
+	if err != nil {
+		return err
+	}
+
+	namespaces := map[string]string{}
+	for prefix, uri := range d.Namespaces {
+		namespaces[prefix] = uri
+	}
+	namespaces["xbrli"] = xbrliNS
+
+	// Entity identifiers are written as prefix:identifier, with one prefix
+	// per identifier scheme.
+	schemes := map[string]string{}
+	entity := func(e Entity) string {
+		prefix, ok := schemes[e.Scheme]
+		if !ok {
+			prefix = "scheme"
+			if len(schemes) > 0 {
+				prefix += strconv.Itoa(len(schemes) + 1)
+			}
+			schemes[e.Scheme] = prefix
+			namespaces[prefix] = e.Scheme
+		}
+		return prefix + ":" + e.Identifier
+	}
+
+	out := oimReport{
+		DocumentInfo: oimDocumentInfo{
+			DocumentType: xbrlJSONDocumentType,
+			Namespaces:   namespaces,
+			Taxonomy:     d.SchemaRefs,
+		},
+	}
+	used := map[string]bool{}
+	for _, f := range d.Facts {
+		if f.ID != "" {
+			used[f.ID] = true
+		}
+	}
+	next := 0
+	for _, f := range d.Facts {
+		id := f.ID
+		for id == "" || (f.ID == "" && used[id]) {
+			next++
+			id = "f" + strconv.Itoa(next)
+		}
+		used[id] = true
+
+		of := oimFact{Dimensions: map[string]string{
+			"concept": f.Concept,
+			"entity":  entity(f.Context.Entity),
+			"period":  oimPeriod(f.Context.Period),
+		}}
+		for _, dim := range f.Context.Dimensions {
+			of.Dimensions[dim.Dimension] = dim.Member
+		}
+		if !f.Nil {
+			v := f.Value
+			of.Value = &v
+		}
+		if f.Unit != nil {
+			of.Dimensions["unit"] = f.Unit.String()
+			if f.Decimals != "" && f.Decimals != "INF" {
+				n, err := strconv.Atoi(f.Decimals)
+				if err != nil {
+					return fmt.Errorf("fact %s: invalid decimals %q", f.Concept, f.Decimals)
+				}
+				of.Decimals = &n
+			}
+		}
+		if f.Tuple != nil {
+			namespaces["redofri"] = redofriOIMNS
+			of.Tuple = &oimTuple{Concept: f.Tuple.Concept, ID: f.Tuple.ID, Order: f.Order}
+		}
+		out.Facts = append(out.Facts, oimFactEntry{id: id, fact: of})
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding JSON: %w", err)
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+type oimReport struct {
+	DocumentInfo oimDocumentInfo `json:"documentInfo"`
+	Facts        oimFacts        `json:"facts"`
+}
+
+type oimDocumentInfo struct {
+	DocumentType string            `json:"documentType"`
+	Namespaces   map[string]string `json:"namespaces"`
+	Taxonomy     []string          `json:"taxonomy"`
+}
+
+type oimFact struct {
+	Value      *string           `json:"value"` // null for xsi:nil facts
+	Decimals   *int              `json:"decimals,omitempty"`
+	Dimensions map[string]string `json:"dimensions"`
+	Tuple      *oimTuple         `json:"redofri:tuple,omitempty"`
+}
+
+type oimTuple struct {
+	Concept string `json:"concept"`
+	ID      string `json:"id"`
+	Order   string `json:"order,omitempty"`
+}
+
+type oimFactEntry struct {
+	id   string
+	fact oimFact
+}
+
+// oimFacts is the facts object, keyed by fact id in document order.
+type oimFacts []oimFactEntry
+
+func (fs oimFacts) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range fs {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(f.id)
+		if err != nil {
+			return nil, err
+		}
+		val, err := json.Marshal(f.fact)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// oimPeriod formats a context period in OIM form. Dates denote the end of
+// the day, so an instant of 2016-12-31 becomes 2017-01-01T00:00:00 and a
+// duration becomes start/end.
+func oimPeriod(p Period) string {
+	if p.IsInstant() {
+		return oimDateTime(p.Instant, true)
+	}
+	return oimDateTime(p.StartDate, false) + "/" + oimDateTime(p.EndDate, true)
+}
+
+// oimDateTime converts an XBRL date to an OIM date-time. End dates without
+// a time refer to midnight at the end of that day.
+func oimDateTime(s string, end bool) string {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return s // already a date-time
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t.Format("2006-01-02T15:04:05")
+}
@@ -1,11 +1,13 @@
 // Package ixbrl provides iXBRL generation and parsing for K2 annual reports.
 //
 // Parse reads an iXBRL (.xhtml) document and populates a model.AnnualReport
-// by extracting ix:nonFraction, ix:nonNumeric, and ix:tuple elements.
+// by extracting ix:nonFraction, ix:nonNumeric, and ix:tuple elements. It
+// reads an XBRL instance (.xbrl) with the same facts as well.
+// ReadDocument reads the same document at fact level, with contexts and
+// units resolved, for analysing any K2 iXBRL report.
 package ixbrl
 
 import (
-	"encoding/xml"
 	"fmt"
 	"io"
 	"math"
@@ -13,15 +15,91 @@ import (
 	"strings"
 
 	"github.com/redofri/redofri/pkg/model"
+	"github.com/redofri/redofri/pkg/taxonomy"
 )
 
-// Parse reads an iXBRL document from r and returns a populated AnnualReport.
-func Parse(r io.Reader) (*model.AnnualReport, error) {
-	facts, err := extractFacts(r)
+// Result holds a parsed annual report together with what could not be
+// carried over into it.
+type Result struct {
+	Report *model.AnnualReport
+
+	// Warnings list unmapped concepts, ignored tuples, duplicate facts
+	// with conflicting values, and facts whose context could not be
+	// placed relative to the fiscal year.
+	Warnings []string
+
+	// Errors lists the facts that could not be read, e.g. because of an
+	// unsupported format. Such facts are left out of the report.
+	Errors []*FactError
+}
+
+// FactError reports a single fact that could not be read or mapped, e.g.
+// because of an unsupported format or a missing continuation.
+type FactError struct {
+	Concept string // QName of the fact
+	Context string // contextRef of the fact
+	Err     error
+}
+
+func (e *FactError) Error() string {
+	return fmt.Sprintf("fact %s@%s: %v", e.Concept, e.Context, e.Err)
+}
+
+func (e *FactError) Unwrap() error { return e.Err }
+
+// Parse reads an iXBRL document, or an XBRL instance, from r and returns a
+// populated AnnualReport. Both give the same facts to the mapper.
+// Contexts are placed by their period dates relative to the fiscal year, so
+// reports using other context ids than redofri's own are read as well.
+// Facts that cannot be read are reported in Result.Errors; only documents
+// that are not well-formed XML fail as a whole.
+func Parse(r io.Reader) (*Result, error) {
+	root, err := parseXMLTree(r)
 	if err != nil {
-		return nil, fmt.Errorf("extracting facts: %w", err)
+		return nil, fmt.Errorf("reading XML: %w", err)
+	}
+	if isInstance(root) {
+		facts, contexts := extractInstanceFacts(root)
+		report, warnings, errs := mapFacts(facts, contexts)
+		setRelease(report, instanceSchemaRefs(root))
+		return &Result{Report: report, Warnings: warnings, Errors: errs}, nil
 	}
-	return mapFacts(facts)
+	in := newInlineDocument(root)
+
+	facts, errs := extractFacts(in)
+	contexts := map[string]*Context{}
+	for _, res := range in.resources {
+		if res.name.Local == "context" {
+			c := readContext(res)
+			contexts[c.ID] = c
+		}
+	}
+	report, warnings, mapErrs := mapFacts(facts, contexts)
+	setRelease(report, in.schemaRefs)
+	return &Result{Report: report, Warnings: warnings, Errors: append(errs, mapErrs...)}, nil
+}
+
+// setRelease sets the taxonomy version and entry point of a parsed report
+// from the schemas it refers to. Both are left empty when no schemaRef is
+// a known K2 entry point.
+func setRelease(r *model.AnnualReport, schemaRefs []string) {
+	release, variant := taxonomy.DetectK2Release(schemaRefs)
+	if release == nil {
+		return
+	}
+	r.Meta.TaxonomyVersion = release.Version
+	r.Meta.EntryPoint = variant
+}
+
+// instanceSchemaRefs returns the schemaRef hrefs of an XBRL instance.
+func instanceSchemaRefs(root *xmlNode) []string {
+	var refs []string
+	for _, c := range root.children {
+		if ref, ok := c.(*xmlNode); ok && ref.is(linkNS, "schemaRef") {
+			refs = append(refs, ref.attr(xlinkNS, "href"))
+		}
+	}
+	return refs
 }
 
 // ---------- fact extraction ----------
@@ -37,11 +115,18 @@ type fact struct {
 	// Context reference, e.g. "period0", "balans0"
 	ContextRef string
 
-	// Unit reference, e.g. "SEK", "procent", "antal-anstallda"
+	// Unit reference, e.g. "SEK", "procent", "antal-anstallda", and the
+	// unit it refers to, e.g. "iso4217:SEK"
 	UnitRef string
+	Unit    string
+
+	// Namespace URI of the concept, when its prefix is not one of the
+	// canonical ones
+	Namespace string
 
 	// Text content (for nonNumeric) or numeric string (for nonFraction)
 	Value string
+	Nil   bool // xsi:nil="true"
 
 	// Numeric attributes
 	Decimals string
@@ -51,7 +136,7 @@ type fact struct {
 
 	// Tuple support
 	TupleID  string // id for ix:tuple elements
-	TupleRef string // reference to parent tuple
+	TupleRef string // tupleID of the parent tuple
 	Order    string // ordering within tuple
 
 	// Special attributes
@@ -61,216 +146,122 @@ type fact struct {
 // ixNS is the iXBRL namespace URI.
 const ixNS = "http://www.xbrl.org/2013/inlineXBRL"
 
-// extractFacts parses the iXBRL XML and extracts all fact elements.
-// It handles nested ix:nonNumeric elements (e.g. in the certification section)
-// and ix:continuation elements by recursively extracting inner facts.
-func extractFacts(r io.Reader) ([]fact, error) {
-	decoder := xml.NewDecoder(r)
-
-	var facts []fact
-
-	for {
-		tok, err := decoder.Token()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return nil, fmt.Errorf("reading XML: %w", err)
-		}
-
-		switch t := tok.(type) {
-		case xml.StartElement:
-			if t.Name.Space != ixNS {
-				continue
-			}
-			extracted, err := parseIXElement(decoder, t)
-			if err != nil {
-				return nil, err
+// extractFacts converts the facts and tuples of an inline document to
+// facts in document order. Text facts get their continuations and format
+// applied; numeric facts keep the displayed number, which parseNumber
+// converts. Facts whose value cannot be read are left out and reported.
+func extractFacts(in *inlineDocument) ([]fact, []*FactError) {
+	// Tuples without a tupleID are numbered so that members nested in
+	// them can be grouped like those referring to one by tupleRef.
+	tupleIDs := map[*xmlNode]string{}
+	for _, n := range in.facts {
+		if n.name.Local == "tuple" {
+			id := n.attr("", "tupleID")
+			if id == "" {
+				id = "tuple" + strconv.Itoa(len(tupleIDs)+1)
 			}
-			facts = append(facts, extracted...)
+			tupleIDs[n] = id
 		}
 	}
 
-	return facts, nil
-}
-
-// parseIXElement dispatches to the correct parser for an ix: element
-// and returns all facts found (including any nested ones).
-func parseIXElement(decoder *xml.Decoder, start xml.StartElement) ([]fact, error) {
-	switch start.Name.Local {
-	case "nonFraction":
-		f, err := parseNonFraction(decoder, start)
-		if err != nil {
-			return nil, err
+	units := map[string]string{}
+	for _, res := range in.resources {
+		if res.name.Local == "unit" {
+			u := readUnit(res)
+			units[u.ID] = u.String()
 		}
-		return []fact{f}, nil
-
-	case "nonNumeric":
-		return parseNonNumericRecursive(decoder, start)
-
-	case "tuple":
-		return []fact{parseTuple(start)}, nil
-
-	case "continuation":
-		// Parse contents of continuation — may contain ix: elements.
-		return parseContainerContents(decoder, start.Name)
-
-	default:
-		return nil, nil
 	}
-}
 
-// getAttr returns the value of an attribute by local name.
-func getAttr(attrs []xml.Attr, local string) string {
-	for _, a := range attrs {
-		if a.Name.Local == local {
-			return a.Value
+	var facts []fact
+	var errs []*FactError
+	for _, n := range in.facts {
+		f := fact{
+			Kind:       n.name.Local,
+			Name:       canonicalName(in, n.attr("", "name")),
+			ContextRef: n.attr("", "contextRef"),
+			UnitRef:    n.attr("", "unitRef"),
+			Unit:       units[n.attr("", "unitRef")],
+			Nil:        n.attr(xsiNS, "nil") == "true",
+			Decimals:   n.attr("", "decimals"),
+			Sign:       n.attr("", "sign"),
+			Format:     n.attr("", "format"),
+			Order:      n.attr("", "order"),
+			ID:         n.attr("", "id"),
 		}
-	}
-	return ""
-}
-
-// parseNonFraction extracts a fact from an ix:nonFraction element.
-func parseNonFraction(decoder *xml.Decoder, start xml.StartElement) (fact, error) {
-	scaleStr := getAttr(start.Attr, "scale")
-	scale := 0
-	if scaleStr != "" {
-		s, err := strconv.Atoi(scaleStr)
-		if err != nil {
-			return fact{}, fmt.Errorf("invalid scale %q: %w", scaleStr, err)
+		if prefix, _, _ := strings.Cut(f.Name, ":"); !isCanonicalPrefix(prefix) {
+			f.Namespace = in.namespaces[prefix]
 		}
-		scale = s
-	}
-
-	// Read inner text content, skipping any nested elements.
-	value := collectText(decoder, start.Name)
-
-	return fact{
-		Kind:       "nonFraction",
-		Name:       getAttr(start.Attr, "name"),
-		ContextRef: getAttr(start.Attr, "contextRef"),
-		UnitRef:    getAttr(start.Attr, "unitRef"),
-		Value:      strings.TrimSpace(value),
-		Decimals:   getAttr(start.Attr, "decimals"),
-		Scale:      scale,
-		Sign:       getAttr(start.Attr, "sign"),
-		Format:     getAttr(start.Attr, "format"),
-		TupleRef:   getAttr(start.Attr, "tupleRef"),
-		Order:      getAttr(start.Attr, "order"),
-		ID:         getAttr(start.Attr, "id"),
-	}, nil
-}
-
-// parseNonNumericRecursive extracts a nonNumeric fact and any nested ix: facts.
-// It returns the outer fact plus all inner facts in order.
-func parseNonNumericRecursive(decoder *xml.Decoder, start xml.StartElement) ([]fact, error) {
-	var result []fact
-	var textParts []string
-	depth := 1
-
-	for depth > 0 {
-		tok, err := decoder.Token()
-		if err != nil {
-			return nil, fmt.Errorf("reading nonNumeric %s: %w", getAttr(start.Attr, "name"), err)
+		if n.name.Local == "tuple" {
+			f.TupleID = tupleIDs[n]
+		}
+		if t, err := in.parentTuple(n); err != nil {
+			errs = append(errs, &FactError{Concept: f.Name, Context: f.ContextRef, Err: err})
+			continue
+		} else if t != nil {
+			f.TupleRef = tupleIDs[t]
 		}
 
-		switch t := tok.(type) {
-		case xml.CharData:
-			textParts = append(textParts, string(t))
-
-		case xml.StartElement:
-			depth++
-			if t.Name.Space == ixNS {
-				// Recursively parse nested ix: element.
-				inner, err := parseIXElement(decoder, t)
-				if err != nil {
-					return nil, err
+		var err error
+		switch {
+		case f.Kind == "tuple" || f.Nil:
+		case f.Kind == "nonFraction":
+			if s := n.attr("", "scale"); s != "" {
+				if f.Scale, err = strconv.Atoi(s); err != nil {
+					err = fmt.Errorf("invalid scale %q", s)
 				}
-				result = append(result, inner...)
-				depth-- // parseIXElement consumed the end element.
 			}
-
-		case xml.EndElement:
-			depth--
+			f.Value = strings.TrimSpace(textContent(n, false))
+			if err == nil {
+				_, err = parseNumber(f)
+			}
+		default:
+			f.Value, err = in.nonNumericValue(n, false)
+			if err == nil && f.Format != "" {
+				f.Value, err = applyTransform(f.Format, f.Value)
+			}
+			f.Value = strings.TrimSpace(f.Value)
 		}
-	}
-
-	// Build the outer fact.
-	outer := fact{
-		Kind:       "nonNumeric",
-		Name:       getAttr(start.Attr, "name"),
-		ContextRef: getAttr(start.Attr, "contextRef"),
-		Value:      strings.TrimSpace(strings.Join(textParts, "")),
-		TupleRef:   getAttr(start.Attr, "tupleRef"),
-		Order:      getAttr(start.Attr, "order"),
-		ID:         getAttr(start.Attr, "id"),
-	}
-
-	// Prepend the outer fact before any inner facts.
-	return append([]fact{outer}, result...), nil
-}
-
-// parseContainerContents parses the contents of an ix:continuation or similar
-// container element, returning any nested ix: facts found within.
-func parseContainerContents(decoder *xml.Decoder, name xml.Name) ([]fact, error) {
-	var result []fact
-	depth := 1
-
-	for depth > 0 {
-		tok, err := decoder.Token()
 		if err != nil {
-			return nil, fmt.Errorf("reading container: %w", err)
-		}
-
-		switch t := tok.(type) {
-		case xml.StartElement:
-			depth++
-			if t.Name.Space == ixNS {
-				inner, err := parseIXElement(decoder, t)
-				if err != nil {
-					return nil, err
-				}
-				result = append(result, inner...)
-				depth-- // parseIXElement consumed the end element.
-			}
-
-		case xml.EndElement:
-			depth--
+			errs = append(errs, &FactError{Concept: f.Name, Context: f.ContextRef, Err: err})
+			continue
 		}
+		facts = append(facts, f)
 	}
+	return facts, errs
+}
 
-	return result, nil
+// canonicalPrefixes maps taxonomy namespaces, without their version date,
+// to the prefixes the mapper looks concepts up by.
+var canonicalPrefixes = map[string]string{
+	"http://www.taxonomier.se/se/fr/gen-base/":    "se-gen-base",
+	"http://www.taxonomier.se/se/fr/cd-base/":     "se-cd-base",
+	"http://www.bolagsverket.se/se/fr/comp-base/": "se-bol-base",
 }
 
-// parseTuple extracts a tuple declaration (self-closing element).
-func parseTuple(start xml.StartElement) fact {
-	return fact{
-		Kind:    "tuple",
-		Name:    getAttr(start.Attr, "name"),
-		TupleID: getAttr(start.Attr, "tupleID"),
+// isCanonicalPrefix reports whether prefix is one of canonicalPrefixes.
+func isCanonicalPrefix(prefix string) bool {
+	for _, p := range canonicalPrefixes {
+		if p == prefix {
+			return true
+		}
 	}
+	return false
 }
 
-// collectText reads tokens until the matching end element, collecting all
-// character data. Nested elements are consumed but their text is included.
-func collectText(decoder *xml.Decoder, name xml.Name) string {
-	var sb strings.Builder
-	depth := 1
-	for depth > 0 {
-		tok, err := decoder.Token()
-		if err != nil {
-			break
-		}
-		switch t := tok.(type) {
-		case xml.CharData:
-			sb.Write(t)
-		case xml.StartElement:
-			depth++
-		case xml.EndElement:
-			depth--
+// canonicalName rewrites a concept QName to the canonical prefix of its
+// namespace, so that reports declaring other prefixes map the same way.
+func canonicalName(in *inlineDocument, name string) string {
+	prefix, local, ok := strings.Cut(name, ":")
+	if !ok {
+		return name
+	}
+	uri := in.namespaces[prefix]
+	for base, canonical := range canonicalPrefixes {
+		if strings.HasPrefix(uri, base) {
+			return canonical + ":" + local
 		}
 	}
-	return sb.String()
+	return name
 }
 
 // ---------- number parsing ----------
@@ -278,42 +269,13 @@ func collectText(decoder *xml.Decoder, name xml.Name) string {
 // parseNumber parses the display string of a nonFraction fact into an int64
 // XBRL value, applying format, scale, and sign transformations.
 func parseNumber(f fact) (int64, error) {
-	s := f.Value
-
-	// Remove display formatting: spaces and any non-breaking spaces.
-	s = strings.ReplaceAll(s, "\u00a0", "")
-	s = strings.ReplaceAll(s, " ", "")
-
-	// Handle format-specific decimal separator.
-	switch f.Format {
-	case "ixt:numspacecomma", "ixt:numcomma":
-		// Swedish format: comma is decimal separator.
-		s = strings.ReplaceAll(s, ",", ".")
-	}
-
-	// Remove any remaining formatting chars (e.g. leading minus that's in the display).
-	s = strings.TrimLeft(s, "-+")
-	if s == "" {
-		return 0, nil
-	}
-
-	val, err := strconv.ParseFloat(s, 64)
+	v, err := numericValue(f.Value, f.Format, strconv.Itoa(f.Scale), f.Sign)
 	if err != nil {
-		return 0, fmt.Errorf("parsing number %q (from %q): %w", s, f.Value, err)
-	}
-
-	// Apply scale: value * 10^scale gives the XBRL value.
-	if f.Scale != 0 {
-		val *= math.Pow(10, float64(f.Scale))
+		return 0, fmt.Errorf("parsing number %q: %w", f.Value, err)
 	}
-
-	// Round to nearest integer.
-	result := int64(math.Round(val))
-
-	// Apply sign.
-	if f.Sign == "-" {
-		result = -result
+	val, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsing number %q: %w", f.Value, err)
 	}
-
-	return result, nil
+	return int64(math.Round(val)), nil
 }
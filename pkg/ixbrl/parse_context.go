@@ -0,0 +1,126 @@
+package ixbrl
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// overviewYears is the number of fiscal years placed: the current year and
+// the three previous ones shown in the flerårsöversikt.
+const overviewYears = 4
+
+// placeContexts maps context ids to the logical contexts the mapper works
+// with: periodN for the fiscal year N years back and balansN for the
+// balance date at its end. Contexts are placed by their dates, whatever
+// they are called in the document:
+//
+//   - a duration ending on the last day of fiscal year N is periodN; if
+//     several such durations start on different days, the longest wins;
+//   - an instant on the last day of fiscal year N is balansN.
+//
+// Fiscal year N ends the day before year N-1 starts. When a year has no
+// duration context to read its start from, it is assumed to be twelve
+// months long. Contexts with dimensions, and those on other dates, are
+// left unplaced.
+func placeContexts(contexts map[string]*Context, start, end string) map[string]string {
+	placed := map[string]string{}
+	yearEnd, err := parseDate(end)
+	if err != nil {
+		return placed
+	}
+	yearStart, _ := parseDate(start)
+
+	for i := 0; i < overviewYears; i++ {
+		// The year's duration starts on the known start date if a context
+		// uses it, otherwise at the earliest start among the durations
+		// ending on the year's last day.
+		var first time.Time
+		for _, c := range contexts {
+			p := c.Period
+			if len(c.Dimensions) > 0 || p.IsInstant() || !sameDate(p.EndDate, yearEnd) {
+				continue
+			}
+			s, err := parseDate(p.StartDate)
+			if err != nil {
+				continue
+			}
+			if s.Equal(yearStart) {
+				first = s
+				break
+			}
+			if first.IsZero() || s.Before(first) {
+				first = s
+			}
+		}
+
+		for id, c := range contexts {
+			if len(c.Dimensions) > 0 {
+				continue
+			}
+			p := c.Period
+			switch {
+			case p.IsInstant() && sameDate(p.Instant, yearEnd):
+				placed[id] = fmt.Sprintf("balans%d", i)
+			case !p.IsInstant() && !first.IsZero() && sameDate(p.EndDate, yearEnd) && sameDate(p.StartDate, first):
+				placed[id] = fmt.Sprintf("period%d", i)
+			}
+		}
+
+		if first.IsZero() {
+			first = previousYearEnd(yearEnd).AddDate(0, 0, 1)
+		}
+		yearEnd = first.AddDate(0, 0, -1)
+		yearStart = time.Time{}
+	}
+	return placed
+}
+
+// fiscalYearFromContexts guesses the fiscal year when the report does not
+// state it: the duration without dimensions that ends last, taking the
+// longest if several end on the same day.
+func fiscalYearFromContexts(contexts map[string]*Context) (start, end string) {
+	var bestStart, bestEnd time.Time
+	for _, c := range contexts {
+		if len(c.Dimensions) > 0 || c.Period.IsInstant() {
+			continue
+		}
+		s, err1 := parseDate(c.Period.StartDate)
+		e, err2 := parseDate(c.Period.EndDate)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		if e.After(bestEnd) || (e.Equal(bestEnd) && s.Before(bestStart)) {
+			bestStart, bestEnd = s, e
+		}
+	}
+	if bestEnd.IsZero() {
+		return "", ""
+	}
+	return bestStart.Format(time.DateOnly), bestEnd.Format(time.DateOnly)
+}
+
+// parseDate parses an XBRL date, ignoring any time part.
+func parseDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if len(s) > len(time.DateOnly) {
+		s = s[:len(time.DateOnly)]
+	}
+	return time.Parse(time.DateOnly, s)
+}
+
+// sameDate reports whether the XBRL date s falls on t.
+func sameDate(s string, t time.Time) bool {
+	d, err := parseDate(s)
+	return err == nil && d.Equal(t)
+}
+
+// previousYearEnd returns the same day a year before end. Month ends stay
+// month ends, so 2016-02-29 gives 2015-02-28.
+func previousYearEnd(end time.Time) time.Time {
+	prev := time.Date(end.Year()-1, end.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if end.AddDate(0, 0, 1).Day() == 1 {
+		return prev.AddDate(0, 1, -1)
+	}
+	return prev.AddDate(0, 0, end.Day()-1)
+}
@@ -0,0 +1,169 @@
+package ixbrl
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// warnings lists what the mapping left out of the report, in document
+// order: facts in contexts that could not be placed, concepts without a
+// mapping and tuples that are not read (both kept in report.Passthrough),
+// facts tagged more than once for the same concept and period with
+// conflicting values, and what passthrough facts lose.
+func (m *mapper) warnings(facts []fact, contexts map[string]*Context, start, end string) []string {
+	var out []string
+
+	// Facts whose context is not one of the placed years.
+	unplaced := map[string]int{}
+	var unplacedOrder []string
+	for _, f := range facts {
+		if f.Kind == "tuple" || f.TupleRef != "" {
+			continue
+		}
+		if _, ok := m.place[f.ContextRef]; ok {
+			continue
+		}
+		if unplaced[f.ContextRef] == 0 {
+			unplacedOrder = append(unplacedOrder, f.ContextRef)
+		}
+		unplaced[f.ContextRef]++
+	}
+	for _, id := range unplacedOrder {
+		ignored := plural(unplaced[id], "fact") + " ignored"
+		c, ok := contexts[id]
+		switch {
+		case !ok:
+			out = append(out, fmt.Sprintf("context %q is not defined; %s", id, ignored))
+		case len(c.Dimensions) > 0:
+			out = append(out, fmt.Sprintf("context %q has dimensions; %s", id, ignored))
+		case end == "":
+			out = append(out, fmt.Sprintf("context %q (%s) could not be placed, no fiscal year found; %s", id, periodString(c.Period), ignored))
+		default:
+			out = append(out, fmt.Sprintf("context %q (%s) is not in fiscal year %s – %s or the %d years before; %s",
+				id, periodString(c.Period), start, end, overviewYears-1, ignored))
+		}
+	}
+
+	// Placed facts whose concept and period the mapper never looked up.
+	unmapped := map[string]int{}
+	var unmappedOrder []string
+	for _, f := range facts {
+		if f.Kind == "tuple" || f.TupleRef != "" {
+			continue
+		}
+		ctx, ok := m.place[f.ContextRef]
+		if !ok || m.used[f.Name+"@"+ctx] {
+			continue
+		}
+		if unmapped[f.Name] == 0 {
+			unmappedOrder = append(unmappedOrder, f.Name)
+		}
+		unmapped[f.Name]++
+	}
+	for _, name := range unmappedOrder {
+		out = append(out, fmt.Sprintf("unmapped concept %s (%s), kept as passthrough", name, plural(unmapped[name], "fact")))
+	}
+
+	// Tuples the mapper does not read. Nested tuples go with their parent.
+	for _, f := range facts {
+		if f.Kind == "tuple" && f.TupleRef == "" && !m.usedTuples[f.TupleID] {
+			out = append(out, fmt.Sprintf("ignored tuple %s %q with %s, kept as passthrough",
+				f.Name, f.TupleID, plural(len(m.tuples[f.TupleID]), "member")))
+		}
+	}
+
+	// Duplicates with conflicting values. The used value comes first: the
+	// first text fact, or the most precise numeric fact.
+	seen := map[string]bool{}
+	for _, f := range facts {
+		ctx, ok := m.place[f.ContextRef]
+		key := f.Name + "@" + ctx
+		if !ok || f.Kind == "tuple" || f.TupleRef != "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		if f.Kind == "nonFraction" {
+			out = append(out, numericConflicts(m.nfByKey[key])...)
+		} else {
+			out = append(out, textConflicts(m.nnByKey[key])...)
+		}
+	}
+	return append(out, m.passthroughLoss...)
+}
+
+// textConflicts reports text facts that differ from the first one, apart
+// from whitespace.
+func textConflicts(fs []fact) []string {
+	var out []string
+	for _, f := range fs[min(len(fs), 1):] {
+		if strings.Join(strings.Fields(f.Value), " ") != strings.Join(strings.Fields(fs[0].Value), " ") {
+			out = append(out, fmt.Sprintf("conflicting values for %s: %q in %s and %q in %s; using the first",
+				f.Name, shorten(fs[0].Value), fs[0].ContextRef, shorten(f.Value), f.ContextRef))
+		}
+	}
+	return out
+}
+
+// numericConflicts reports numeric facts that are inconsistent with the
+// most precise one: rounded to their own decimals, the precise value must
+// equal theirs.
+func numericConflicts(fs []fact) []string {
+	best, ok := mostPrecise(fs)
+	if !ok {
+		return nil
+	}
+	bestValue, err := numericValue(best.Value, best.Format, strconv.Itoa(best.Scale), best.Sign)
+	if err != nil {
+		return nil
+	}
+	precise, _ := strconv.ParseFloat(bestValue, 64)
+
+	var out []string
+	for _, f := range fs {
+		if f.Nil || (f.ContextRef == best.ContextRef && f.Value == best.Value && f.Scale == best.Scale && f.Sign == best.Sign) {
+			continue
+		}
+		v, err := numericValue(f.Value, f.Format, strconv.Itoa(f.Scale), f.Sign)
+		if err != nil {
+			continue
+		}
+		other, _ := strconv.ParseFloat(v, 64)
+		want := precise
+		if d := decimalsValue(f.Decimals); d != math.MaxInt {
+			p := math.Pow(10, float64(d))
+			want = math.Round(precise*p) / p
+		}
+		if math.Abs(want-other) > 1e-9*math.Max(1, math.Abs(other)) {
+			out = append(out, fmt.Sprintf("conflicting values for %s: %s in %s and %s in %s; using %s",
+				f.Name, bestValue, best.ContextRef, v, f.ContextRef, bestValue))
+		}
+	}
+	return out
+}
+
+// periodString formats a period for messages.
+func periodString(p Period) string {
+	if p.IsInstant() {
+		return p.Instant
+	}
+	return p.StartDate + " – " + p.EndDate
+}
+
+// plural formats n with a noun, e.g. "1 fact" or "3 facts".
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return strconv.Itoa(n) + " " + noun + "s"
+}
+
+// shorten cuts long text values for messages.
+func shorten(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > 40 {
+		return string(r[:37]) + "..."
+	}
+	return s
+}
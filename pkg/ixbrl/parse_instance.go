@@ -0,0 +1,241 @@
+package ixbrl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// isInstance reports whether root is an XBRL 2.1 instance (.xbrl) rather
+// than an inline document.
+func isInstance(root *xmlNode) bool {
+	return root.is(xbrliNS, "xbrl")
+}
+
+// extractInstanceFacts converts the facts and tuples of an XBRL instance
+// to the facts extractFacts produces for an inline document, and reads its
+// contexts. Numeric values keep their lexical form with the sign moved to
+// Sign, so that parseNumber reads them like a displayed number; tuples are
+// numbered "tupleN" and their members ordered by position.
+func extractInstanceFacts(root *xmlNode) ([]fact, map[string]*Context) {
+	namespaces := map[string]string{}
+	collectNamespaces(root, namespaces)
+	prefixes := map[string]string{}
+	for prefix, uri := range namespaces {
+		if old, ok := prefixes[uri]; !ok || prefix < old {
+			prefixes[uri] = prefix
+		}
+	}
+	units := map[string]string{}
+	contexts := map[string]*Context{}
+
+	var facts []fact
+	tuples := 0
+	var walk func(n *xmlNode, tupleRef string)
+	walk = func(n *xmlNode, tupleRef string) {
+		order := 0
+		for _, c := range n.children {
+			e, ok := c.(*xmlNode)
+			if !ok {
+				continue
+			}
+			switch e.name.Space {
+			case xbrliNS:
+				switch e.name.Local {
+				case "context":
+					ctx := readContext(e)
+					contexts[ctx.ID] = ctx
+				case "unit":
+					u := readUnit(e)
+					units[u.ID] = u.String()
+				}
+				continue
+			case linkNS:
+				continue
+			}
+
+			f := fact{
+				Name:       instanceName(e.name.Space, e.name.Local, prefixes),
+				ContextRef: e.attr("", "contextRef"),
+				UnitRef:    e.attr("", "unitRef"),
+				Nil:        e.attr(xsiNS, "nil") == "true",
+				Decimals:   e.attr("", "decimals"),
+				ID:         e.attr("", "id"),
+				TupleRef:   tupleRef,
+			}
+			if prefix, _, _ := strings.Cut(f.Name, ":"); !isCanonicalPrefix(prefix) {
+				f.Namespace = e.name.Space
+			}
+			if tupleRef != "" {
+				order++
+				f.Order = strconv.Itoa(order)
+			}
+
+			switch {
+			case f.ContextRef == "":
+				tuples++
+				f.Kind = "tuple"
+				f.TupleID = "tuple" + strconv.Itoa(tuples)
+				facts = append(facts, f)
+				walk(e, f.TupleID)
+				continue
+			case f.UnitRef != "":
+				f.Kind = "nonFraction"
+				f.Unit = units[f.UnitRef]
+				if !f.Nil {
+					v := strings.TrimSpace(textContent(e, false))
+					if rest, ok := strings.CutPrefix(v, "-"); ok {
+						v, f.Sign = rest, "-"
+					}
+					f.Value = strings.TrimPrefix(v, "+")
+				}
+			default:
+				f.Kind = "nonNumeric"
+				if !f.Nil {
+					f.Value = strings.TrimSpace(textContent(e, false))
+				}
+			}
+			facts = append(facts, f)
+		}
+	}
+	walk(root, "")
+
+	// Units may follow the facts that use them.
+	for i := range facts {
+		if facts[i].UnitRef != "" {
+			facts[i].Unit = units[facts[i].UnitRef]
+		}
+	}
+	return facts, contexts
+}
+
+// newInstanceDocument reads the facts of an XBRL instance as ReadDocument
+// does those of an inline document. Values are taken as written; tuples
+// are numbered "tupleN" and their members ordered by position.
+func newInstanceDocument(root *xmlNode) (*Document, error) {
+	d := &Document{
+		SchemaRefs: instanceSchemaRefs(root),
+		Namespaces: map[string]string{},
+		Contexts:   map[string]*Context{},
+		Units:      map[string]*Unit{},
+	}
+	collectNamespaces(root, d.Namespaces)
+	delete(d.Namespaces, "")
+	prefixes := map[string]string{}
+	for prefix, uri := range d.Namespaces {
+		if old, ok := prefixes[uri]; !ok || prefix < old {
+			prefixes[uri] = prefix
+		}
+	}
+	for _, c := range root.children {
+		if e, ok := c.(*xmlNode); ok && e.name.Space == xbrliNS {
+			switch e.name.Local {
+			case "context":
+				ctx := readContext(e)
+				d.Contexts[ctx.ID] = ctx
+			case "unit":
+				u := readUnit(e)
+				d.Units[u.ID] = u
+			}
+		}
+	}
+
+	var walk func(n *xmlNode, parent *Tuple) error
+	walk = func(n *xmlNode, parent *Tuple) error {
+		order := 0
+		for _, c := range n.children {
+			e, ok := c.(*xmlNode)
+			if !ok || e.name.Space == xbrliNS || e.name.Space == linkNS {
+				continue
+			}
+			name := instanceName(e.name.Space, e.name.Local, prefixes)
+			pos := ""
+			if parent != nil {
+				order++
+				pos = strconv.Itoa(order)
+			}
+
+			ctxRef := e.attr("", "contextRef")
+			if ctxRef == "" {
+				t := &Tuple{ID: "tuple" + strconv.Itoa(len(d.Tuples)+1), Concept: name, Order: pos, Parent: parent}
+				d.Tuples = append(d.Tuples, t)
+				if parent != nil {
+					parent.Tuples = append(parent.Tuples, t)
+				}
+				if err := walk(e, t); err != nil {
+					return err
+				}
+				continue
+			}
+
+			f := &Fact{
+				ID:        e.attr("", "id"),
+				Concept:   name,
+				Namespace: e.name.Space,
+				Nil:       e.attr(xsiNS, "nil") == "true",
+				Decimals:  e.attr("", "decimals"),
+				Tuple:     parent,
+				Order:     pos,
+			}
+			ctx, ok := d.Contexts[ctxRef]
+			if !ok {
+				return fmt.Errorf("fact %s: context %q not defined", name, ctxRef)
+			}
+			f.Context = ctx
+			if unitRef := e.attr("", "unitRef"); unitRef != "" {
+				unit, ok := d.Units[unitRef]
+				if !ok {
+					return fmt.Errorf("fact %s: unit %q not defined", name, unitRef)
+				}
+				f.Numeric, f.Unit = true, unit
+			}
+			if !f.Nil {
+				f.Display = strings.TrimSpace(textContent(e, false))
+				f.Value = f.Display
+				if f.Numeric {
+					f.Value = strings.TrimPrefix(f.Value, "+")
+				}
+			}
+			if parent != nil {
+				parent.Facts = append(parent.Facts, f)
+			}
+			d.Facts = append(d.Facts, f)
+		}
+		return nil
+	}
+	if err := walk(root, nil); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// collectNamespaces records the xmlns declarations of n and its
+// descendants, keeping the first declaration of each prefix.
+func collectNamespaces(n *xmlNode, namespaces map[string]string) {
+	for _, a := range n.attrs {
+		if a.Name.Space == "xmlns" {
+			if _, ok := namespaces[a.Name.Local]; !ok {
+				namespaces[a.Name.Local] = a.Value
+			}
+		}
+	}
+	for _, c := range n.children {
+		if e, ok := c.(*xmlNode); ok {
+			collectNamespaces(e, namespaces)
+		}
+	}
+}
+
+// instanceName returns the QName of an instance element, using the
+// canonical prefix for the taxonomy namespaces.
+func instanceName(space, local string, prefixes map[string]string) string {
+	for base, canonical := range canonicalPrefixes {
+		if strings.HasPrefix(space, base) {
+			return canonical + ":" + local
+		}
+	}
+	if prefix := prefixes[space]; prefix != "" {
+		return prefix + ":" + local
+	}
+	return local
+}
@@ -2,6 +2,7 @@ package ixbrl
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 
@@ -9,60 +10,79 @@ import (
 )
 
 // mapFacts takes a slice of extracted facts and populates a model.AnnualReport.
-func mapFacts(facts []fact) (*model.AnnualReport, error) {
+// Facts are looked up by logical context (period0, balans1, ...), which
+// placeContexts assigns from the context dates and the fiscal year.
+func mapFacts(facts []fact, contexts map[string]*Context) (*model.AnnualReport, []string, []*FactError) {
 	m := &mapper{
-		report:  &model.AnnualReport{},
-		tuples:  make(map[string][]fact),
-		nfByKey: make(map[string][]fact),
-		nnByKey: make(map[string][]fact),
+		report:     &model.AnnualReport{},
+		tuples:     make(map[string][]fact),
+		nfByKey:    make(map[string][]fact),
+		nnByKey:    make(map[string][]fact),
+		used:       make(map[string]bool),
+		usedTuples: make(map[string]bool),
 	}
 
-	// Index facts by kind and key (name + contextRef).
+	// The fiscal year is read first since it decides where each context
+	// belongs. Reports that do not state it get the latest full period.
+	start, end := firstValue(facts, nsCd+"RakenskapsarForstaDag"), firstValue(facts, nsCd+"RakenskapsarSistaDag")
+	if start == "" || end == "" {
+		start, end = fiscalYearFromContexts(contexts)
+	}
+	m.place = placeContexts(contexts, start, end)
+	for _, f := range facts {
+		if f.Name == nsCd+"RakenskapsarForstaDag" || f.Name == nsCd+"RakenskapsarSistaDag" {
+			m.used[f.Name+"@"+m.place[f.ContextRef]] = true
+		}
+	}
+
+	// Index facts by kind and key (name + logical context). Tuple members
+	// are only reached through their tuple.
 	for _, f := range facts {
-		switch f.Kind {
-		case "tuple":
+		ctx, placed := m.place[f.ContextRef]
+		key := f.Name + "@" + ctx
+		switch {
+		case f.Kind == "tuple":
 			// Register tuple ID for later grouping.
 			if m.tuples[f.TupleID] == nil {
 				m.tuples[f.TupleID] = []fact{}
 			}
-		case "nonFraction":
-			key := f.Name + "@" + f.ContextRef
+		case f.TupleRef != "":
+			m.tuples[f.TupleRef] = append(m.tuples[f.TupleRef], f)
+		case !placed:
+		case f.Kind == "nonFraction":
 			m.nfByKey[key] = append(m.nfByKey[key], f)
-			if f.TupleRef != "" {
-				m.tuples[f.TupleRef] = append(m.tuples[f.TupleRef], f)
-			}
-		case "nonNumeric":
-			key := f.Name + "@" + f.ContextRef
+		case f.Kind == "nonNumeric":
 			m.nnByKey[key] = append(m.nnByKey[key], f)
-			if f.TupleRef != "" {
-				m.tuples[f.TupleRef] = append(m.tuples[f.TupleRef], f)
-			}
 		}
 	}
 
 	// Map all sections.
 	m.mapMeta()
+	m.report.FiscalYear.StartDate, m.report.FiscalYear.EndDate = start, end
 	m.mapCertification()
 	m.mapManagementReport()
 	m.mapIncomeStatement()
 	m.mapBalanceSheet()
 	m.mapNotes(facts)
 	m.mapSignatures(facts)
+	m.mapPassthrough(facts)
 
-	if m.err != nil {
-		return nil, m.err
-	}
-
-	return m.report, nil
+	return m.report, m.warnings(facts, contexts, start, end), m.errs
 }
 
 // mapper holds state during fact-to-model mapping.
 type mapper struct {
 	report  *model.AnnualReport
+	place   map[string]string // contextRef -> logical context, e.g. "period0"
 	tuples  map[string][]fact // tupleID -> member facts
 	nfByKey map[string][]fact // "name@context" -> nonFraction facts
 	nnByKey map[string][]fact // "name@context" -> nonNumeric facts
-	err     error             // sticky error
+	errs    []*FactError      // facts that could not be mapped
+
+	used       map[string]bool // "name@context" keys looked up
+	usedTuples map[string]bool // tuple IDs read
+
+	passthroughLoss []string // what passthrough facts lose, for the warnings
 }
 
 // ---------- helpers ----------
@@ -76,30 +96,73 @@ const (
 // nn returns the text value of the first nonNumeric fact matching name@context.
 func (m *mapper) nn(name, ctx string) string {
 	key := name + "@" + ctx
+	m.used[key] = true
 	if fs, ok := m.nnByKey[key]; ok && len(fs) > 0 {
 		return fs[0].Value
 	}
 	return ""
 }
 
-// nf returns the int64 value of the first nonFraction fact matching name@context.
+// nf returns the int64 value of the nonFraction fact matching name@context.
+// When a concept is tagged more than once, e.g. in kronor in the income
+// statement and in tkr in the flerårsöversikt, the most precise fact is used.
 // Returns nil if not found.
 func (m *mapper) nf(name, ctx string) *int64 {
-	key := name + "@" + ctx
-	fs, ok := m.nfByKey[key]
-	if !ok || len(fs) == 0 {
+	m.used[name+"@"+ctx] = true
+	f, ok := mostPrecise(m.nfByKey[name+"@"+ctx])
+	if !ok {
 		return nil
 	}
-	v, err := parseNumber(fs[0])
+	return m.number(f)
+}
+
+// number returns the value of a numeric fact, reporting facts that cannot
+// be parsed.
+func (m *mapper) number(f fact) *int64 {
+	v, err := parseNumber(f)
 	if err != nil {
-		if m.err == nil {
-			m.err = fmt.Errorf("parsing %s@%s: %w", name, ctx, err)
-		}
+		m.errs = append(m.errs, &FactError{Concept: f.Name, Context: f.ContextRef, Err: err})
 		return nil
 	}
 	return &v
 }
 
+// firstValue returns the value of the first non-nil fact of a concept in
+// any context.
+func firstValue(facts []fact, name string) string {
+	for _, f := range facts {
+		if f.Name == name && !f.Nil {
+			return strings.TrimSpace(f.Value)
+		}
+	}
+	return ""
+}
+
+// mostPrecise returns the non-nil fact with the highest decimals, the
+// first one on ties.
+func mostPrecise(fs []fact) (fact, bool) {
+	best, found := fact{}, false
+	for _, f := range fs {
+		if f.Nil {
+			continue
+		}
+		if !found || decimalsValue(f.Decimals) > decimalsValue(best.Decimals) {
+			best, found = f, true
+		}
+	}
+	return best, found
+}
+
+// decimalsValue orders decimals attributes; INF and missing values count
+// as exact.
+func decimalsValue(s string) int {
+	d, err := strconv.Atoi(s)
+	if err != nil {
+		return math.MaxInt
+	}
+	return d
+}
+
 // nfNeg returns the negated int64 value for a nonFraction fact stored with sign="-".
 // These concepts are stored as positive in the model but emitted with sign="-" in iXBRL.
 func (m *mapper) nfNeg(name, ctx string) *int64 {
@@ -145,9 +208,6 @@ func (m *mapper) mapMeta() {
 	r.Company.Name = m.nn(nsCd+"ForetagetsNamn", "period0")
 	r.Company.OrgNr = m.nn(nsCd+"Organisationsnummer", "period0")
 
-	r.FiscalYear.StartDate = m.nn(nsCd+"RakenskapsarForstaDag", "period0")
-	r.FiscalYear.EndDate = m.nn(nsCd+"RakenskapsarSistaDag", "period0")
-
 	r.Meta.Language = m.nn(nsCd+"Sprak", "period0")
 	r.Meta.Country = m.nn(nsCd+"Land", "period0")
 	r.Meta.Currency = m.nn(nsCd+"Redovisningsvaluta", "period0")
@@ -167,6 +227,10 @@ func (m *mapper) mapCertification() {
 	c.Signatory.FirstName = m.nn(nsBol+"UnderskriftFaststallelseintygForetradareTilltalsnamn", "period0")
 	c.Signatory.LastName = m.nn(nsBol+"UnderskriftFaststallelseintygForetradareEfternamn", "period0")
 	c.Signatory.Role = m.nn(nsBol+"UnderskriftFaststallelseintygForetradareForetradarroll", "period0")
+
+	// ArsstammaIntygande wraps the facts above and is written again from
+	// them by the generator.
+	m.used[nsBol+"ArsstammaIntygande@balans0"] = true
 }
 
 func (m *mapper) mapManagementReport() {
@@ -221,41 +285,41 @@ func (m *mapper) mapMultiYearOverview() []model.MultiYearOverviewYear {
 	return years
 }
 
-// nfWithScale finds a nonFraction fact with a specific scale value.
+// nfWithScale finds a nonFraction fact with a specific scale value. Reports
+// that use another scale in the flerårsöversikt fall back to the most
+// precise fact.
 func (m *mapper) nfWithScale(name, ctx string, wantScale int) *int64 {
-	key := name + "@" + ctx
-	fs, ok := m.nfByKey[key]
-	if !ok {
-		return nil
-	}
+	m.used[name+"@"+ctx] = true
+	fs := m.nfByKey[name+"@"+ctx]
 	for _, f := range fs {
-		if f.Scale == wantScale {
-			v, err := parseNumber(f)
-			if err != nil {
-				if m.err == nil {
-					m.err = fmt.Errorf("parsing %s@%s: %w", name, ctx, err)
-				}
-				return nil
-			}
-			return &v
+		if f.Scale == wantScale && !f.Nil {
+			return m.number(f)
 		}
 	}
-	return nil
+	return m.nf(name, ctx)
 }
 
-// solidityStr extracts the solidity percentage as a display string (e.g. "33,7").
+// solidityStr extracts the solidity percentage as a decimal string (e.g.
+// "33.7"). It is computed from the fact value, so it does not depend on how
+// the percentage was displayed or scaled.
 func (m *mapper) solidityStr(name, ctx string) *string {
-	key := name + "@" + ctx
-	fs, ok := m.nfByKey[key]
-	if !ok || len(fs) == 0 {
+	m.used[name+"@"+ctx] = true
+	f, ok := mostPrecise(m.nfByKey[name+"@"+ctx])
+	if !ok {
 		return nil
 	}
-	// The display value is the raw text, e.g. "33,7".
-	v := fs[0].Value
-	if v == "" {
+	v, err := numericValue(f.Value, f.Format, strconv.Itoa(f.Scale), f.Sign)
+	if err != nil {
+		m.errs = append(m.errs, &FactError{Concept: f.Name, Context: f.ContextRef, Err: err})
 		return nil
 	}
-	return &v
+	neg := strings.HasPrefix(v, "-")
+	intPart, fracPart, _ := strings.Cut(strings.TrimPrefix(v, "-"), ".")
+	pct := shiftDecimal(intPart, fracPart, 2)
+	if neg {
+		pct = "-" + pct
+	}
+	return &pct
 }
 
 func (m *mapper) mapEquityChanges() {
@@ -599,6 +663,7 @@ func (m *mapper) mapMultiPostNote(n *model.Notes, facts []fact) {
 	tupleName := nsGen + "TillgangarAvsattningarSkulderTuple"
 	for _, f := range facts {
 		if f.Kind == "tuple" && f.Name == tupleName {
+			m.usedTuples[f.TupleID] = true
 			members := m.tuples[f.TupleID]
 			entry := model.MultiPostEntry{}
 			for _, mf := range members {
@@ -606,10 +671,7 @@ func (m *mapper) mapMultiPostNote(n *model.Notes, facts []fact) {
 				case nsGen + "TillgangarAvsattningarSkulderPost":
 					entry.PostName = mf.Value
 				case nsGen + "TillgangarAvsattningarSkulderBelopp":
-					v, err := parseNumber(mf)
-					if err == nil {
-						entry.Amount = &v
-					}
+					entry.Amount = m.number(mf)
 				}
 			}
 			if entry.PostName != "" {
@@ -633,6 +695,7 @@ func (m *mapper) mapSignatures(facts []fact) {
 	tupleName := nsGen + "UnderskriftArsredovisningForetradareTuple"
 	for _, f := range facts {
 		if f.Kind == "tuple" && f.Name == tupleName {
+			m.usedTuples[f.TupleID] = true
 			members := m.tuples[f.TupleID]
 			s := model.Signatory{}
 			for _, mf := range members {
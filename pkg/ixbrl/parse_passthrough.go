@@ -0,0 +1,90 @@
+package ixbrl
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/redofri/redofri/pkg/model"
+)
+
+// mapPassthrough keeps the facts the other mappers did not use, and the
+// tuples they did not read, in report.Passthrough. Facts in contexts that
+// could not be placed are left out, since the generator has no context to
+// write them in; for tuple members, and for numeric facts kept as text
+// because their unit is not defined, passthroughLoss says so.
+func (m *mapper) mapPassthrough(facts []fact) {
+	for _, f := range facts {
+		switch {
+		case f.Kind == "tuple":
+			if f.TupleRef == "" && !m.usedTuples[f.TupleID] {
+				m.report.Passthrough = append(m.report.Passthrough, m.passthroughTuple(facts, f))
+			}
+		case f.TupleRef != "":
+		default:
+			if ctx, ok := m.place[f.ContextRef]; ok && !m.used[f.Name+"@"+ctx] {
+				if p, ok := m.passthroughFact(f); ok {
+					m.report.Passthrough = append(m.report.Passthrough, p)
+				}
+			}
+		}
+	}
+}
+
+// passthroughFact converts a single fact; numeric values are stored as
+// XBRL values so that the display format does not matter.
+func (m *mapper) passthroughFact(f fact) (model.PassthroughFact, bool) {
+	ctx, ok := m.place[f.ContextRef]
+	if !ok {
+		if f.TupleRef != "" {
+			m.passthroughLoss = append(m.passthroughLoss, fmt.Sprintf("member %s of tuple %q is in context %q, which could not be placed; fact ignored",
+				f.Name, f.TupleRef, f.ContextRef))
+		}
+		return model.PassthroughFact{}, false
+	}
+	p := model.PassthroughFact{
+		Concept:   f.Name,
+		Namespace: f.Namespace,
+		Context:   ctx,
+		Nil:       f.Nil,
+		Value:     f.Value,
+	}
+	if f.Kind == "nonFraction" {
+		if f.Unit == "" {
+			m.passthroughLoss = append(m.passthroughLoss, fmt.Sprintf("numeric fact %s in %s has undefined unit %q; kept as passthrough text",
+				f.Name, f.ContextRef, f.UnitRef))
+		} else {
+			p.Unit, p.Decimals = f.Unit, f.Decimals
+		}
+		if !f.Nil {
+			v, err := numericValue(f.Value, f.Format, strconv.Itoa(f.Scale), f.Sign)
+			if err != nil {
+				m.errs = append(m.errs, &FactError{Concept: f.Name, Context: f.ContextRef, Err: err})
+				return model.PassthroughFact{}, false
+			}
+			p.Value = v
+		}
+	}
+	return p, true
+}
+
+// passthroughTuple converts a tuple with its members, sorted by order.
+func (m *mapper) passthroughTuple(facts []fact, t fact) model.PassthroughFact {
+	var members []fact
+	for _, f := range facts {
+		if f.TupleRef == t.TupleID {
+			members = append(members, f)
+		}
+	}
+	sort.SliceStable(members, func(i, j int) bool { return orderValue(members[i].Order) < orderValue(members[j].Order) })
+
+	p := model.PassthroughFact{Concept: t.Name, Namespace: t.Namespace, Tuple: true}
+	for _, f := range members {
+		if f.Kind == "tuple" {
+			p.Members = append(p.Members, m.passthroughTuple(facts, f))
+		} else if mp, ok := m.passthroughFact(f); ok {
+			p.Members = append(p.Members, mp)
+		}
+	}
+	return p
+}
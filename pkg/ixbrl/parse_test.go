@@ -3,6 +3,7 @@ package ixbrl
 import (
 	"bytes"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -21,10 +22,14 @@ func TestParseRoundtrip(t *testing.T) {
 	}
 
 	// Parse it back.
-	parsed, err := Parse(&buf)
+	result, err := Parse(&buf)
 	if err != nil {
 		t.Fatalf("parsing iXBRL: %v", err)
 	}
+	for _, e := range result.Errors {
+		t.Errorf("fact error: %v", e)
+	}
+	parsed := result.Report
 
 	// --- Metadata ---
 	t.Run("company", func(t *testing.T) {
@@ -267,10 +272,20 @@ func TestParseReferenceExample(t *testing.T) {
 	}
 	defer f.Close()
 
-	report, err := Parse(f)
+	result, err := Parse(f)
 	if err != nil {
 		t.Fatalf("parsing reference example: %v", err)
 	}
+	for _, e := range result.Errors {
+		t.Errorf("fact error: %v", e)
+	}
+	if len(result.Warnings) != 0 {
+		t.Errorf("warnings = %q", result.Warnings)
+	}
+	if len(result.Report.Passthrough) != 0 {
+		t.Errorf("passthrough = %+v", result.Report.Passthrough)
+	}
+	report := result.Report
 
 	// Verify key values from the reference example.
 	t.Run("company", func(t *testing.T) {
@@ -294,6 +309,14 @@ func TestParseReferenceExample(t *testing.T) {
 			report.BalanceSheet.EquityAndLiabilities.TotalEquityAndLiabilities.Current)
 	})
 
+	t.Run("solidity", func(t *testing.T) {
+		years := report.ManagementReport.MultiYearOverview.Years
+		if len(years) == 0 || years[0].Solidity == nil {
+			t.Fatal("missing solidity")
+		}
+		assertEqual(t, "solidity", "33.7", *years[0].Solidity)
+	})
+
 	t.Run("signatures", func(t *testing.T) {
 		if len(report.Signatures.Signatories) < 2 {
 			t.Errorf("expected at least 2 signatories, got %d", len(report.Signatures.Signatories))
@@ -301,6 +324,337 @@ func TestParseReferenceExample(t *testing.T) {
 	})
 }
 
+// thirdPartyReport is a report as written by other software: its own
+// context ids and prefixes, a broken fiscal year, TR4 transformations,
+// ix:exclude and a chain of continuations spread over the document.
+const thirdPartyReport = `<?xml version="1.0" encoding="UTF-8"?>
+<html xmlns="http://www.w3.org/1999/xhtml"
+      xmlns:ix="http://www.xbrl.org/2013/inlineXBRL"
+      xmlns:ixt4="http://www.xbrl.org/inlineXBRL/transformation/2020-02-12"
+      xmlns:xbrli="http://www.xbrl.org/2003/instance"
+      xmlns:iso4217="http://www.xbrl.org/2003/iso4217"
+      xmlns:gen="http://www.taxonomier.se/se/fr/gen-base/2020-12-01"
+      xmlns:cd="http://www.taxonomier.se/se/fr/cd-base/2020-12-01"
+      xmlns:ext="http://example.com/ext">
+<body>
+<ix:header><ix:hidden>
+  <ix:nonNumeric name="cd:ForetagetsNamn" contextRef="D_CY">Tredje Part AB</ix:nonNumeric>
+  <ix:nonNumeric name="cd:ForetagetsNamn" contextRef="D_CY_copy">Tredje  Part
+    AB</ix:nonNumeric>
+  <ix:nonNumeric name="cd:ForetagetsNamn" contextRef="D_CY_copy">Tredje Part Aktiebolag</ix:nonNumeric>
+  <ix:nonNumeric name="gen:Okand" contextRef="D_CY">x</ix:nonNumeric>
+  <ix:nonNumeric name="gen:Okand" contextRef="I_CY">y</ix:nonNumeric>
+  <ix:nonFraction name="gen:OkandAntal" contextRef="D_CY" unitRef="st" decimals="INF">12</ix:nonFraction>
+  <ix:tuple name="gen:TillgangarAvsattningarSkulderTuple" tupleID="post1"/>
+  <ix:nonNumeric name="gen:TillgangarAvsattningarSkulderPost" contextRef="I_CY" tupleRef="post1" order="1">Byggnader</ix:nonNumeric>
+  <ix:nonFraction name="gen:TillgangarAvsattningarSkulderBelopp" contextRef="D_Q4" unitRef="sek" decimals="INF" tupleRef="post1" order="2">5000</ix:nonFraction>
+</ix:hidden><ix:resources>
+  <xbrli:context id="D_CY"><xbrli:entity><xbrli:identifier scheme="http://www.bolagsverket.se">556000-0001</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2016-07-01</xbrli:startDate><xbrli:endDate>2017-06-30</xbrli:endDate></xbrli:period></xbrli:context>
+  <xbrli:context id="D_CY_copy"><xbrli:entity><xbrli:identifier scheme="http://www.bolagsverket.se">556000-0001</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2016-07-01</xbrli:startDate><xbrli:endDate>2017-06-30</xbrli:endDate></xbrli:period></xbrli:context>
+  <xbrli:context id="D_PY"><xbrli:entity><xbrli:identifier scheme="http://www.bolagsverket.se">556000-0001</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2015-07-01</xbrli:startDate><xbrli:endDate>2016-06-30</xbrli:endDate></xbrli:period></xbrli:context>
+  <xbrli:context id="D_Q4"><xbrli:entity><xbrli:identifier scheme="http://www.bolagsverket.se">556000-0001</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2017-04-01</xbrli:startDate><xbrli:endDate>2017-06-30</xbrli:endDate></xbrli:period></xbrli:context>
+  <xbrli:context id="I_CY"><xbrli:entity><xbrli:identifier scheme="http://www.bolagsverket.se">556000-0001</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2017-06-30</xbrli:instant></xbrli:period></xbrli:context>
+  <xbrli:context id="I_PY"><xbrli:entity><xbrli:identifier scheme="http://www.bolagsverket.se">556000-0001</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2016-06-30</xbrli:instant></xbrli:period></xbrli:context>
+  <xbrli:unit id="sek"><xbrli:measure>iso4217:SEK</xbrli:measure></xbrli:unit>
+  <xbrli:unit id="sekPerShare"><xbrli:divide><xbrli:unitNumerator><xbrli:measure>iso4217:SEK</xbrli:measure></xbrli:unitNumerator>
+    <xbrli:unitDenominator><xbrli:measure>xbrli:shares</xbrli:measure></xbrli:unitDenominator></xbrli:divide></xbrli:unit>
+</ix:resources></ix:header>
+<p>Räkenskapsår <ix:nonNumeric name="cd:RakenskapsarForstaDag" contextRef="D_CY" format="ixt4:date-day-monthname-year-sv">1 juli 2016</ix:nonNumeric>
+ – <ix:nonNumeric name="cd:RakenskapsarSistaDag" contextRef="D_CY" format="ixt4:date-day-month-year">30.06.2017</ix:nonNumeric></p>
+<ix:nonNumeric name="gen:AllmantVerksamheten" contextRef="D_CY" continuedAt="c1">Bolaget bedriver</ix:nonNumeric>
+<ix:continuation id="c1" continuedAt="c2"> konsultverksamhet<ix:exclude> (se not 3)</ix:exclude></ix:continuation>
+<div><p><ix:continuation id="c2" continuedAt="c3"> i <b>Göteborg</b></ix:continuation></p></div>
+<ix:continuation id="c3"> och Borås.</ix:continuation>
+<table>
+<tr><td>Nettoomsättning</td>
+  <td><ix:nonFraction name="gen:Nettoomsattning" contextRef="D_CY_copy" unitRef="sek" decimals="-3" scale="3" format="ixt4:num-dot-decimal">1,235</ix:nonFraction></td>
+  <td><ix:nonFraction name="gen:Nettoomsattning" contextRef="D_PY" unitRef="sek" decimals="-3" scale="3" format="ixt4:num-dot-decimal">987<ix:exclude> tkr</ix:exclude></ix:nonFraction></td></tr>
+<tr><td>Nettoomsättning Q4</td>
+  <td><ix:nonFraction name="gen:Nettoomsattning" contextRef="D_Q4" unitRef="sek" decimals="0" format="ixt4:num-dot-decimal">300,000</ix:nonFraction></td></tr>
+<tr><td>Nettoomsättning exakt</td>
+  <td><ix:nonFraction name="gen:Nettoomsattning" contextRef="D_CY" unitRef="sek" decimals="INF" format="ixt4:num-comma-decimal">1.234.567</ix:nonFraction></td></tr>
+<tr><td>Kassa</td>
+  <td><ix:nonFraction name="gen:KassaBank" contextRef="I_CY" unitRef="sek" decimals="INF" format="ixt4:fixed-zero">–</ix:nonFraction></td>
+  <td><ix:nonFraction name="gen:KassaBank" contextRef="I_PY" unitRef="sek" decimals="INF" format="ixt4:num-comma-decimal">12 500</ix:nonFraction></td></tr>
+<tr><td>Kassa igen</td>
+  <td><ix:nonFraction name="gen:KassaBank" contextRef="I_PY" unitRef="sek" decimals="-3" scale="3" format="ixt4:num-comma-decimal">12</ix:nonFraction></td></tr>
+<tr><td>Utdelning per aktie</td>
+  <td>-<ix:nonFraction name="ext:UtdelningPerAktie" contextRef="D_PY" unitRef="sekPerShare" decimals="2" sign="-" format="ixt4:num-dot-decimal">1,012.50</ix:nonFraction></td></tr>
+<tr><td>Tillgångar</td>
+  <td><ix:nonFraction name="gen:Tillgangar" contextRef="I_CY" unitRef="sek" decimals="INF" format="ixt:unknown-rule">1</ix:nonFraction></td>
+  <td><ix:nonFraction name="gen:Tillgangar" contextRef="I_PY" unitRef="sek" decimals="INF" format="ixt4:num-dot-decimal">7,773</ix:nonFraction></td></tr>
+</table>
+</body>
+</html>`
+
+// TestParseThirdParty parses a report that uses other context ids, prefixes
+// and transformations than redofri's own output.
+func TestParseThirdParty(t *testing.T) {
+	result, err := Parse(strings.NewReader(thirdPartyReport))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	r := result.Report
+
+	t.Run("fiscal year", func(t *testing.T) {
+		assertEqual(t, "startDate", "2016-07-01", r.FiscalYear.StartDate)
+		assertEqual(t, "endDate", "2017-06-30", r.FiscalYear.EndDate)
+		assertEqual(t, "name", "Tredje Part AB", r.Company.Name)
+	})
+
+	t.Run("contexts placed by date", func(t *testing.T) {
+		assertInt64PtrValue(t, "netSales.current", 1234567, r.IncomeStatement.Revenue.NetSales.Current)
+		assertInt64PtrValue(t, "netSales.previous", 987000, r.IncomeStatement.Revenue.NetSales.Previous)
+		assertInt64PtrValue(t, "cash.current", 0, r.BalanceSheet.Assets.CurrentAssets.CashAndBank.TotalCashAndBank.Current)
+		assertInt64PtrValue(t, "cash.previous", 12500, r.BalanceSheet.Assets.CurrentAssets.CashAndBank.TotalCashAndBank.Previous)
+		assertInt64PtrValue(t, "totalAssets.previous", 7773, r.BalanceSheet.Assets.TotalAssets.Previous)
+	})
+
+	t.Run("continuations", func(t *testing.T) {
+		assertEqual(t, "businessDescription", "Bolaget bedriver konsultverksamhet i Göteborg och Borås.",
+			r.ManagementReport.BusinessDescription)
+	})
+
+	t.Run("errors per fact", func(t *testing.T) {
+		if r.BalanceSheet.Assets.TotalAssets.Current != nil {
+			t.Error("fact with unsupported format was mapped")
+		}
+		if len(result.Errors) != 1 {
+			t.Fatalf("got %d fact errors, want 1: %v", len(result.Errors), result.Errors)
+		}
+		e := result.Errors[0]
+		if e.Concept != "se-gen-base:Tillgangar" || e.Context != "I_CY" || !strings.Contains(e.Error(), "unsupported format") {
+			t.Errorf("fact error = %v", e)
+		}
+	})
+
+	t.Run("warnings", func(t *testing.T) {
+		want := []string{
+			`context "D_Q4" (2017-04-01 – 2017-06-30) is not in fiscal year 2016-07-01 – 2017-06-30 or the 3 years before; 1 fact ignored`,
+			`unmapped concept se-gen-base:Okand (2 facts), kept as passthrough`,
+			`unmapped concept se-gen-base:OkandAntal (1 fact), kept as passthrough`,
+			`unmapped concept ext:UtdelningPerAktie (1 fact), kept as passthrough`,
+			`ignored tuple se-gen-base:TillgangarAvsattningarSkulderTuple "post1" with 2 members, kept as passthrough`,
+			`conflicting values for se-cd-base:ForetagetsNamn: "Tredje Part AB" in D_CY and "Tredje Part Aktiebolag" in D_CY_copy; using the first`,
+			`conflicting values for se-gen-base:KassaBank: 12500 in I_PY and 12000 in I_PY; using 12500`,
+			`numeric fact se-gen-base:OkandAntal in D_CY has undefined unit "st"; kept as passthrough text`,
+			`member se-gen-base:TillgangarAvsattningarSkulderBelopp of tuple "post1" is in context "D_Q4", which could not be placed; fact ignored`,
+		}
+		if strings.Join(result.Warnings, "\n") != strings.Join(want, "\n") {
+			t.Errorf("warnings:\n%s\nwant:\n%s", strings.Join(result.Warnings, "\n"), strings.Join(want, "\n"))
+		}
+	})
+
+	t.Run("passthrough", func(t *testing.T) {
+		want := []model.PassthroughFact{
+			{Concept: "se-gen-base:Okand", Context: "period0", Value: "x"},
+			{Concept: "se-gen-base:Okand", Context: "balans0", Value: "y"},
+			{Concept: "se-gen-base:OkandAntal", Context: "period0", Value: "12"},
+			{Concept: "se-gen-base:TillgangarAvsattningarSkulderTuple", Tuple: true, Members: []model.PassthroughFact{
+				{Concept: "se-gen-base:TillgangarAvsattningarSkulderPost", Context: "balans0", Value: "Byggnader"},
+			}},
+			{Concept: "ext:UtdelningPerAktie", Namespace: "http://example.com/ext", Context: "period1",
+				Unit: "iso4217:SEK/xbrli:shares", Decimals: "2", Value: "-1012.50"},
+		}
+		if !reflect.DeepEqual(r.Passthrough, want) {
+			t.Errorf("passthrough:\n%+v\nwant:\n%+v", r.Passthrough, want)
+		}
+	})
+}
+
+// TestPassthroughRoundtrip checks that passthrough facts are written by
+// Generate and read back unchanged.
+func TestPassthroughRoundtrip(t *testing.T) {
+	first, err := Parse(strings.NewReader(thirdPartyReport))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	first.Report.Passthrough = append(first.Report.Passthrough,
+		model.PassthroughFact{Concept: "se-gen-base:Okand", Context: "period3", Nil: true},
+		model.PassthroughFact{Concept: "se-gen-base:OkandTuple", Tuple: true, Members: []model.PassthroughFact{
+			{Concept: "se-gen-base:OkandBelopp", Context: "balans0", Unit: "iso4217:SEK", Decimals: "INF", Value: "1234567"},
+			{Concept: "se-gen-base:OkandTuple", Tuple: true, Members: []model.PassthroughFact{
+				{Concept: "se-gen-base:OkandAndel", Context: "balans0", Unit: "xbrli:pure", Decimals: "3", Value: "0.125"},
+			}},
+		}},
+	)
+
+	for _, lang := range []string{"sv", "en"} {
+		t.Run(lang, func(t *testing.T) {
+			first.Report.Meta.Language = lang
+			doc, err := GenerateBytes(first.Report)
+			if err != nil {
+				t.Fatalf("Generate: %v", err)
+			}
+			second, err := Parse(bytes.NewReader(doc))
+			if err != nil {
+				t.Fatalf("Parse generated: %v", err)
+			}
+			for _, e := range second.Errors {
+				t.Errorf("fact error: %v", e)
+			}
+			if !reflect.DeepEqual(second.Report.Passthrough, first.Report.Passthrough) {
+				t.Errorf("passthrough after round trip:\n%+v\nwant:\n%+v", second.Report.Passthrough, first.Report.Passthrough)
+			}
+		})
+	}
+}
+
+// TestPassthroughInvalidNumber checks that a numeric passthrough fact
+// whose value is not a number fails the generation.
+func TestPassthroughInvalidNumber(t *testing.T) {
+	for _, value := range []string{"12 345", "1e6", "+5", "1.2.3", ""} {
+		r := loadTestReport(t)
+		r.Passthrough = []model.PassthroughFact{
+			{Concept: "se-gen-base:OkandBelopp", Context: "balans0", Unit: "iso4217:SEK", Decimals: "INF", Value: value},
+		}
+		_, err := GenerateBytes(r)
+		if err == nil || !strings.Contains(err.Error(), "se-gen-base:OkandBelopp@balans0") {
+			t.Errorf("value %q: err = %v", value, err)
+		}
+	}
+}
+
+// TestParseInstance checks that the XBRL instance of the reference example
+// gives the same report as its iXBRL document.
+func TestParseInstance(t *testing.T) {
+	parseFile := func(path string) *Result {
+		f, err := os.Open(path)
+		if err != nil {
+			t.Skipf("reference example not available: %v", err)
+		}
+		defer f.Close()
+		result, err := Parse(f)
+		if err != nil {
+			t.Fatalf("parsing %s: %v", path, err)
+		}
+		return result
+	}
+	inline := parseFile("../../ref/exempel/faststalld-arsredovisning-exempel-1.xhtml")
+	instance := parseFile("../../ref/exempel/faststalld-arsredovisning-exempel-1.xbrl")
+
+	for _, e := range instance.Errors {
+		t.Errorf("fact error: %v", e)
+	}
+	if len(instance.Warnings) != 0 {
+		t.Errorf("warnings = %q", instance.Warnings)
+	}
+	if !reflect.DeepEqual(instance.Report, inline.Report) {
+		t.Errorf("instance report differs from iXBRL report")
+	}
+}
+
+// TestParseInstanceFacts checks signs, nil facts, tuples and foreign
+// namespaces in an XBRL instance.
+func TestParseInstanceFacts(t *testing.T) {
+	const doc = `<?xml version="1.0" encoding="UTF-8"?>
+<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:link="http://www.xbrl.org/2003/linkbase"
+  xmlns:xlink="http://www.w3.org/1999/xlink" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+  xmlns:iso4217="http://www.xbrl.org/2003/iso4217" xmlns:se-gen-base="http://www.taxonomier.se/se/fr/gen-base/2017-09-30"
+  xmlns:se-cd-base="http://www.taxonomier.se/se/fr/cd-base/2017-09-30" xmlns:ext="http://example.com/ext">
+  <link:schemaRef xlink:type="simple" xlink:href="http://www.taxonomier.se/se/fr/k2/2017-09-30/se-k2-ab-risbs-2017-09-30.xsd"/>
+  <xbrli:context id="D_CY"><xbrli:entity><xbrli:identifier scheme="http://www.bolagsverket.se">5569999999</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2016-01-01</xbrli:startDate><xbrli:endDate>2016-12-31</xbrli:endDate></xbrli:period></xbrli:context>
+  <xbrli:context id="I_CY"><xbrli:entity><xbrli:identifier scheme="http://www.bolagsverket.se">5569999999</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2016-12-31</xbrli:instant></xbrli:period></xbrli:context>
+  <se-cd-base:RakenskapsarForstaDag contextRef="D_CY">2016-01-01</se-cd-base:RakenskapsarForstaDag>
+  <se-cd-base:RakenskapsarSistaDag contextRef="D_CY">2016-12-31</se-cd-base:RakenskapsarSistaDag>
+  <se-gen-base:AretsResultat contextRef="D_CY" unitRef="SEK" decimals="INF">-12500</se-gen-base:AretsResultat>
+  <se-gen-base:Nettoomsattning contextRef="D_CY" unitRef="SEK" decimals="-3">250000</se-gen-base:Nettoomsattning>
+  <se-gen-base:KassaBank contextRef="I_CY" unitRef="SEK" xsi:nil="true"/>
+  <ext:Okand contextRef="I_CY" unitRef="SEK" decimals="0">-5</ext:Okand>
+  <se-gen-base:TillgangarAvsattningarSkulderTuple>
+    <se-gen-base:TillgangarAvsattningarSkulderPost contextRef="I_CY">Byggnader</se-gen-base:TillgangarAvsattningarSkulderPost>
+  </se-gen-base:TillgangarAvsattningarSkulderTuple>
+  <xbrli:unit id="SEK"><xbrli:measure>iso4217:SEK</xbrli:measure></xbrli:unit>
+</xbrli:xbrl>`
+
+	result, err := Parse(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	for _, e := range result.Errors {
+		t.Errorf("fact error: %v", e)
+	}
+	r := result.Report
+	assertEqual(t, "taxonomyVersion", "2017-09-30", r.Meta.TaxonomyVersion)
+	assertEqual(t, "entryPoint", "risbs", r.Meta.EntryPoint)
+	assertInt64PtrValue(t, "netResult", -12500, r.IncomeStatement.NetResult.Current)
+	assertInt64PtrValue(t, "netSales", 250000, r.IncomeStatement.Revenue.NetSales.Current)
+	if r.BalanceSheet.Assets.CurrentAssets.CashAndBank.TotalCashAndBank.Current != nil {
+		t.Error("nil fact was mapped to a value")
+	}
+
+	want := []model.PassthroughFact{
+		{Concept: "ext:Okand", Namespace: "http://example.com/ext", Context: "balans0",
+			Unit: "iso4217:SEK", Decimals: "0", Value: "-5"},
+		{Concept: "se-gen-base:TillgangarAvsattningarSkulderTuple", Tuple: true, Members: []model.PassthroughFact{
+			{Concept: "se-gen-base:TillgangarAvsattningarSkulderPost", Context: "balans0", Value: "Byggnader"},
+		}},
+	}
+	if !reflect.DeepEqual(r.Passthrough, want) {
+		t.Errorf("passthrough:\n%+v\nwant:\n%+v", r.Passthrough, want)
+	}
+}
+
+// TestPlaceContexts checks that contexts are placed by their dates.
+func TestPlaceContexts(t *testing.T) {
+	ctx := func(id, start, end, instant string) *Context {
+		return &Context{ID: id, Period: Period{StartDate: start, EndDate: end, Instant: instant}}
+	}
+	contexts := map[string]*Context{}
+	for _, c := range []*Context{
+		ctx("a", "2016-01-01", "2016-12-31", ""),
+		ctx("b", "2015-01-01", "2015-12-31", ""),
+		ctx("c", "2014-05-01", "2014-12-31", ""), // first, shortened year
+		ctx("d", "", "", "2016-12-31"),
+		ctx("e", "", "", "2015-12-31"),
+		ctx("f", "", "", "2014-12-31"),
+		ctx("g", "", "", "2014-04-30"), // the day before the shortened year
+		ctx("h", "", "", "2016-06-30"),
+		ctx("i", "2016-10-01", "2016-12-31", ""),
+	} {
+		contexts[c.ID] = c
+	}
+	contexts["dim"] = &Context{ID: "dim", Period: Period{Instant: "2016-12-31"}, Dimensions: []Dimension{{Dimension: "x:Axis", Member: "x:M"}}}
+
+	got := placeContexts(contexts, "2016-01-01", "2016-12-31")
+	want := map[string]string{
+		"a": "period0", "b": "period1", "c": "period2",
+		"d": "balans0", "e": "balans1", "f": "balans2", "g": "balans3",
+	}
+	for id, logical := range want {
+		if got[id] != logical {
+			t.Errorf("context %s placed as %q, want %q", id, got[id], logical)
+		}
+	}
+	for _, id := range []string{"h", "i", "dim"} {
+		if p, ok := got[id]; ok {
+			t.Errorf("context %s placed as %q, want unplaced", id, p)
+		}
+	}
+
+	// Without durations, years are assumed to be twelve months long.
+	leap := placeContexts(map[string]*Context{
+		"x": ctx("x", "", "", "2016-02-29"),
+		"y": ctx("y", "", "", "2015-02-28"),
+	}, "2015-03-01", "2016-02-29")
+	if leap["x"] != "balans0" || leap["y"] != "balans1" {
+		t.Errorf("instants placed as %v", leap)
+	}
+
+	start, end := fiscalYearFromContexts(contexts)
+	if start != "2016-01-01" || end != "2016-12-31" {
+		t.Errorf("fiscalYearFromContexts = %s–%s", start, end)
+	}
+}
+
 // TestParseNumberFormatting tests number parsing with various formats.
 func TestParseNumberFormatting(t *testing.T) {
 	tests := []struct {
@@ -367,9 +721,13 @@ func TestParseExtractFacts(t *testing.T) {
 </body>
 </html>`
 
-	facts, err := extractFacts(strings.NewReader(input))
+	root, err := parseXMLTree(strings.NewReader(input))
 	if err != nil {
-		t.Fatalf("extractFacts: %v", err)
+		t.Fatalf("parseXMLTree: %v", err)
+	}
+	facts, errs := extractFacts(newInlineDocument(root))
+	if len(errs) > 0 {
+		t.Fatalf("extractFacts: %v", errs)
 	}
 
 	if len(facts) != 4 {
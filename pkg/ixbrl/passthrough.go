@@ -0,0 +1,301 @@
+package ixbrl
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/redofri/redofri/pkg/model"
+)
+
+// standardUnits maps the units the generator always declares to their ids.
+var standardUnits = map[string]string{
+	"iso4217:SEK":               "SEK",
+	"xbrli:pure":                "procent",
+	"se-k2-type:AntalAnstallda": "antal-anstallda",
+}
+
+// standardPrefixes are the namespace prefixes declared by writeHTMLOpen.
+var standardPrefixes = map[string]bool{
+	"iso4217": true, "ixt": true, "xlink": true, "link": true, "xbrli": true, "ix": true,
+	"se-gen-base": true, "se-cd-base": true, "se-bol-base": true, "se-k2-type": true,
+}
+
+// walkPassthrough calls fn for every passthrough fact and tuple member.
+func walkPassthrough(facts []model.PassthroughFact, fn func(p *model.PassthroughFact)) {
+	for i := range facts {
+		fn(&facts[i])
+		walkPassthrough(facts[i].Members, fn)
+	}
+}
+
+// passthroughUnits returns the units used by passthrough facts that are
+// not among the standard ones, sorted, with the ids they are declared as.
+func passthroughUnits(r *model.AnnualReport) (units []string, ids map[string]string) {
+	ids = map[string]string{}
+	for u, id := range standardUnits {
+		ids[u] = id
+	}
+	walkPassthrough(r.Passthrough, func(p *model.PassthroughFact) {
+		if p.Unit != "" && ids[p.Unit] == "" {
+			ids[p.Unit] = "?"
+			units = append(units, p.Unit)
+		}
+	})
+	sort.Strings(units)
+	for i, u := range units {
+		ids[u] = fmt.Sprintf("passthrough-unit%d", i+1)
+	}
+	return units, ids
+}
+
+// passthroughNamespaces returns the prefixes and namespace URIs of
+// passthrough concepts outside the standard taxonomies, sorted by prefix.
+func passthroughNamespaces(r *model.AnnualReport) [][2]string {
+	seen := map[string]bool{}
+	var out [][2]string
+	walkPassthrough(r.Passthrough, func(p *model.PassthroughFact) {
+		prefix, _, _ := strings.Cut(p.Concept, ":")
+		if p.Namespace == "" || standardPrefixes[prefix] || seen[prefix] {
+			return
+		}
+		seen[prefix] = true
+		out = append(out, [2]string{prefix, p.Namespace})
+	})
+	if hasNilPassthrough(r) {
+		out = append(out, [2]string{"xsi", xsiNS})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i][0] < out[j][0] })
+	return out
+}
+
+// hasNilPassthrough reports whether any passthrough fact is nil.
+func hasNilPassthrough(r *model.AnnualReport) bool {
+	found := false
+	walkPassthrough(r.Passthrough, func(p *model.PassthroughFact) {
+		found = found || p.Nil
+	})
+	return found
+}
+
+// passthroughYears returns how many fiscal years the passthrough contexts
+// reach back, counting the current year: 1 for period0/balans0 only.
+func passthroughYears(r *model.AnnualReport) int {
+	years := 0
+	walkPassthrough(r.Passthrough, func(p *model.PassthroughFact) {
+		if n, ok := contextYear(p.Context); ok && n+1 > years {
+			years = n + 1
+		}
+	})
+	return years
+}
+
+// contextYear returns N for the logical contexts periodN and balansN.
+func contextYear(ctx string) (int, bool) {
+	digits := strings.TrimPrefix(strings.TrimPrefix(ctx, "period"), "balans")
+	if digits == ctx {
+		return 0, false
+	}
+	n, err := strconv.Atoi(digits)
+	return n, err == nil
+}
+
+// writeUnitString writes a unit given in OIM unit string syntax, such as
+// "iso4217:SEK" or "iso4217:SEK/xbrli:shares".
+func (g *generator) writeUnitString(id, unit string) {
+	num, denom, divide := strings.Cut(unit, "/")
+	if !divide {
+		g.linef(`<xbrli:unit id="%s">`, id)
+		g.in()
+		for _, m := range unitMeasures(num) {
+			g.linef(`<xbrli:measure>%s</xbrli:measure>`, esc(m))
+		}
+		g.out()
+		g.line(`</xbrli:unit>`)
+		return
+	}
+	g.linef(`<xbrli:unit id="%s">`, id)
+	g.in()
+	g.line(`<xbrli:divide>`)
+	g.in()
+	for _, part := range []struct{ el, ms string }{{"unitNumerator", num}, {"unitDenominator", denom}} {
+		g.linef(`<xbrli:%s>`, part.el)
+		g.in()
+		for _, m := range unitMeasures(part.ms) {
+			g.linef(`<xbrli:measure>%s</xbrli:measure>`, esc(m))
+		}
+		g.out()
+		g.linef(`</xbrli:%s>`, part.el)
+	}
+	g.out()
+	g.line(`</xbrli:divide>`)
+	g.out()
+	g.line(`</xbrli:unit>`)
+}
+
+// unitMeasures splits "(a*b)" or "a*b" into its measures.
+func unitMeasures(s string) []string {
+	return strings.Split(strings.Trim(s, "()"), "*")
+}
+
+// writePassthrough writes the facts and tuples in r.Passthrough as a table
+// under Övriga upplysningar, so that facts the model does not know about
+// survive a parse and generate round trip.
+func (g *generator) writePassthrough(r *model.AnnualReport) {
+	if len(r.Passthrough) == 0 {
+		return
+	}
+	_, units := passthroughUnits(r)
+
+	g.linef(`<h3>%s</h3>`, g.t("Övriga upplysningar"))
+
+	g.line(`<table class="ar-note-10">`)
+	g.in()
+	g.line(`<colgroup>`)
+	g.in()
+	g.line(`<col />`)
+	g.line(`<col />`)
+	g.line(`<col class="kr" />`)
+	g.out()
+	g.line(`</colgroup>`)
+	g.line(`<tbody>`)
+	g.in()
+
+	// Tuples are declared in their heading row, so that facts and tuples
+	// keep their order when the document is read back.
+	tuples := 0
+	var rows func(facts []model.PassthroughFact, parent string)
+	rows = func(facts []model.PassthroughFact, parent string) {
+		for i := range facts {
+			p := &facts[i]
+			order := ""
+			if parent != "" {
+				order = strconv.Itoa(i + 1)
+			}
+			if p.Tuple {
+				tuples++
+				id := fmt.Sprintf("PassthroughTuple%d", tuples)
+				g.tuples[id] = true
+				decl := fmt.Sprintf(`<ix:tuple name="%s" tupleID="%s" />`, esc(p.Concept), id)
+				if parent != "" {
+					decl = fmt.Sprintf(`<ix:tuple name="%s" tupleID="%s" tupleRef="%s" order="%s" />`, esc(p.Concept), id, parent, order)
+				}
+				g.line(`<tr>`)
+				g.in()
+				g.linef(`<td colspan="3">%s<strong>%s</strong></td>`, decl, esc(conceptLocalName(p.Concept)))
+				g.out()
+				g.line(`</tr>`)
+				rows(p.Members, id)
+				continue
+			}
+			g.line(`<tr>`)
+			g.in()
+			g.linef(`<td>%s</td>`, esc(conceptLocalName(p.Concept)))
+			g.linef(`<td>%s</td>`, esc(g.contextLabel(r, p.Context)))
+			g.line(`<td>`)
+			g.in()
+			g.write(indentStr(g.indent))
+			if p.Unit != "" {
+				g.passthroughNonFraction(p, units[p.Unit], parent, order)
+			} else {
+				g.passthroughNonNumeric(p, parent, order)
+			}
+			g.write("\n")
+			g.out()
+			g.line(`</td>`)
+			g.out()
+			g.line(`</tr>`)
+		}
+	}
+	rows(r.Passthrough, "")
+
+	g.out()
+	g.line(`</tbody>`)
+	g.out()
+	g.line(`</table>`)
+}
+
+// passthroughNonFraction writes a numeric passthrough fact. The value is
+// shown as stored, with the report language's separators. A value that is
+// not a decimal number fails the generation rather than being written as
+// another number.
+func (g *generator) passthroughNonFraction(p *model.PassthroughFact, unitRef, tupleRef, order string) {
+	attrs := fmt.Sprintf(`contextRef="%s" name="%s" unitRef="%s"`, p.Context, esc(p.Concept), unitRef)
+	if tupleRef != "" {
+		attrs += fmt.Sprintf(` tupleRef="%s" order="%s"`, tupleRef, order)
+	}
+	if p.Nil {
+		// Nil facts have no decimals, format or content.
+		g.writef(`<ix:nonFraction %s xsi:nil="true"/>`, attrs)
+		return
+	}
+	decimals := p.Decimals
+	if decimals == "" {
+		decimals = "INF"
+	}
+	attrs += fmt.Sprintf(` decimals="%s"`, esc(decimals))
+
+	value, negative := strings.CutPrefix(p.Value, "-")
+	whole, frac, _ := strings.Cut(value, ".")
+	n, err := strconv.ParseUint(whole, 10, 63)
+	if err == nil && strings.Trim(frac, "0123456789") != "" {
+		err = strconv.ErrSyntax
+	}
+	if err != nil {
+		if g.err == nil {
+			g.err = fmt.Errorf("passthrough fact %s@%s: value %q is not a decimal number", p.Concept, p.Context, p.Value)
+		}
+		return
+	}
+	display := g.lbl.Int(int64(n))
+	if frac != "" {
+		display += g.lbl.DecimalSeparator() + frac
+	}
+	attrs += fmt.Sprintf(` scale="0" format="%s"`, g.numberFormat())
+	if negative {
+		// As for expenses, the minus is shown outside the tag.
+		attrs += ` sign="-"`
+		g.write("-")
+	}
+	g.writef(`<ix:nonFraction %s>%s</ix:nonFraction>`, attrs, display)
+}
+
+// passthroughNonNumeric writes a text passthrough fact.
+func (g *generator) passthroughNonNumeric(p *model.PassthroughFact, tupleRef, order string) {
+	var opts []nnOpt
+	if tupleRef != "" {
+		opts = append(opts, withTupleRef(tupleRef), withOrder(order))
+	}
+	if p.Nil {
+		attrs := fmt.Sprintf(`name="%s" contextRef="%s"`, esc(p.Concept), p.Context)
+		if tupleRef != "" {
+			attrs += fmt.Sprintf(` order="%s" tupleRef="%s"`, order, tupleRef)
+		}
+		g.writef(`<ix:nonNumeric %s xsi:nil="true"/>`, attrs)
+		return
+	}
+	g.nonNumeric(esc(p.Concept), p.Context, p.Value, opts...)
+}
+
+// contextLabel describes a logical context: the fiscal year for periodN
+// and the balance date for balansN.
+func (g *generator) contextLabel(r *model.AnnualReport, ctx string) string {
+	n, ok := contextYear(ctx)
+	if !ok {
+		return ctx
+	}
+	start, end := yearNDates(r.FiscalYear.StartDate, r.FiscalYear.EndDate, n)
+	if strings.HasPrefix(ctx, "balans") {
+		return g.date(end)
+	}
+	return fiscalYearLabel(start, end)
+}
+
+// conceptLocalName returns a concept QName without its prefix.
+func conceptLocalName(concept string) string {
+	if _, local, ok := strings.Cut(concept, ":"); ok {
+		return local
+	}
+	return concept
+}
@@ -28,7 +28,7 @@ func (g *generator) writeSignatures(r *model.AnnualReport) {
 
 	// Tuple declarations for all signatories
 	for i := range sigs.Signatories {
-		g.linef(`<ix:tuple name="se-gen-base:UnderskriftArsredovisningForetradareTuple" tupleID="UnderskriftArsredovisningForetradareTuple%d" />`, i+1)
+		g.tuple("se-gen-base:UnderskriftArsredovisningForetradareTuple", fmt.Sprintf("UnderskriftArsredovisningForetradareTuple%d", i+1))
 	}
 
 	// Signatory blocks
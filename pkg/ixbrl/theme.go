@@ -0,0 +1,301 @@
+package ixbrl
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"html/template"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Theme controls the visual presentation of the generated document.
+//
+// A theme can only change styling and untagged display markup. The tagged
+// facts, the ix:header block and the page/section ids are always written by
+// the generator, so a themed document carries exactly the same XBRL data as
+// the default one.
+type Theme struct {
+	Name string `json:"name"`
+
+	// CSS replaces the default stylesheet when non-empty.
+	CSS string `json:"css,omitempty"`
+	// ExtraCSS is appended after the base stylesheet.
+	ExtraCSS string `json:"extraCss,omitempty"`
+
+	// CoverTemplate replaces the title heading on the cover page.
+	// It is an html/template executed with ThemeData.
+	CoverTemplate string `json:"coverTemplate,omitempty"`
+	// HeaderTemplate replaces the contents of every page header.
+	// It is an html/template executed with ThemeData.
+	HeaderTemplate string `json:"headerTemplate,omitempty"`
+}
+
+// ThemeData is the data available to cover and header templates.
+type ThemeData struct {
+	CompanyName string
+	OrgNr       string
+	FiscalYear  string // display label, e.g. "2016"
+	StartDate   string
+	EndDate     string
+	Title       string // the default cover title
+	Page        int    // page number (header template only)
+	TotalPages  int
+	Logo        template.URL // logo data URI, empty if the report has no logo
+}
+
+// builtinThemes are the themes selectable by name.
+var builtinThemes = map[string]*Theme{
+	"standard": {Name: "standard"},
+	"print": {
+		Name: "print",
+		ExtraCSS: `html {
+	background: none;
+}
+body {
+	color: rgb(0, 0, 0);
+}
+.ar-page {
+	font-family: Georgia, Times New Roman, Times, serif; box-shadow: none; border-color: rgb(200, 200, 200); background: rgb(255, 255, 255);
+}
+.ar-page > h2:first-of-type {
+	border-bottom-color: rgb(0, 0, 0);
+}
+.ar-cover-meta, #ar-certification {
+	background: none; border-color: rgb(0, 0, 0);
+}
+.ar-page-hdr {
+	color: rgb(0, 0, 0);
+}
+`,
+	},
+	"compact": {
+		Name: "compact",
+		ExtraCSS: `html {
+	font-size: 90%;
+}
+.ar-page {
+	padding: 1em 1.6em 1.6em; min-height: 0px; line-height: 1.2;
+}
+.ar-page-hdr {
+	margin-bottom: 1.2em;
+}
+h2 {
+	margin-top: 1.2em;
+}
+.ar-cover-meta {
+	margin: 0.8em 0 1em;
+}
+`,
+	},
+}
+
+// BuiltinTheme returns the built-in theme with the given name.
+func BuiltinTheme(name string) (*Theme, bool) {
+	t, ok := builtinThemes[name]
+	if !ok {
+		return nil, false
+	}
+	copied := *t
+	return &copied, true
+}
+
+// BuiltinThemes returns the names of all built-in themes, sorted.
+func BuiltinThemes() []string {
+	names := make([]string, 0, len(builtinThemes))
+	for name := range builtinThemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate checks that the theme follows Bolagsverket's presentation rules:
+// no scripts or event handlers, no external resources, and no markup that
+// could change the XBRL tagging or document structure.
+func (t *Theme) Validate() error {
+	_, err := t.compile()
+	return err
+}
+
+// compiledTheme holds the parsed templates of a validated theme.
+type compiledTheme struct {
+	cover  *template.Template
+	header *template.Template
+}
+
+func (t *Theme) compile() (*compiledTheme, error) {
+	if err := checkThemeCSS(t.CSS); err != nil {
+		return nil, fmt.Errorf("theme %s: css: %w", t.Name, err)
+	}
+	if err := checkThemeCSS(t.ExtraCSS); err != nil {
+		return nil, fmt.Errorf("theme %s: extra css: %w", t.Name, err)
+	}
+
+	ct := &compiledTheme{}
+	sample := ThemeData{
+		CompanyName: "Exempel AB",
+		OrgNr:       "556999-9999",
+		FiscalYear:  "2016",
+		StartDate:   "2016-01-01",
+		EndDate:     "2016-12-31",
+		Title:       "Årsredovisning för räkenskapsåret 2016",
+		Page:        1,
+		TotalPages:  10,
+	}
+	for _, tpl := range []struct {
+		name string
+		src  string
+		dst  **template.Template
+	}{
+		{"cover", t.CoverTemplate, &ct.cover},
+		{"header", t.HeaderTemplate, &ct.header},
+	} {
+		if tpl.src == "" {
+			continue
+		}
+		parsed, err := template.New(tpl.name).Parse(tpl.src)
+		if err != nil {
+			return nil, fmt.Errorf("theme %s: %s template: %w", t.Name, tpl.name, err)
+		}
+		// Render with sample data so that structural problems are
+		// reported when the theme is loaded rather than mid-generation.
+		if _, err := renderThemeTemplate(parsed, sample); err != nil {
+			return nil, fmt.Errorf("theme %s: %s template: %w", t.Name, tpl.name, err)
+		}
+		*tpl.dst = parsed
+	}
+	return ct, nil
+}
+
+// renderThemeTemplate executes a theme template and checks the resulting markup.
+func renderThemeTemplate(tpl *template.Template, data ThemeData) (string, error) {
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	out := buf.String()
+	if err := checkThemeMarkup(out); err != nil {
+		return "", err
+	}
+	return out, nil
+}
+
+const xhtmlNS = "http://www.w3.org/1999/xhtml"
+
+// forbiddenThemeElements may not appear in theme templates. Styles belong in
+// the theme CSS, and everything else either executes code, loads external
+// resources or changes document metadata.
+var forbiddenThemeElements = map[string]bool{
+	"script": true, "noscript": true, "style": true, "link": true, "meta": true,
+	"base": true, "iframe": true, "frame": true, "frameset": true, "object": true,
+	"embed": true, "applet": true, "form": true, "input": true, "button": true,
+	"audio": true, "video": true, "source": true,
+}
+
+// checkThemeMarkup verifies that a rendered template fragment is well-formed
+// XHTML without tagging, ids, scripts or external references.
+func checkThemeMarkup(fragment string) error {
+	d := xml.NewDecoder(strings.NewReader(`<div xmlns="` + xhtmlNS + `">` + fragment + `</div>`))
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("not well-formed XHTML: %w", err)
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if start.Name.Space != xhtmlNS {
+			return fmt.Errorf("element <%s:%s> is not allowed, templates may only contain XHTML", start.Name.Space, start.Name.Local)
+		}
+		if forbiddenThemeElements[strings.ToLower(start.Name.Local)] {
+			return fmt.Errorf("element <%s> is not allowed", start.Name.Local)
+		}
+		for _, a := range start.Attr {
+			name := strings.ToLower(a.Name.Local)
+			switch {
+			case a.Name.Space == "xmlns" || (a.Name.Space == "" && a.Name.Local == "xmlns"):
+				// Namespace declarations name a namespace, not a resource;
+				// only XHTML may be declared.
+				if a.Value != xhtmlNS {
+					return fmt.Errorf("namespace declaration %q is not allowed, templates may only contain XHTML", a.Value)
+				}
+			case strings.HasPrefix(name, "on"):
+				return fmt.Errorf("event handler attribute %s is not allowed", a.Name.Local)
+			case name == "id":
+				return fmt.Errorf("id attributes are reserved for the generator")
+			case name == "href" || name == "src" || name == "srcset" || name == "action":
+				if !isLocalReference(a.Value) {
+					return fmt.Errorf("%s=%q refers to an external resource", a.Name.Local, a.Value)
+				}
+			case name == "style":
+				if err := checkThemeCSS(a.Value); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// isLocalReference reports whether a URL stays within the document: either a
+// fragment reference or an embedded image data URI.
+func isLocalReference(v string) bool {
+	v = strings.TrimSpace(v)
+	return v == "" || strings.HasPrefix(v, "#") || strings.HasPrefix(strings.ToLower(v), "data:image/")
+}
+
+var cssURLRe = regexp.MustCompile(`(?i)url\(\s*['"]?([^'")\s]*)`)
+
+// checkThemeCSS rejects stylesheet constructs that load external resources or
+// execute code, or that could break out of the <style> element.
+func checkThemeCSS(css string) error {
+	lower := strings.ToLower(css)
+	for _, bad := range []string{"@import", "expression(", "javascript:", "behavior:", "-moz-binding", "</", "<!--", "]]>"} {
+		if strings.Contains(lower, bad) {
+			return fmt.Errorf("%q is not allowed", bad)
+		}
+	}
+	for _, m := range cssURLRe.FindAllStringSubmatch(css, -1) {
+		if !isLocalReference(m[1]) || m[1] == "" {
+			return fmt.Errorf("url(%s) refers to an external resource", m[1])
+		}
+	}
+	return nil
+}
+
+// themeData returns the template data for the given page.
+func (g *generator) themeData(pageNum, totalPages int) ThemeData {
+	r := g.report
+	yearLabel := fiscalYearLabel(r.FiscalYear.StartDate, r.FiscalYear.EndDate)
+	return ThemeData{
+		CompanyName: r.Company.Name,
+		OrgNr:       r.Company.OrgNr,
+		FiscalYear:  yearLabel,
+		StartDate:   g.date(r.FiscalYear.StartDate),
+		EndDate:     g.date(r.FiscalYear.EndDate),
+		Title:       g.lbl.Tf("Årsredovisning för räkenskapsåret %s", yearLabel),
+		Page:        pageNum,
+		TotalPages:  totalPages,
+		Logo:        template.URL(g.logoURI),
+	}
+}
+
+// themeTemplate renders a theme template in place. The output is checked on
+// every render since it depends on report data.
+func (g *generator) themeTemplate(tpl *template.Template, pageNum, totalPages int) {
+	if g.err != nil {
+		return
+	}
+	out, err := renderThemeTemplate(tpl, g.themeData(pageNum, totalPages))
+	if err != nil {
+		g.err = fmt.Errorf("theme %s: %s template: %w", g.theme.Name, tpl.Name(), err)
+		return
+	}
+	g.line(strings.TrimSpace(out))
+}
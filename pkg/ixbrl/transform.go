@@ -0,0 +1,403 @@
+package ixbrl
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// transform converts the displayed text of a fact into its XBRL value.
+// Numeric transforms return an unsigned decimal such as "1234.5", before
+// scale and sign are applied.
+type transform func(s string) (string, error)
+
+// transforms is the inline XBRL transformation registry, versions 1 to 5,
+// keyed by local name. The names do not overlap between versions, so the
+// namespace of the format prefix is not needed to pick a rule. Japanese
+// era dates and the Indian national calendar are not supported.
+var transforms = map[string]transform{
+	// Numbers.
+	"numcommadot":       numberTransform('.'),
+	"numspacedot":       numberTransform('.'),
+	"numdotdecimal":     numberTransform('.'),
+	"numdotdecimalin":   numberTransform('.'),
+	"num-dot-decimal":   numberTransform('.'),
+	"numdotcomma":       numberTransform(','),
+	"numcomma":          numberTransform(','),
+	"numspacecomma":     numberTransform(','),
+	"numcommadecimal":   numberTransform(','),
+	"num-comma-decimal": numberTransform(','),
+	"numunitdecimal":    unitDecimal,
+	"numunitdecimalin":  unitDecimal,
+	"num-unit-decimal":  unitDecimal,
+	"numdash":           fixed("0"),
+	"zerodash":          fixed("0"),
+	"fixed-zero":        fixed("0"),
+
+	// Booleans and empty values.
+	"booleanfalse": fixed("false"),
+	"booleantrue":  fixed("true"),
+	"fixed-false":  fixed("false"),
+	"fixed-true":   fixed("true"),
+	"nocontent":    fixed(""),
+	"fixed-empty":  fixed(""),
+
+	// Dates with numeric months. TR1 names say which separator is used,
+	// later versions accept any; both are parsed the same way.
+	"dateslasheu":         dateTransform("dmy"),
+	"datedoteu":           dateTransform("dmy"),
+	"datedaymonthyear":    dateTransform("dmy"),
+	"date-day-month-year": dateTransform("dmy"),
+	"dateslashus":         dateTransform("mdy"),
+	"datedotus":           dateTransform("mdy"),
+	"datemonthdayyear":    dateTransform("mdy"),
+	"date-month-day-year": dateTransform("mdy"),
+	"dateyearmonthday":    dateTransform("ymd"),
+	"date-year-month-day": dateTransform("ymd"),
+	"dateyearmonthdaycjk": dateTransform("ymd"),
+	"dateslashdaymontheu": dateTransform("dm"),
+	"datedaymonth":        dateTransform("dm"),
+	"date-day-month":      dateTransform("dm"),
+	"dateslashmonthdayus": dateTransform("md"),
+	"datemonthday":        dateTransform("md"),
+	"date-month-day":      dateTransform("md"),
+	"datemonthyear":       dateTransform("my"),
+	"date-month-year":     dateTransform("my"),
+	"dateyearmonthcjk":    dateTransform("ym"),
+	"date-year-month":     dateTransform("ym"),
+	"datelongyearmonth":   dateTransform("yM"),
+	"dateshortyearmonth":  dateTransform("yM"),
+	"dateyearmonthen":     dateTransform("yM"),
+	"datelongmonthyear":   dateTransform("My"),
+	"dateshortmonthyear":  dateTransform("My"),
+	"datemonthyearen":     dateTransform("My"),
+	"datelonguk":          dateTransform("dMy"),
+	"dateshortuk":         dateTransform("dMy"),
+	"datedaymonthyearen":  dateTransform("dMy"),
+	"datelongus":          dateTransform("Mdy"),
+	"dateshortus":         dateTransform("Mdy"),
+	"datemonthdayyearen":  dateTransform("Mdy"),
+	"datelongdaymonthuk":  dateTransform("dM"),
+	"dateshortdaymonthuk": dateTransform("dM"),
+	"datedaymonthen":      dateTransform("dM"),
+	"datelongmonthdayus":  dateTransform("Md"),
+	"dateshortmonthdayus": dateTransform("Md"),
+	"datemonthdayen":      dateTransform("Md"),
+}
+
+// transformPatterns maps the language-specific TR4 and TR5 date names, such
+// as date-day-monthname-year-sv, to their field order once the language
+// suffix is removed. Month names are recognised in all supported languages.
+var transformPatterns = map[string]string{
+	"date-day-monthname":      "dM",
+	"date-day-monthname-year": "dMy",
+	"date-monthname-day":      "Md",
+	"date-monthname-day-year": "Mdy",
+	"date-monthname-year":     "My",
+	"date-year-monthname":     "yM",
+	"date-year-monthname-day": "yMd",
+}
+
+// Namespaces of the first two transformation registries. The generator
+// declares TR1.
+const (
+	transformsTR1 = "http://www.xbrl.org/inlineXBRL/transformation/2010-04-20"
+	transformsTR2 = "http://www.xbrl.org/inlineXBRL/transformation/2011-07-31"
+)
+
+// registryNames lists the rules defined by TR1 and TR2, which have
+// different names for the same transformations.
+var registryNames = map[string][]string{
+	transformsTR1: {
+		"datedoteu", "datedotus", "datelongdaymonthuk", "datelongmonthdayus",
+		"datelongmonthyear", "datelonguk", "datelongus", "datelongyearmonth",
+		"dateshortdaymonthuk", "dateshortmonthdayus", "dateshortmonthyear", "dateshortuk",
+		"dateshortus", "dateshortyearmonth", "dateslashdaymontheu", "dateslasheu",
+		"dateslashmonthdayus", "dateslashus",
+		"numcomma", "numcommadot", "numdash", "numdotcomma", "numspacecomma", "numspacedot",
+	},
+	transformsTR2: {
+		"booleanfalse", "booleantrue",
+		"datedaymonth", "datedaymonthen", "datedaymonthyear", "datedaymonthyearen",
+		"dateerayearmonthdayjp", "dateerayearmonthjp", "datemonthday", "datemonthdayen",
+		"datemonthdayyear", "datemonthdayyearen", "datemonthyearen", "dateyearmonthcjk",
+		"dateyearmonthdaycjk", "dateyearmonthen",
+		"nocontent", "numcommadecimal", "numdotdecimal", "numunitdecimal", "zerodash",
+	},
+}
+
+// TransformDefined reports whether the transformation registry with
+// namespace ns defines the rule name, e.g. "numcommadot". TR1 and TR2 are
+// checked by their own lists; later registries against every rule the
+// parser supports.
+func TransformDefined(ns, name string) bool {
+	if names, ok := registryNames[ns]; ok {
+		return slices.Contains(names, name)
+	}
+	if !strings.HasPrefix(ns, ixtNSPrefix) {
+		return false
+	}
+	_, ok := lookupTransform(name)
+	return ok
+}
+
+// lookupTransform returns the transform for a format QName such as
+// "ixt:num-dot-decimal" or "ixt4:date-day-monthname-year-sv".
+func lookupTransform(format string) (transform, bool) {
+	name := format
+	if _, local, ok := strings.Cut(format, ":"); ok {
+		name = local
+	}
+	if t, ok := transforms[name]; ok {
+		return t, true
+	}
+	if i := strings.LastIndexByte(name, '-'); i > 0 {
+		if order, ok := transformPatterns[name[:i]]; ok {
+			return dateTransform(order), true
+		}
+	}
+	return nil, false
+}
+
+// applyTransform applies the transformation rule named by format to s.
+func applyTransform(format, s string) (string, error) {
+	t, ok := lookupTransform(format)
+	if !ok {
+		return "", fmt.Errorf("unsupported format %q", format)
+	}
+	return t(strings.TrimSpace(s))
+}
+
+func fixed(v string) transform {
+	return func(string) (string, error) { return v, nil }
+}
+
+// numberTransform parses a number whose decimal separator is decimalSep.
+// Spaces, apostrophes and the other of '.' and ',' are taken as group
+// separators.
+func numberTransform(decimalSep rune) transform {
+	return func(s string) (string, error) {
+		var intPart, fracPart strings.Builder
+		seenSep := false
+		for _, r := range s {
+			switch {
+			case unicode.IsDigit(r):
+				d := digitValue(r)
+				if seenSep {
+					fracPart.WriteByte(d)
+				} else {
+					intPart.WriteByte(d)
+				}
+			case r == decimalSep && !seenSep:
+				seenSep = true
+			case isGroupSeparator(r):
+			default:
+				return "", fmt.Errorf("invalid number %q", s)
+			}
+		}
+		if intPart.Len() == 0 && fracPart.Len() == 0 {
+			return "", fmt.Errorf("invalid number %q", s)
+		}
+		if intPart.Len() == 0 {
+			intPart.WriteByte('0')
+		}
+		if fracPart.Len() == 0 {
+			return intPart.String(), nil
+		}
+		return intPart.String() + "." + fracPart.String(), nil
+	}
+}
+
+func isGroupSeparator(r rune) bool {
+	switch r {
+	case ' ', '\u00a0', '\u2009', '\u202f', '.', ',', '\'', '\u2019':
+		return true
+	}
+	return false
+}
+
+// digitValue converts a Unicode decimal digit, e.g. a full-width one, to
+// its ASCII form.
+func digitValue(r rune) byte {
+	if r >= '0' && r <= '9' {
+		return byte(r)
+	}
+	zero := r
+	for unicode.IsDigit(zero-1) && r-zero < 9 {
+		zero--
+	}
+	return byte('0' + r - zero)
+}
+
+// unitDecimal parses numbers written with units, such as "1 234 kr 50 öre":
+// the last group of digits is the fraction, the groups before it the
+// integer part.
+func unitDecimal(s string) (string, error) {
+	var groups []string
+	var cur strings.Builder
+	flush := func() {
+		if cur.Len() > 0 {
+			groups = append(groups, cur.String())
+			cur.Reset()
+		}
+	}
+	for _, r := range s {
+		switch {
+		case unicode.IsDigit(r):
+			cur.WriteByte(digitValue(r))
+		case isGroupSeparator(r) && cur.Len() > 0:
+			// Keep the integer groups together.
+		default:
+			flush()
+		}
+	}
+	flush()
+	switch len(groups) {
+	case 0:
+		return "", fmt.Errorf("invalid number %q", s)
+	case 1:
+		return groups[0], nil
+	}
+	return strings.Join(groups[:len(groups)-1], "") + "." + groups[len(groups)-1], nil
+}
+
+// monthNames maps lower-case month names and their three-letter
+// abbreviations in English, Swedish, Danish, Norwegian, German, French,
+// Spanish, Dutch and Finnish to month numbers.
+var monthNames = func() map[string]int {
+	lists := [][]string{
+		{"january", "february", "march", "april", "may", "june", "july", "august", "september", "october", "november", "december"},
+		{"januari", "februari", "mars", "april", "maj", "juni", "juli", "augusti", "september", "oktober", "november", "december"},
+		{"januar", "februar", "marts", "april", "maj", "juni", "juli", "august", "september", "oktober", "november", "december"},
+		{"januar", "februar", "mars", "april", "mai", "juni", "juli", "august", "september", "oktober", "november", "desember"},
+		{"januar", "februar", "märz", "april", "mai", "juni", "juli", "august", "september", "oktober", "november", "dezember"},
+		{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+		{"tammikuuta", "helmikuuta", "maaliskuuta", "huhtikuuta", "toukokuuta", "kesäkuuta", "heinäkuuta", "elokuuta", "syyskuuta", "lokakuuta", "marraskuuta", "joulukuuta"},
+		{"tammikuu", "helmikuu", "maaliskuu", "huhtikuu", "toukokuu", "kesäkuu", "heinäkuu", "elokuu", "syyskuu", "lokakuu", "marraskuu", "joulukuu"},
+	}
+	m := map[string]int{"sept": 9}
+	for _, names := range lists {
+		for i, name := range names {
+			m[name] = i + 1
+			if r := []rune(name); len(r) > 3 {
+				if _, ok := m[string(r[:3])]; !ok {
+					m[string(r[:3])] = i + 1
+				}
+			}
+		}
+	}
+	return m
+}()
+
+// dateTransform parses a date whose fields appear in the given order:
+// d for day, m for numeric month, M for month name and y for year. The
+// result is an xs:date, xs:gYearMonth or xs:gMonthDay depending on the
+// fields present. Two-digit years are taken to be in this century.
+func dateTransform(order string) transform {
+	hasDay := strings.ContainsRune(order, 'd')
+	hasYear := strings.ContainsRune(order, 'y')
+	return func(s string) (string, error) {
+		tokens := dateTokens(s)
+		if len(tokens) != len(order) {
+			return "", fmt.Errorf("invalid date %q", s)
+		}
+		var day, month, year int
+		for i, field := range order {
+			tok := tokens[i]
+			var err error
+			switch field {
+			case 'd':
+				day, err = strconv.Atoi(tok)
+			case 'm':
+				month, err = strconv.Atoi(tok)
+			case 'M':
+				var ok bool
+				if month, ok = monthNames[tok]; !ok {
+					err = fmt.Errorf("unknown month %q", tok)
+				}
+			case 'y':
+				year, err = strconv.Atoi(tok)
+				if err == nil && len(tok) <= 2 {
+					year += 2000
+				}
+			}
+			if err != nil {
+				return "", fmt.Errorf("invalid date %q", s)
+			}
+		}
+		if month < 1 || month > 12 || (hasDay && (day < 1 || day > daysIn(month, year))) {
+			return "", fmt.Errorf("invalid date %q", s)
+		}
+		switch {
+		case !hasYear:
+			return fmt.Sprintf("--%02d-%02d", month, day), nil
+		case !hasDay:
+			return fmt.Sprintf("%04d-%02d", year, month), nil
+		}
+		return fmt.Sprintf("%04d-%02d-%02d", year, month, day), nil
+	}
+}
+
+// dateTokens splits a date into runs of digits and runs of letters,
+// dropping separators, ordinal suffixes such as "st" and the CJK date
+// characters.
+func dateTokens(s string) []string {
+	var tokens []string
+	var cur strings.Builder
+	digits := false
+	flush := func() {
+		if cur.Len() == 0 {
+			return
+		}
+		tok := cur.String()
+		cur.Reset()
+		if !digits {
+			switch tok {
+			case "st", "nd", "rd", "th", "de", "del", "der", "den":
+				return
+			}
+		}
+		tokens = append(tokens, tok)
+	}
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case unicode.IsDigit(r):
+			if !digits {
+				flush()
+			}
+			digits = true
+			cur.WriteByte(digitValue(r))
+		case unicode.IsLetter(r) && r < 0x2e80:
+			if digits {
+				flush()
+			}
+			digits = false
+			cur.WriteRune(r)
+		case r == '.' && !digits && cur.Len() > 0:
+			// Abbreviated month names such as "jan."
+		default:
+			flush()
+		}
+	}
+	flush()
+	return tokens
+}
+
+// daysIn returns the number of days in month of year; year 0 is treated
+// as a leap year so that --02-29 is accepted.
+func daysIn(month, year int) int {
+	switch month {
+	case 2:
+		if year == 0 || (year%4 == 0 && (year%100 != 0 || year%400 == 0)) {
+			return 29
+		}
+		return 28
+	case 4, 6, 9, 11:
+		return 30
+	}
+	return 31
+}
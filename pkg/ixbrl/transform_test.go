@@ -0,0 +1,64 @@
+package ixbrl
+
+import "testing"
+
+func TestApplyTransform(t *testing.T) {
+	tests := []struct {
+		format, in, want string
+	}{
+		{"ixt:numspacecomma", "2 650 000", "2650000"},
+		{"ixt:numcomma", "33,7", "33.7"},
+		{"ixt:numdotdecimal", "1,234.50", "1234.50"},
+		{"ixt4:num-dot-decimal", "1\u00a0234", "1234"},
+		{"ixt4:num-comma-decimal", "1.234,5", "1234.5"},
+		{"ixt4:num-comma-decimal", ",5", "0.5"},
+		{"ixt4:num-dot-decimal", "\uff11\uff12", "12"},
+		{"ixt4:num-unit-decimal", "1 234 kr 50 öre", "1234.50"},
+		{"ixt:zerodash", "–", "0"},
+		{"ixt4:fixed-zero", "-", "0"},
+		{"ixt4:fixed-true", "ja", "true"},
+		{"ixt:nocontent", "text", ""},
+		{"ixt:datedaymonthyear", "31.12.2016", "2016-12-31"},
+		{"ixt4:date-day-month-year", "31/12/16", "2016-12-31"},
+		{"ixt4:date-year-month-day", "2017-03-14", "2017-03-14"},
+		{"ixt4:date-month-day-year", "12-31-2016", "2016-12-31"},
+		{"ixt:dateyearmonthdaycjk", "2016年12月31日", "2016-12-31"},
+		{"ixt4:date-day-monthname-year-sv", "den 14 mars 2017", "2017-03-14"},
+		{"ixt4:date-day-monthname-year-en", "14th March 2017", "2017-03-14"},
+		{"ixt4:date-monthname-day-year-en", "Dec. 31, 2016", "2016-12-31"},
+		{"ixt4:date-day-monthname-year-de", "1. Mai 2017", "2017-05-01"},
+		{"ixt:datelongus", "February 29, 2016", "2016-02-29"},
+		{"ixt4:date-year-month", "2016-12", "2016-12"},
+		{"ixt4:date-monthname-year-sv", "december 2016", "2016-12"},
+		{"ixt4:date-day-month", "31/12", "--12-31"},
+	}
+	for _, tt := range tests {
+		got, err := applyTransform(tt.format, tt.in)
+		if err != nil {
+			t.Errorf("%s(%q): %v", tt.format, tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s(%q) = %q, want %q", tt.format, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestApplyTransform_Errors(t *testing.T) {
+	tests := []struct {
+		format, in string
+	}{
+		{"ixt:unknown", "1"},
+		{"ixt4:num-dot-decimal", "12a"},
+		{"ixt4:num-dot-decimal", ""},
+		{"ixt4:date-day-month-year", "30.02.2017"},
+		{"ixt4:date-day-month-year", "2017"},
+		{"ixt4:date-day-monthname-year-sv", "14 smarch 2017"},
+		{"ixt:datelongus", "February 29, 2017"},
+	}
+	for _, tt := range tests {
+		if got, err := applyTransform(tt.format, tt.in); err == nil {
+			t.Errorf("%s(%q) = %q, want error", tt.format, tt.in, got)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package labels
+
+// english translates the Swedish display texts. Line item labels follow the
+// English standard labels of the K2 taxonomy (se-gen-base), shortened where
+// the taxonomy label carries qualifiers that the Swedish layout also omits.
+var english = map[string]string{
+	// Document and cover page
+	"Årsredovisning":                       "Annual report",
+	"Årsredovisning för räkenskapsåret %s": "Annual report for the financial year %s",
+	"Org nr":              "Corp. ID no.",
+	"Bolag":               "Company",
+	"Organisationsnummer": "Corporate identity number",
+	"Räkenskapsår":        "Financial year",
+	"Valuta":              "Currency",
+	"Om inte annat särskilt anges, redovisas alla belopp i hela kronor. Uppgifter inom parentes avser föregående år.": "Unless otherwise stated, all amounts are reported in whole Swedish kronor (SEK). Figures in brackets refer to the previous year.",
+	"Innehåll":               "Contents",
+	"Sida":                   "Page",
+	"Sida %d av %d":          "Page %d of %d",
+	"förvaltningsberättelse": "management report",
+	"resultaträkning":        "income statement",
+	"balansräkning":          "balance sheet",
+	"noter":                  "notes",
+	"Fastställelseintyg":     "Certificate of adoption",
+
+	// Management report
+	"Förvaltningsberättelse":                                   "Management report",
+	"Verksamheten":                                             "Operations",
+	"Allmänt om verksamheten":                                  "General information about the operations",
+	"Väsentliga händelser under räkenskapsåret":                "Significant events during the financial year",
+	"Styrelsens yttrande över den föreslagna vinstutdelningen": "The board's statement on the proposed dividend",
+	"Flerårsöversikt":                                          "Multi-year overview",
+	"Nettoomsättning, <abbr>tkr</abbr>":                        "Net sales, <abbr>kSEK</abbr>",
+	"Resultat efter finansiella poster, <abbr>tkr</abbr>":      "Profit/loss after financial items, <abbr>kSEK</abbr>",
+	"Soliditet, %":                                             "Equity/assets ratio, %",
+	"Förändringar i eget kapital":                              "Changes in equity",
+	"Aktiekapital":                                             "Share capital",
+	"Reservfond":                                               "Statutory reserve",
+	"Balanserat resultat":                                      "Retained earnings",
+	"Årets resultat":                                           "Profit/loss for the year",
+	"Totalt":                                                   "Total",
+	"Belopp vid årets ingång":                                  "Opening balance",
+	"Resultatdisposition enligt årsstämman":                    "Appropriation of profits according to the annual general meeting",
+	"– Utdelning":                                              "– Dividend",
+	"Belopp vid årets utgång":                                  "Closing balance",
+	"Resultatdisposition":                                      "Appropriation of profits",
+	"Till årsstämmans förfogande står följande vinstmedel:":                                      "The following profits are at the disposal of the annual general meeting:",
+	"Styrelsen och verkställande direktören föreslår att vinstmedlen disponeras enligt följande": "The board of directors and the managing director propose that the profits be appropriated as follows",
+	"Utdelning till ägarna":   "Dividend to shareholders",
+	"Balanseras i ny räkning": "Carried forward",
+
+	// Income statement
+	"Resultaträkning": "Income statement",
+	"Rapporten visar bolagets intäkter, kostnader och resultat för aktuellt och föregående räkenskapsår.": "The statement shows the company's income, expenses and result for the current and previous financial year.",
+	"Not": "Note",
+	"Rörelseintäkter, lagerförändringar <abbr>m.m.</abbr>": "Operating income, changes in inventories, <abbr>etc.</abbr>",
+	"Nettoomsättning": "Net sales",
+	"Förändring av lager av produkter i arbete, färdiga varor och pågående arbete för annans räkning": "Change in inventories of work in progress, finished goods and work in progress on behalf of others",
+	"Övriga rörelseintäkter":                                     "Other operating income",
+	"Summa rörelseintäkter, lagerförändringar <abbr>m.m.</abbr>": "Total operating income, changes in inventories, <abbr>etc.</abbr>",
+	"Rörelsekostnader":                                           "Operating expenses",
+	"Råvaror och förnödenheter":                                  "Raw materials and consumables",
+	"Handelsvaror":                                               "Goods for resale",
+	"Övriga externa kostnader":                                   "Other external expenses",
+	"Personalkostnader":                                          "Personnel expenses",
+	"Av- och nedskrivningar av materiella och immateriella anläggningstillgångar": "Depreciation, amortisation and impairment of property, plant and equipment and intangible assets",
+	"Övriga rörelsekostnader": "Other operating expenses",
+	"Summa rörelsekostnader":  "Total operating expenses",
+	"Rörelseresultat":         "Operating profit/loss",
+	"Finansiella poster":      "Financial items",
+	"Resultat från övriga finansiella anläggningstillgångar": "Profit/loss from other financial fixed assets",
+	"Övriga ränteintäkter och liknande resultatposter":       "Other interest income and similar items",
+	"Räntekostnader och liknande resultatposter":             "Interest expenses and similar items",
+	"Summa finansiella poster":                               "Total financial items",
+	"Resultat efter finansiella poster":                      "Profit/loss after financial items",
+	"Bokslutsdispositioner":                                  "Appropriations",
+	"Förändring av periodiseringsfonder":                     "Change in tax allocation reserves",
+	"Förändring av överavskrivningar":                        "Change in excess depreciation",
+	"Summa bokslutsdispositioner":                            "Total appropriations",
+	"Resultat före skatt":                                    "Profit/loss before tax",
+	"Skatter":                                                "Taxes",
+	"Skatt på årets resultat":                                "Tax on profit for the year",
+
+	// Balance sheet
+	"Balansräkning": "Balance sheet",
+	"Balansräkningen visar bolagets tillgångar, eget kapital och skulder på balansdagen, jämfört med föregående år.": "The balance sheet shows the company's assets, equity and liabilities at the balance sheet date, compared with the previous year.",
+	"Tillgångar":                                   "Assets",
+	"Anläggningstillgångar":                        "Fixed assets",
+	"Materiella anläggningstillgångar":             "Property, plant and equipment",
+	"Byggnader och mark":                           "Buildings and land",
+	"Maskiner och andra tekniska anläggningar":     "Machinery and other technical installations",
+	"Inventarier, verktyg och installationer":      "Equipment, tools and installations",
+	"Summa materiella anläggningstillgångar":       "Total property, plant and equipment",
+	"Finansiella anläggningstillgångar":            "Financial fixed assets",
+	"Andra långfristiga värdepappersinnehav":       "Other long-term securities holdings",
+	"Summa finansiella anläggningstillgångar":      "Total financial fixed assets",
+	"Summa anläggningstillgångar":                  "Total fixed assets",
+	"Omsättningstillgångar":                        "Current assets",
+	"Varulager <abbr>m.m.</abbr>":                  "Inventories, <abbr>etc.</abbr>",
+	"Varor under tillverkning":                     "Work in progress",
+	"Färdiga varor och handelsvaror":               "Finished goods and goods for resale",
+	"Summa varulager":                              "Total inventories",
+	"Kortfristiga fordringar":                      "Current receivables",
+	"Kundfordringar":                               "Trade receivables",
+	"Övriga fordringar":                            "Other receivables",
+	"Förutbetalda kostnader och upplupna intäkter": "Prepaid expenses and accrued income",
+	"Summa kortfristiga fordringar":                "Total current receivables",
+	"Kassa och bank":                               "Cash and bank balances",
+	"Summa kassa och bank":                         "Total cash and bank balances",
+	"Summa omsättningstillgångar":                  "Total current assets",
+	"Summa tillgångar":                             "Total assets",
+	"Eget kapital och skulder":                     "Equity and liabilities",
+	"Eget kapital":                                 "Equity",
+	"Bundet eget kapital":                          "Restricted equity",
+	"Summa bundet eget kapital":                    "Total restricted equity",
+	"Fritt eget kapital":                           "Non-restricted equity",
+	"Summa fritt eget kapital":                     "Total non-restricted equity",
+	"Summa eget kapital":                           "Total equity",
+	"Obeskattade reserver":                         "Untaxed reserves",
+	"Periodiseringsfonder":                         "Tax allocation reserves",
+	"Ackumulerade överavskrivningar":               "Accumulated excess depreciation",
+	"Summa obeskattade reserver":                   "Total untaxed reserves",
+	"Avsättningar":                                 "Provisions",
+	"Avsättningar för pensioner och liknande förpliktelser enligt lagen (1967:531) om tryggande av pensionsutfästelse <abbr>m.m.</abbr>": "Provisions for pensions and similar obligations under the Act (1967:531) on Safeguarding of Pension Commitments, <abbr>etc.</abbr>",
+	"Övriga avsättningar":                          "Other provisions",
+	"Summa avsättningar":                           "Total provisions",
+	"Långfristiga skulder":                         "Non-current liabilities",
+	"Övriga skulder till kreditinstitut":           "Other liabilities to credit institutions",
+	"Övriga skulder":                               "Other liabilities",
+	"Summa långfristiga skulder":                   "Total non-current liabilities",
+	"Kortfristiga skulder":                         "Current liabilities",
+	"Leverantörsskulder":                           "Trade payables",
+	"Skatteskulder":                                "Tax liabilities",
+	"Upplupna kostnader och förutbetalda intäkter": "Accrued expenses and deferred income",
+	"Summa kortfristiga skulder":                   "Total current liabilities",
+	"Summa eget kapital och skulder":               "Total equity and liabilities",
+
+	// Notes
+	"Noter": "Notes",
+	"Noterna förklarar de belopp och bedömningar som ligger bakom resultat- och balansräkningen.": "The notes explain the amounts and judgements behind the income statement and the balance sheet.",
+	"Not %d":                                "Note %d",
+	"Redovisnings- och värderingsprinciper": "Accounting and valuation policies",
+	"Avskrivningar":                         "Depreciation",
+	"Tillämpade avskrivningstider:":         "Depreciation periods applied:",
+	"år":                                    "years",
+	"Anskaffningsvärde för egentillverkade varor": "Cost of internally produced goods",
+	"Ändrade jämförelsetal":                       "Restated comparatives",
+	"Nyckeltalsdefinitioner":                      "Definitions of key figures",
+	"Soliditet":                                   "Equity/assets ratio",
+	"Eget kapital och obeskattade reserver (med avdrag för uppskjuten skatt) i förhållande till balansomslutningen.": "Equity and untaxed reserves (less deferred tax) in relation to total assets.",
+	"Upplysningar till resultaträkningen": "Notes to the income statement",
+	"Medelantalet anställda":              "Average number of employees",
+	"Upplysningar till balansräkningen":   "Notes to the balance sheet",
+	"Ingående anskaffningsvärden":         "Opening cost",
+	"- Inköp":                             "- Purchases",
+	"- Försäljningar":                     "- Sales",
+	"Utgående anskaffningsvärden":         "Closing cost",
+	"Ingående avskrivningar":              "Opening depreciation",
+	"- Årets avskrivningar":               "- Depreciation for the year",
+	"Utgående avskrivningar":              "Closing depreciation",
+	"Redovisat värde":                     "Carrying amount",
+	"Långfristiga skulder som förfaller till betalning senare än fem år efter balansdagen:": "Non-current liabilities falling due for payment more than five years after the balance sheet date:",
+	"Summa":                    "Total",
+	"Ställda säkerheter":       "Pledged assets",
+	"Företagsinteckning":       "Floating charges",
+	"Fastighetsinteckning":     "Real estate mortgages",
+	"Summa ställda säkerheter": "Total pledged assets",
+	"Eventualförpliktelser":    "Contingent liabilities",
+	"Tillgångar, avsättningar och skulder som avser flera poster": "Assets, provisions and liabilities relating to several items",
+	"Övriga upplysningar": "Other information",
+}
@@ -0,0 +1,140 @@
+// Package labels holds the display texts used when rendering an annual report,
+// keyed by language.
+//
+// Swedish is the source language: every display string in the renderers is
+// written in Swedish and looked up in the catalogue for the report's
+// Meta.Language. Missing translations fall back to the Swedish text, so a
+// partially translated catalogue still produces a complete document.
+package labels
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultLanguage is the language of the source texts.
+const DefaultLanguage = "sv"
+
+// Catalogue holds the translations and number/date conventions for one language.
+type Catalogue struct {
+	Language string
+
+	messages  map[string]string // Swedish source text → translation
+	thousands string            // thousands separator
+	decimal   string            // decimal separator
+	months    [12]string        // month names for long dates, nil for ISO dates
+}
+
+// catalogues lists the supported languages.
+var catalogues = map[string]*Catalogue{
+	"sv": {
+		Language:  "sv",
+		thousands: " ",
+		decimal:   ",",
+	},
+	"en": {
+		Language:  "en",
+		messages:  english,
+		thousands: ",",
+		decimal:   ".",
+		months: [12]string{"January", "February", "March", "April", "May", "June",
+			"July", "August", "September", "October", "November", "December"},
+	},
+}
+
+// For returns the catalogue for the given language code ("sv", "en", "en-GB").
+// Unsupported languages get the Swedish catalogue.
+func For(language string) *Catalogue {
+	if c, ok := catalogues[baseLanguage(language)]; ok {
+		return c
+	}
+	return catalogues[DefaultLanguage]
+}
+
+// Supported reports whether there is a catalogue for the given language.
+func Supported(language string) bool {
+	_, ok := catalogues[baseLanguage(language)]
+	return ok
+}
+
+// Languages returns the supported language codes, sorted.
+func Languages() []string {
+	langs := make([]string, 0, len(catalogues))
+	for l := range catalogues {
+		langs = append(langs, l)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// baseLanguage strips any region subtag: "en-GB" → "en".
+func baseLanguage(language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+	return language
+}
+
+// T returns the translation of a Swedish source text.
+func (c *Catalogue) T(sv string) string {
+	if s, ok := c.messages[sv]; ok {
+		return s
+	}
+	return sv
+}
+
+// Tf translates a Swedish format string and formats it with args.
+func (c *Catalogue) Tf(sv string, args ...any) string {
+	return fmt.Sprintf(c.T(sv), args...)
+}
+
+// Date formats an ISO date (YYYY-MM-DD) for display. Swedish keeps the ISO
+// form; other languages spell out the month. Unparseable input is returned as is.
+func (c *Catalogue) Date(iso string) string {
+	if c.months[0] == "" {
+		return iso
+	}
+	t, err := time.Parse("2006-01-02", iso)
+	if err != nil {
+		return iso
+	}
+	return fmt.Sprintf("%d %s %d", t.Day(), c.months[t.Month()-1], t.Year())
+}
+
+// Int formats a whole number with the language's thousands separator:
+// 1234567 → "1 234 567" (sv) or "1,234,567" (en).
+func (c *Catalogue) Int(v int64) string {
+	if v == 0 {
+		return "0"
+	}
+	negative := v < 0
+	if negative {
+		v = -v
+	}
+	s := fmt.Sprintf("%d", v)
+	var parts []string
+	for len(s) > 3 {
+		parts = append([]string{s[len(s)-3:]}, parts...)
+		s = s[:len(s)-3]
+	}
+	parts = append([]string{s}, parts...)
+	result := strings.Join(parts, c.thousands)
+	if negative {
+		result = "-" + result
+	}
+	return result
+}
+
+// Decimal converts a decimal number written with "." (as stored in the model)
+// to the language's decimal separator.
+func (c *Catalogue) Decimal(s string) string {
+	return strings.ReplaceAll(s, ".", c.decimal)
+}
+
+// DecimalSeparator returns the decimal separator, "," or ".".
+func (c *Catalogue) DecimalSeparator() string {
+	return c.decimal
+}
@@ -0,0 +1,79 @@
+package labels
+
+import "testing"
+
+func TestFor(t *testing.T) {
+	tests := []struct {
+		lang string
+		want string
+	}{
+		{"sv", "sv"},
+		{"en", "en"},
+		{"en-GB", "en"},
+		{"EN", "en"},
+		{"de", "sv"},
+		{"", "sv"},
+	}
+	for _, tt := range tests {
+		if got := For(tt.lang).Language; got != tt.want {
+			t.Errorf("For(%q).Language = %q, want %q", tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	en := For("en")
+	if got := en.T("Resultaträkning"); got != "Income statement" {
+		t.Errorf("T(Resultaträkning) = %q", got)
+	}
+	if got := en.T("Okänd text"); got != "Okänd text" {
+		t.Errorf("untranslated text should fall back to Swedish, got %q", got)
+	}
+	if got := For("sv").T("Resultaträkning"); got != "Resultaträkning" {
+		t.Errorf("Swedish T = %q", got)
+	}
+	if got := en.Tf("Sida %d av %d", 2, 10); got != "Page 2 of 10" {
+		t.Errorf("Tf = %q", got)
+	}
+}
+
+func TestDate(t *testing.T) {
+	if got := For("sv").Date("2016-12-31"); got != "2016-12-31" {
+		t.Errorf("sv Date = %q", got)
+	}
+	if got := For("en").Date("2016-01-05"); got != "5 January 2016" {
+		t.Errorf("en Date = %q", got)
+	}
+	if got := For("en").Date("not a date"); got != "not a date" {
+		t.Errorf("invalid date should be returned as is, got %q", got)
+	}
+}
+
+func TestInt(t *testing.T) {
+	tests := []struct {
+		lang string
+		v    int64
+		want string
+	}{
+		{"sv", 0, "0"},
+		{"sv", 999, "999"},
+		{"sv", 1234567, "1 234 567"},
+		{"sv", -1000, "-1 000"},
+		{"en", 1234567, "1,234,567"},
+		{"en", -25000, "-25,000"},
+	}
+	for _, tt := range tests {
+		if got := For(tt.lang).Int(tt.v); got != tt.want {
+			t.Errorf("%s Int(%d) = %q, want %q", tt.lang, tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	if got := For("sv").Decimal("33.7"); got != "33,7" {
+		t.Errorf("sv Decimal = %q", got)
+	}
+	if got := For("en").Decimal("33.7"); got != "33.7" {
+		t.Errorf("en Decimal = %q", got)
+	}
+}
@@ -22,6 +22,15 @@ type AnnualReport struct {
 	BalanceSheet     BalanceSheet     `json:"balanceSheet"`
 	Notes            Notes            `json:"notes"`
 	Signatures       Signatures       `json:"signatures"`
+
+	// The revisionsberättelse that goes with the report; nil when the
+	// company is not required to have one.
+	AuditReport *AuditReport `json:"auditReport,omitempty"`
+
+	// Facts from a parsed report that the model has no field for, kept so
+	// that parse → edit → generate loses no data. The generator writes them
+	// in an "Övriga upplysningar" section.
+	Passthrough []PassthroughFact `json:"passthrough,omitempty"`
 }
 
 // Company holds basic company information.
@@ -29,6 +38,24 @@ type AnnualReport struct {
 type Company struct {
 	Name  string `json:"name"`  // se-cd-base:ForetagetsNamn
 	OrgNr string `json:"orgNr"` // se-cd-base:Organisationsnummer
+
+	// Optional company logo, shown on the cover page and in page headers.
+	Logo *Image `json:"logo,omitempty"`
+}
+
+// Image is a picture embedded in the iXBRL document as a base64 data URI.
+// Bolagsverket only accepts JPEG, PNG, SVG and GIF, and each image must be
+// smaller than 1 MB.
+type Image struct {
+	// MIME type, e.g. "image/png". Detected from Data when empty.
+	MediaType string `json:"mediaType,omitempty"`
+	// Raw image bytes (base64 in JSON).
+	Data []byte `json:"data,omitempty"`
+	// Path to an image file. The CLI loads it into Data, resolving relative
+	// paths against the directory of the JSON input.
+	Path string `json:"path,omitempty"`
+	// Alternative text for the <img> element.
+	Alt string `json:"alt,omitempty"`
 }
 
 // FiscalYear defines the reporting period.
@@ -49,11 +76,28 @@ type Meta struct {
 	// Entry point variant: "risbs", "risab", "raibs", or "raiab"
 	EntryPoint string `json:"entryPoint"`
 
+	// K2 taxonomy version, e.g. "2024-09-12"; empty for the current one.
+	// Older versions are used to regenerate reports filed with them.
+	TaxonomyVersion string `json:"taxonomyVersion,omitempty"`
+
 	// Software info for <meta> tags
 	Software        string `json:"software"`
 	SoftwareVersion string `json:"softwareVersion"`
 }
 
+// AuditReport describes the revisionsberättelse of a company that is
+// required to have one ("krav på revisionsberättelse"). redofri does not
+// write its content; an embedded revisionsberättelse is carried as
+// passthrough facts.
+type AuditReport struct {
+	// Embedded is set when the revisionsberättelse is part of the filed
+	// document; otherwise the auditor files it separately.
+	Embedded bool `json:"embedded"`
+
+	// Revisionsberättelse taxonomy version, e.g. "2020-12-01"
+	TaxonomyVersion string `json:"taxonomyVersion,omitempty"`
+}
+
 // PreviousYear holds data for the comparative period (föregående år).
 // This allows the same struct hierarchy to hold both current and previous year data.
 // In the model, most numeric fields use *int64 (pointer) to distinguish
@@ -461,6 +505,18 @@ type AccountingPolicies struct {
 
 	// se-gen-base:RedovisningsprinciperAnskaffningsvardeEgentillverkadevaror
 	ManufacturedGoodsPolicy string `json:"manufacturedGoodsPolicy,omitempty"`
+
+	// Ändrade jämförelsetal: comparative figures that differ from last
+	// year's filed report, with the reason they were restated.
+	Restatements []Restatement `json:"restatements,omitempty"`
+}
+
+// Restatement records why comparative figures were restated.
+type Restatement struct {
+	// The restated field, e.g. "incomeStatement.revenue.netSales.previous",
+	// or a section such as "balanceSheet.assets" for all fields below it.
+	Field         string `json:"field"`
+	Justification string `json:"justification"`
 }
 
 // DepreciationPolicy holds a single depreciation period declaration.
@@ -583,6 +639,34 @@ type Signatory struct {
 	Role string `json:"role,omitempty"`
 }
 
+// PassthroughFact is a fact, or a tuple of facts, that the model does not
+// know about.
+type PassthroughFact struct {
+	// QName of the concept, e.g. "se-gen-base:AndraUpplysningar"
+	Concept string `json:"concept"`
+	// Namespace URI of the concept's prefix. Only set for prefixes other
+	// than se-gen-base, se-cd-base and se-bol-base.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Context: "period0"–"period3" for the fiscal year and up to three
+	// previous years, "balans0"–"balans3" for their balance dates. Empty
+	// for tuples.
+	Context string `json:"context,omitempty"`
+	// Unit of numeric facts, e.g. "iso4217:SEK" or "xbrli:pure"
+	Unit string `json:"unit,omitempty"`
+	// Decimals of numeric facts, e.g. "INF" or "-3"
+	Decimals string `json:"decimals,omitempty"`
+	// Value: a decimal number such as "-1234.5" for numeric facts, else text
+	Value string `json:"value,omitempty"`
+	// Nil is set for facts tagged xsi:nil="true", which have no value
+	Nil bool `json:"nil,omitempty"`
+
+	// Tuple is set for tuples, whose facts and nested tuples are listed in
+	// Members in order.
+	Tuple   bool              `json:"tuple,omitempty"`
+	Members []PassthroughFact `json:"members,omitempty"`
+}
+
 // Helper function to create an int64 pointer (useful for populating the model).
 func Int64(v int64) *int64 {
 	return &v
@@ -0,0 +1,119 @@
+package pdf
+
+import (
+	"github.com/redofri/redofri/pkg/model"
+)
+
+// writeBalanceSheetHeader starts a balance sheet page with the title and
+// the column headings.
+func (r *renderer) writeBalanceSheetHeader(rep *model.AnnualReport, toc, side string) {
+	_, prevEnd := prevYearDates(rep.FiscalYear.StartDate, rep.FiscalYear.EndDate)
+
+	r.startSection(toc)
+	r.heading(r.t("Balansräkning"), sizeH2, 0)
+	r.paragraph(r.regular, sizeSmall, r.t("Balansräkningen visar bolagets tillgångar, eget kapital och skulder på balansdagen, jämfört med föregående år."))
+	r.headerRow(statementColumns(), []string{
+		r.t("Balansräkning"),
+		r.t("Not"),
+		r.date(rep.FiscalYear.EndDate),
+		r.date(prevEnd),
+	})
+	r.heading(r.t(side), sizeH4, 3*sizeBody*lineSpacing)
+}
+
+// writeBalanceSheetAssets writes the tillgångar side of the balansräkning.
+func (r *renderer) writeBalanceSheetAssets(rep *model.AnnualReport) {
+	bs := &rep.BalanceSheet
+	r.writeBalanceSheetHeader(rep, "balansräkning", "Tillgångar")
+
+	fa := &bs.Assets.FixedAssets
+	r.groupRow(r.t("Anläggningstillgångar"))
+
+	tang := &fa.Tangible
+	r.groupRow(r.t("Materiella anläggningstillgångar"))
+	r.statementRow("Byggnader och mark", noteRefs(tang.BuildingsAndLandNote), tang.BuildingsAndLand, false, rowStyle{}, false)
+	r.statementRow("Maskiner och andra tekniska anläggningar", noteRefs(tang.MachineryAndEquipmentNote), tang.MachineryAndEquipment, false, rowStyle{}, false)
+	r.statementRow("Inventarier, verktyg och installationer", noteRefs(tang.FixturesAndFittingsNote), tang.FixturesAndFittings, false, rowStyle{sum: true}, false)
+	r.statementRow("Summa materiella anläggningstillgångar", nil, tang.TotalTangible, false, rowStyle{bold: true}, false)
+
+	fin := &fa.Financial
+	r.groupRow(r.t("Finansiella anläggningstillgångar"))
+	r.statementRow("Andra långfristiga värdepappersinnehav", noteRefs(fin.OtherLongTermSecuritiesNote), fin.OtherLongTermSecurities, false, rowStyle{sum: true}, false)
+	r.statementRow("Summa finansiella anläggningstillgångar", nil, fin.TotalFinancial, false, rowStyle{bold: true}, false)
+	r.statementRow("Summa anläggningstillgångar", nil, fa.TotalFixedAssets, false, rowStyle{bold: true}, false)
+
+	ca := &bs.Assets.CurrentAssets
+	r.groupRow(r.t("Omsättningstillgångar"))
+
+	inv := &ca.Inventory
+	r.groupRow(r.t("Varulager <abbr>m.m.</abbr>"))
+	r.statementRow("Råvaror och förnödenheter", nil, inv.RawMaterials, false, rowStyle{}, false)
+	r.statementRow("Varor under tillverkning", nil, inv.WorkInProgress, false, rowStyle{}, false)
+	r.statementRow("Färdiga varor och handelsvaror", nil, inv.FinishedGoods, false, rowStyle{sum: true}, false)
+	r.statementRow("Summa varulager", nil, inv.TotalInventory, false, rowStyle{bold: true}, false)
+
+	rec := &ca.ShortTermReceivables
+	r.groupRow(r.t("Kortfristiga fordringar"))
+	r.statementRow("Kundfordringar", nil, rec.TradeReceivables, false, rowStyle{}, false)
+	r.statementRow("Övriga fordringar", nil, rec.OtherReceivables, false, rowStyle{}, false)
+	r.statementRow("Förutbetalda kostnader och upplupna intäkter", nil, rec.PrepaidExpenses, false, rowStyle{sum: true}, false)
+	r.statementRow("Summa kortfristiga fordringar", nil, rec.TotalShortTermReceivables, false, rowStyle{bold: true}, false)
+
+	cb := &ca.CashAndBank
+	r.groupRow(r.t("Kassa och bank"))
+	r.statementRow("Kassa och bank", nil, cb.CashAndBankExcl, false, rowStyle{sum: true}, false)
+	r.statementRow("Summa kassa och bank", nil, cb.TotalCashAndBank, false, rowStyle{bold: true}, false)
+	r.statementRow("Summa omsättningstillgångar", nil, ca.TotalCurrentAssets, false, rowStyle{bold: true}, false)
+
+	r.statementRow("Summa tillgångar", nil, bs.Assets.TotalAssets, false, rowStyle{total: true}, false)
+}
+
+// writeBalanceSheetEquityLiabilities writes the eget kapital och skulder
+// side of the balansräkning.
+func (r *renderer) writeBalanceSheetEquityLiabilities(rep *model.AnnualReport) {
+	el := &rep.BalanceSheet.EquityAndLiabilities
+	r.writeBalanceSheetHeader(rep, "", "Eget kapital och skulder")
+
+	eq := &el.Equity
+	r.groupRow(r.t("Eget kapital"))
+	r.groupRow(r.t("Bundet eget kapital"))
+	r.statementRow("Aktiekapital", nil, eq.ShareCapital, false, rowStyle{}, false)
+	r.statementRow("Reservfond", nil, eq.ReserveFund, false, rowStyle{sum: true}, false)
+	r.statementRow("Summa bundet eget kapital", nil, eq.TotalRestrictedEquity, false, rowStyle{bold: true}, false)
+	r.groupRow(r.t("Fritt eget kapital"))
+	r.statementRow("Balanserat resultat", nil, eq.RetainedEarnings, false, rowStyle{}, false)
+	r.statementRow("Årets resultat", nil, eq.NetIncome, false, rowStyle{sum: true}, false)
+	r.statementRow("Summa fritt eget kapital", nil, eq.TotalUnrestrictedEquity, false, rowStyle{bold: true}, false)
+	r.statementRow("Summa eget kapital", nil, eq.TotalEquity, false, rowStyle{bold: true}, false)
+
+	ur := &el.UntaxedReserves
+	r.groupRow(r.t("Obeskattade reserver"))
+	r.statementRow("Periodiseringsfonder", nil, ur.TaxAllocationReserves, false, rowStyle{}, false)
+	r.statementRow("Ackumulerade överavskrivningar", nil, ur.AccumulatedExcessDepreciation, false, rowStyle{sum: true}, false)
+	r.statementRow("Summa obeskattade reserver", nil, ur.TotalUntaxedReserves, false, rowStyle{bold: true}, false)
+
+	prov := &el.Provisions
+	r.groupRow(r.t("Avsättningar"))
+	r.statementRow("Avsättningar för pensioner och liknande förpliktelser enligt lagen (1967:531) om tryggande av pensionsutfästelse <abbr>m.m.</abbr>", nil,
+		prov.PensionProvisions, false, rowStyle{}, false)
+	r.statementRow("Övriga avsättningar", nil, prov.OtherProvisions, false, rowStyle{sum: true}, false)
+	r.statementRow("Summa avsättningar", nil, prov.TotalProvisions, false, rowStyle{bold: true}, false)
+
+	lt := &el.LongTermLiabilities
+	r.space(3)
+	r.need(3 * sizeBody * lineSpacing)
+	r.row(statementColumns(), []string{r.t("Långfristiga skulder"), formatNoteRefs(noteRefs(lt.LongTermLiabilitiesNote))}, rowStyle{bold: true})
+	r.statementRow("Övriga skulder till kreditinstitut", lt.BankLoansNotes, lt.BankLoans, false, rowStyle{}, false)
+	r.statementRow("Övriga skulder", nil, lt.OtherLongTermLiabilities, false, rowStyle{sum: true}, false)
+	r.statementRow("Summa långfristiga skulder", nil, lt.TotalLongTermLiabilities, false, rowStyle{bold: true}, false)
+
+	st := &el.ShortTermLiabilities
+	r.groupRow(r.t("Kortfristiga skulder"))
+	r.statementRow("Leverantörsskulder", nil, st.TradePayables, false, rowStyle{}, false)
+	r.statementRow("Skatteskulder", nil, st.TaxLiabilities, false, rowStyle{}, false)
+	r.statementRow("Övriga skulder", noteRefs(st.OtherShortTermLiabilitiesNote), st.OtherShortTermLiabilities, false, rowStyle{}, false)
+	r.statementRow("Upplupna kostnader och förutbetalda intäkter", nil, st.AccruedExpenses, false, rowStyle{sum: true}, false)
+	r.statementRow("Summa kortfristiga skulder", nil, st.TotalShortTermLiabilities, false, rowStyle{bold: true}, false)
+
+	r.statementRow("Summa eget kapital och skulder", nil, el.TotalEquityAndLiabilities, false, rowStyle{total: true}, false)
+}
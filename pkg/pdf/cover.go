@@ -0,0 +1,122 @@
+package pdf
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/redofri/redofri/pkg/model"
+)
+
+// tocEntries lists the table of contents in document order.
+var tocEntries = []string{"förvaltningsberättelse", "resultaträkning", "balansräkning", "noter"}
+
+// writeCoverPage writes the first page: company info, table of contents and
+// fastställelseintyg.
+func (r *renderer) writeCoverPage(rep *model.AnnualReport) {
+	r.startSection("")
+
+	if r.logo != nil {
+		h := 56.0
+		w := h * float64(r.logo.width) / float64(r.logo.height)
+		if w > 180 {
+			w = 180
+			h = w * float64(r.logo.height) / float64(r.logo.width)
+		}
+		r.y -= h
+		r.cur().image(marginLeft, r.y, w, h)
+		r.y -= 12
+	}
+
+	r.y -= sizeH2
+	r.cur().text(r.bold, sizeH2, marginLeft, r.y, rep.Company.Name)
+	r.y -= sizeBody * lineSpacing
+	r.cur().text(r.regular, sizeBody, marginLeft, r.y, r.t("Org nr")+" "+rep.Company.OrgNr)
+	r.y -= 40
+
+	yearLabel := fiscalYearLabel(rep.FiscalYear.EndDate)
+	r.heading(r.lbl.Tf("Årsredovisning för räkenskapsåret %s", yearLabel), sizeTitle, 0)
+	r.y -= 6
+
+	metaCols := []column{{width: 140}, {width: contentWidth - 140}}
+	for _, item := range [][2]string{
+		{r.t("Bolag"), rep.Company.Name},
+		{r.t("Organisationsnummer"), rep.Company.OrgNr},
+		{r.t("Räkenskapsår"), r.date(rep.FiscalYear.StartDate) + " - " + r.date(rep.FiscalYear.EndDate)},
+		{r.t("Valuta"), rep.Meta.Currency},
+	} {
+		r.y -= sizeBody
+		r.cur().text(r.regular, sizeBody, marginLeft, r.y, item[0])
+		r.cur().text(r.bold, sizeBody, marginLeft+metaCols[0].width, r.y, item[1])
+		r.y -= sizeBody*lineSpacing - sizeBody
+	}
+	r.y -= 18
+
+	r.paragraph(r.regular, sizeBody, rep.ManagementReport.IntroText+".")
+	r.y -= 6
+
+	r.writeTOC()
+
+	r.paragraph(r.regular, sizeSmall, r.t("Om inte annat särskilt anges, redovisas alla belopp i hela kronor. Uppgifter inom parentes avser föregående år."))
+	r.y -= 12
+
+	r.writeCertification(rep)
+}
+
+// writeTOC writes the table of contents. The page numbers are only known
+// once the whole document is laid out, so they are drawn later.
+func (r *renderer) writeTOC() {
+	cols := []column{{width: contentWidth - 60}, {width: 60, align: alignRight}}
+	r.headerRow(cols, []string{r.t("Innehåll"), r.t("Sida")})
+
+	r.need(float64(len(tocEntries)) * sizeBody * lineSpacing)
+	p := r.cur()
+	for _, entry := range tocEntries {
+		r.y -= sizeBody
+		p.text(r.regular, sizeBody, marginLeft, r.y, "- "+r.t(entry))
+		y := r.y
+		key := entry
+		p.deferred = append(p.deferred, func() {
+			num, ok := r.sectionPages[key]
+			if !ok {
+				return
+			}
+			s := strconv.Itoa(num)
+			p.text(r.regular, sizeBody, marginLeft+contentWidth-r.regular.textWidth(s, sizeBody), y, s)
+		})
+		r.y -= sizeBody*lineSpacing - sizeBody
+	}
+	r.y -= 12
+}
+
+// writeCertification writes the fastställelseintyg block.
+func (r *renderer) writeCertification(rep *model.AnnualReport) {
+	cert := &rep.Certification
+
+	r.heading(r.t("Fastställelseintyg"), sizeH4, 6*sizeBody*lineSpacing)
+	r.paragraph(r.regular, sizeBody, cert.ConfirmationText+" "+cert.MeetingDate+".")
+	r.paragraph(r.regular, sizeBody, cert.DispositionDecision)
+	r.paragraph(r.regular, sizeBody, cert.OriginalContentCertification)
+	r.paragraphAt(r.bold, sizeBody, marginLeft, contentWidth, cert.ElectronicSignatureLabel+":")
+	r.paragraphAt(r.regular, sizeBody, marginLeft, contentWidth,
+		cert.Signatory.FirstName+" "+cert.Signatory.LastName+", "+cert.Signatory.Role)
+	r.paragraph(r.regular, sizeBody, cert.SigningDate)
+}
+
+// fiscalYearLabel returns the year of the fiscal year end date for display.
+func fiscalYearLabel(endDate string) string {
+	if len(endDate) < 4 {
+		return endDate
+	}
+	return endDate[:4]
+}
+
+// prevYearDates computes the previous fiscal year dates, assuming a fiscal
+// year of the same length shifted back one year.
+func prevYearDates(startDate, endDate string) (string, string) {
+	s, err1 := time.Parse("2006-01-02", startDate)
+	e, err2 := time.Parse("2006-01-02", endDate)
+	if err1 != nil || err2 != nil {
+		return "", ""
+	}
+	return s.AddDate(-1, 0, 0).Format("2006-01-02"), e.AddDate(-1, 0, 0).Format("2006-01-02")
+}
@@ -0,0 +1,149 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+)
+
+// document collects numbered PDF objects and writes them out with a
+// cross-reference table. Object n is stored at objects[n-1].
+type document struct {
+	objects [][]byte
+}
+
+// alloc reserves an object number so that objects can refer to each other
+// before their contents are known.
+func (d *document) alloc() int {
+	d.objects = append(d.objects, nil)
+	return len(d.objects)
+}
+
+// set stores the body of a previously allocated object.
+func (d *document) set(id int, body string) {
+	d.objects[id-1] = []byte(body)
+}
+
+// add allocates and stores an object in one step.
+func (d *document) add(body string) int {
+	id := d.alloc()
+	d.set(id, body)
+	return id
+}
+
+// setStream stores a stream object. dict holds the dictionary entries
+// without the surrounding << >> and without /Length.
+func (d *document) setStream(id int, dict string, data []byte, compress bool) {
+	if compress {
+		var buf bytes.Buffer
+		zw := zlib.NewWriter(&buf)
+		zw.Write(data)
+		zw.Close()
+		data = buf.Bytes()
+		dict += " /Filter /FlateDecode"
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "<<%s /Length %d>>\nstream\n", dict, len(data))
+	b.Write(data)
+	b.WriteString("\nendstream")
+	d.objects[id-1] = b.Bytes()
+}
+
+// addStream allocates and stores a stream object.
+func (d *document) addStream(dict string, data []byte, compress bool) int {
+	id := d.alloc()
+	d.setStream(id, dict, data, compress)
+	return id
+}
+
+// writeTo writes the complete file. The binary comment after the header
+// marks the file as binary for transfer programs, as PDF/A requires.
+func (d *document) writeTo(w io.Writer, root, info int, fileID []byte) error {
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	offsets := make([]int, len(d.objects))
+	for i, obj := range d.objects {
+		if obj == nil {
+			return fmt.Errorf("pdf: object %d was allocated but never written", i+1)
+		}
+		offsets[i] = b.Len()
+		fmt.Fprintf(&b, "%d 0 obj\n", i+1)
+		b.Write(obj)
+		b.WriteString("\nendobj\n")
+	}
+
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n", len(d.objects)+1)
+	b.WriteString("0000000000 65535 f \n")
+	for _, off := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&b, "trailer\n<</Size %d /Root %d 0 R /Info %d 0 R /ID [<%x> <%x>]>>\n",
+		len(d.objects)+1, root, info, fileID, fileID)
+	fmt.Fprintf(&b, "startxref\n%d\n%%%%EOF\n", xref)
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// ref formats an indirect reference.
+func ref(id int) string {
+	return fmt.Sprintf("%d 0 R", id)
+}
+
+// textString encodes s as a PDF text string: a literal string for plain
+// ASCII, otherwise UTF-16BE with a byte order mark.
+func textString(s string) string {
+	ascii := true
+	for _, r := range s {
+		if r >= 0x80 || r < 0x20 {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		return "(" + escapeLiteral([]byte(s)) + ")"
+	}
+	var b strings.Builder
+	b.WriteString("<FEFF")
+	for _, u := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&b, "%04X", u)
+	}
+	b.WriteString(">")
+	return b.String()
+}
+
+// escapeLiteral escapes the bytes of a literal string.
+func escapeLiteral(s []byte) string {
+	var b strings.Builder
+	for _, c := range s {
+		switch c {
+		case '\\', '(', ')':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\n':
+			b.WriteString(`\n`)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// name sanitises s for use as a PDF name object (without the leading slash).
+func name(s string) string {
+	var b strings.Builder
+	for _, c := range []byte(s) {
+		if c <= ' ' || c >= 0x7f || strings.IndexByte("()<>[]{}/%#", c) >= 0 {
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
@@ -0,0 +1,202 @@
+package pdf
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/unicode/norm"
+)
+
+// font is a simple (single-byte) font using WinAnsiEncoding, which covers
+// Swedish and English text. Widths are in thousandths of an em.
+type font struct {
+	resource string // resource name in page dictionaries, e.g. "F1"
+	baseFont string
+	widths   [256]int
+	ascent   int
+	descent  int
+
+	// ttf is the embedded font program; nil for the standard Helvetica
+	// fonts that every PDF reader provides.
+	ttf *trueType
+}
+
+// width returns the width of encoded text at the given size, in points.
+func (f *font) width(text []byte, size float64) float64 {
+	w := 0
+	for _, c := range text {
+		w += f.widths[c]
+	}
+	return float64(w) * size / 1000
+}
+
+// textWidth encodes s and returns its width in points.
+func (f *font) textWidth(s string, size float64) float64 {
+	return f.width(encodeWinAnsi(s), size)
+}
+
+// encodeWinAnsi converts UTF-8 text to WinAnsiEncoding (Windows-1252).
+// Characters outside the encoding are replaced by their unaccented base
+// letter when there is one, otherwise by '?'.
+func encodeWinAnsi(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r == '\t' || r == '\n' || r == '\r':
+			out = append(out, ' ')
+			continue
+		case r == '\u2212': // minus sign
+			out = append(out, '-')
+			continue
+		case r < 0x80:
+			out = append(out, byte(r))
+			continue
+		}
+		if b, ok := charmap.Windows1252.EncodeRune(r); ok {
+			out = append(out, b)
+			continue
+		}
+		if base := []rune(norm.NFD.String(string(r))); len(base) > 0 && base[0] < 0x80 {
+			out = append(out, byte(base[0]))
+			continue
+		}
+		out = append(out, '?')
+	}
+	return out
+}
+
+// helvetica returns the standard Helvetica or Helvetica-Bold font.
+func helvetica(resource string, bold bool) *font {
+	f := &font{resource: resource, baseFont: "Helvetica", ascent: 718, descent: -207}
+	ascii := helveticaWidths
+	if bold {
+		f.baseFont = "Helvetica-Bold"
+		ascii = helveticaBoldWidths
+	}
+	for c := 32; c < 256; c++ {
+		f.widths[c] = 556
+		if c < 127 {
+			f.widths[c] = ascii[c-32]
+			continue
+		}
+		r := charmap.Windows1252.DecodeByte(byte(c))
+		if w, ok := helveticaExtraWidths[r]; ok {
+			f.widths[c] = w
+			continue
+		}
+		// Accented letters are as wide as their base letter.
+		if base := []rune(norm.NFD.String(string(r))); len(base) > 1 && base[0] >= 32 && base[0] < 127 {
+			f.widths[c] = ascii[base[0]-32]
+		}
+	}
+	return f
+}
+
+// helveticaWidths holds the Helvetica widths for ' ' through '~'.
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+// helveticaBoldWidths holds the Helvetica-Bold widths for ' ' through '~'.
+var helveticaBoldWidths = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
+
+// helveticaExtraWidths covers the non-letter characters above 0x7f that
+// differ from the 556 default. The values are shared by both weights, which
+// is close enough for layout.
+var helveticaExtraWidths = map[rune]int{
+	'\u00a0': 278, // no-break space
+	'‘':      222,
+	'’':      222,
+	'“':      333,
+	'”':      333,
+	'•':      350,
+	'—':      1000,
+	'…':      1000,
+	'§':      556,
+	'©':      737,
+	'°':      400,
+	'×':      584,
+	'÷':      584,
+	'Æ':      1000,
+	'æ':      889,
+	'Ø':      778,
+	'ø':      611,
+	'ß':      611,
+}
+
+// newTrueTypeFont builds a font that embeds the given TrueType program.
+func newTrueTypeFont(resource string, data []byte) (*font, error) {
+	ttf, err := parseTrueType(data)
+	if err != nil {
+		return nil, err
+	}
+	f := &font{
+		resource: resource,
+		baseFont: ttf.postScriptName,
+		ascent:   ttf.scale(ttf.ascent),
+		descent:  ttf.scale(ttf.descent),
+		ttf:      ttf,
+	}
+	for c := 32; c < 256; c++ {
+		r := charmap.Windows1252.DecodeByte(byte(c))
+		f.widths[c] = ttf.scale(int(ttf.advance(ttf.glyph(r))))
+	}
+	return f, nil
+}
+
+// writeFont adds the font dictionary (and for TrueType fonts the descriptor
+// and font program) to the document and returns the font object number.
+func (d *document) writeFont(f *font) int {
+	if f.ttf == nil {
+		return d.add(fmt.Sprintf("<</Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding>>", f.baseFont))
+	}
+	t := f.ttf
+	file := d.addStream(fmt.Sprintf(" /Length1 %d", len(t.data)), t.data, true)
+	flags := 32 // nonsymbolic
+	if t.fixedPitch {
+		flags |= 1
+	}
+	if t.italicAngle != 0 {
+		flags |= 64
+	}
+	descriptor := d.add(fmt.Sprintf("<</Type /FontDescriptor /FontName /%s /Flags %d /FontBBox [%d %d %d %d] /ItalicAngle %s /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %s>>",
+		f.baseFont, flags,
+		t.scale(t.bbox[0]), t.scale(t.bbox[1]), t.scale(t.bbox[2]), t.scale(t.bbox[3]),
+		number(t.italicAngle), f.ascent, f.descent, t.scale(t.capHeight), ref(file)))
+
+	var widths strings.Builder
+	for c := 32; c < 256; c++ {
+		if c > 32 {
+			widths.WriteByte(' ')
+		}
+		fmt.Fprintf(&widths, "%d", f.widths[c])
+	}
+	return d.add(fmt.Sprintf("<</Type /Font /Subtype /TrueType /BaseFont /%s /FirstChar 32 /LastChar 255 /Widths [%s] /Encoding /WinAnsiEncoding /FontDescriptor %s>>",
+		f.baseFont, widths.String(), ref(descriptor)))
+}
+
+// number formats a coordinate or size with at most two decimals.
+func number(v float64) string {
+	v = math.Round(v*100) / 100
+	s := fmt.Sprintf("%.2f", v)
+	s = strings.TrimRight(s, "0")
+	s = strings.TrimSuffix(s, ".")
+	if s == "-0" {
+		s = "0"
+	}
+	return s
+}
@@ -0,0 +1,62 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+
+	"github.com/redofri/redofri/pkg/ixbrl"
+	"github.com/redofri/redofri/pkg/model"
+)
+
+// logoImage is a decoded raster image stored as 8-bit RGB samples.
+type logoImage struct {
+	width, height int
+	rgb           []byte
+}
+
+// loadLogo checks the logo against the same rules as the iXBRL document and
+// decodes it. SVG logos cannot be drawn without an SVG renderer and are
+// left out of the PDF; they return nil without an error.
+func loadLogo(img *model.Image) (*logoImage, error) {
+	mediaType, err := ixbrl.CheckImage(img)
+	if err != nil {
+		return nil, err
+	}
+	if mediaType == "image/svg+xml" {
+		return nil, nil
+	}
+	decoded, _, err := image.Decode(bytes.NewReader(img.Data))
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", mediaType, err)
+	}
+
+	// Transparency is not allowed in PDF/A-1, so the image is flattened
+	// onto a white background.
+	b := decoded.Bounds()
+	logo := &logoImage{width: b.Dx(), height: b.Dy(), rgb: make([]byte, 0, 3*b.Dx()*b.Dy())}
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := color.NRGBA64Model.Convert(decoded.At(x, y)).(color.NRGBA64)
+			a := uint32(c.A)
+			for _, v := range []uint16{c.R, c.G, c.B} {
+				blended := (uint32(v)*a + 0xffff*(0xffff-a)) / 0xffff
+				logo.rgb = append(logo.rgb, byte(blended>>8))
+			}
+		}
+	}
+	if logo.width == 0 || logo.height == 0 {
+		return nil, fmt.Errorf("image is empty")
+	}
+	return logo, nil
+}
+
+// writeImage adds the image XObject and returns its object number.
+func (d *document) writeImage(img *logoImage) int {
+	return d.addStream(fmt.Sprintf(" /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8",
+		img.width, img.height), img.rgb, true)
+}
@@ -0,0 +1,110 @@
+package pdf
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/redofri/redofri/pkg/model"
+)
+
+// statementColumns returns the label, note and two amount columns used by
+// the income statement and balance sheet.
+func statementColumns() []column {
+	return []column{
+		{width: contentWidth - 36 - 2*88},
+		{width: 36},
+		{width: 88, align: alignRight},
+		{width: 88, align: alignRight},
+	}
+}
+
+// writeIncomeStatement writes the resultaträkning.
+func (r *renderer) writeIncomeStatement(rep *model.AnnualReport) {
+	is := &rep.IncomeStatement
+	prevStart, prevEnd := prevYearDates(rep.FiscalYear.StartDate, rep.FiscalYear.EndDate)
+
+	r.startSection("resultaträkning")
+	r.heading(r.t("Resultaträkning"), sizeH2, 0)
+	r.paragraph(r.regular, sizeSmall, r.t("Rapporten visar bolagets intäkter, kostnader och resultat för aktuellt och föregående räkenskapsår."))
+
+	cols := statementColumns()
+	r.headerRow(cols, []string{
+		r.t("Resultaträkning"),
+		r.t("Not"),
+		r.date(rep.FiscalYear.StartDate) + "\n–" + r.date(rep.FiscalYear.EndDate),
+		r.date(prevStart) + "\n–" + r.date(prevEnd),
+	})
+
+	rev := &is.Revenue
+	r.groupRow(r.t("Rörelseintäkter, lagerförändringar <abbr>m.m.</abbr>"))
+	r.statementRow("Nettoomsättning", nil, rev.NetSales, false, rowStyle{}, false)
+	r.statementRow("Förändring av lager av produkter i arbete, färdiga varor och pågående arbete för annans räkning", nil,
+		rev.InventoryChange, false, rowStyle{}, false)
+	r.statementRow("Övriga rörelseintäkter", nil, rev.OtherOperatingIncome, false, rowStyle{sum: true}, false)
+	r.statementRow("Summa rörelseintäkter, lagerförändringar <abbr>m.m.</abbr>", nil, rev.TotalRevenue, false, rowStyle{bold: true}, true)
+
+	exp := &is.Expenses
+	r.groupRow(r.t("Rörelsekostnader"))
+	r.statementRow("Råvaror och förnödenheter", nil, exp.RawMaterials, true, rowStyle{}, false)
+	r.statementRow("Handelsvaror", nil, exp.TradingGoods, true, rowStyle{}, false)
+	r.statementRow("Övriga externa kostnader", nil, exp.OtherExternalExpenses, true, rowStyle{}, false)
+	r.statementRow("Personalkostnader", noteRefs(exp.PersonnelExpensesNote), exp.PersonnelExpenses, true, rowStyle{}, false)
+	r.statementRow("Av- och nedskrivningar av materiella och immateriella anläggningstillgångar", nil,
+		exp.DepreciationAmortization, true, rowStyle{}, false)
+	r.statementRow("Övriga rörelsekostnader", nil, exp.OtherOperatingExpenses, true, rowStyle{sum: true}, false)
+	r.statementRow("Summa rörelsekostnader", nil, exp.TotalExpenses, true, rowStyle{bold: true}, true)
+	r.statementRow("Rörelseresultat", nil, is.OperatingResult, false, rowStyle{bold: true}, true)
+
+	fi := &is.FinancialItems
+	r.groupRow(r.t("Finansiella poster"))
+	r.statementRow("Resultat från övriga finansiella anläggningstillgångar", nil, fi.ResultOtherFinancialAssets, false, rowStyle{}, false)
+	r.statementRow("Övriga ränteintäkter och liknande resultatposter", nil, fi.OtherInterestIncome, false, rowStyle{}, false)
+	r.statementRow("Räntekostnader och liknande resultatposter", nil, fi.InterestExpenses, true, rowStyle{sum: true}, false)
+	r.statementRow("Summa finansiella poster", nil, fi.TotalFinancialItems, false, rowStyle{bold: true}, true)
+	r.statementRow("Resultat efter finansiella poster", nil, is.ResultAfterFinancialItems, false, rowStyle{bold: true}, true)
+
+	// Appropriations are tagged with sign="-" and shown with a minus sign.
+	ap := &is.Appropriations
+	r.groupRow(r.t("Bokslutsdispositioner"))
+	r.statementRow("Förändring av periodiseringsfonder", nil, ap.TaxAllocationReserve, true, rowStyle{}, false)
+	r.statementRow("Förändring av överavskrivningar", nil, ap.ExcessDepreciation, true, rowStyle{sum: true}, false)
+	r.statementRow("Summa bokslutsdispositioner", nil, ap.TotalAppropriations, true, rowStyle{bold: true}, true)
+	r.statementRow("Resultat före skatt", nil, is.ResultBeforeTax, false, rowStyle{bold: true}, true)
+
+	r.groupRow(r.t("Skatter"))
+	r.statementRow("Skatt på årets resultat", nil, is.Tax.IncomeTax, true, rowStyle{sum: true}, false)
+	r.statementRow("Årets resultat", nil, is.NetResult, false, rowStyle{total: true}, true)
+}
+
+// statementRow writes a row of the income statement or balance sheet.
+// label is the Swedish display text; negative shows amounts with a leading
+// "-" like the iXBRL does for expenses. Rows without values are skipped
+// unless always is set.
+func (r *renderer) statementRow(label string, notes []int, yc model.YearComparison, negative bool, style rowStyle, always bool) {
+	if yc.Current == nil && yc.Previous == nil && !always {
+		return
+	}
+	r.row(statementColumns(), []string{
+		r.t(label),
+		formatNoteRefs(notes),
+		r.amount(yc.Current, negative),
+		r.amount(yc.Previous, negative),
+	}, style)
+}
+
+// noteRefs returns a single note reference, or nil for 0.
+func noteRefs(n int) []int {
+	if n <= 0 {
+		return nil
+	}
+	return []int{n}
+}
+
+// formatNoteRefs joins note numbers for the Not column.
+func formatNoteRefs(notes []int) string {
+	parts := make([]string, len(notes))
+	for i, n := range notes {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,306 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// A4 page geometry in points.
+const (
+	pageWidth    = 595.28
+	pageHeight   = 841.89
+	marginLeft   = 56.7
+	marginRight  = 56.7
+	marginBottom = 56.7
+	headerTop    = 42.0 // distance from the top edge to the header baseline
+	contentTop   = 96.0 // distance from the top edge to the first content line
+	contentWidth = pageWidth - marginLeft - marginRight
+)
+
+// Font sizes.
+const (
+	sizeBody    = 9.5
+	sizeSmall   = 8.0
+	sizeTitle   = 20.0
+	sizeH2      = 15.0
+	sizeH3      = 11.5
+	sizeH4      = 10.0
+	lineSpacing = 1.35 // leading as a multiple of the font size
+)
+
+// page is one A4 page. Content is drawn while laying out; the page header
+// is drawn by renderer.write once the total page count is known.
+type page struct {
+	content bytes.Buffer
+	logo    bool // page content uses the logo image
+
+	// deferred draws content that depends on later pages, such as the
+	// page numbers in the table of contents.
+	deferred []func()
+}
+
+// text draws encoded text with its baseline starting at (x, y).
+func (p *page) text(f *font, size, x, y float64, s string) {
+	fmt.Fprintf(&p.content, "BT /%s %s Tf %s %s Td (%s) Tj ET\n",
+		f.resource, number(size), number(x), number(y), escapeLiteral(encodeWinAnsi(s)))
+}
+
+// rule draws a horizontal line.
+func (p *page) rule(x1, x2, y, width float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s m %s %s l S\n",
+		number(width), number(x1), number(y), number(x2), number(y))
+}
+
+// image draws the logo XObject scaled to w×h with its lower left corner at (x, y).
+func (p *page) image(x, y, w, h float64) {
+	p.logo = true
+	fmt.Fprintf(&p.content, "q %s 0 0 %s %s %s cm /Im1 Do Q\n", number(w), number(h), number(x), number(y))
+}
+
+// align is the horizontal alignment of a table cell.
+type align int
+
+const (
+	alignLeft align = iota
+	alignRight
+)
+
+// column describes one table column.
+type column struct {
+	width float64
+	align align
+}
+
+// rowStyle controls how a table row is emphasised. It mirrors the CSS
+// classes of the iXBRL tables: "sum" underlines the amounts that are summed
+// in the next row, "sub-sum" rows are bold, "total" rows are bold with a
+// double rule.
+type rowStyle struct {
+	bold  bool
+	sum   bool // thin rule under the amount cells
+	total bool // double rule under the amount cells
+}
+
+// cursor advances through the pages of the document.
+type cursor struct {
+	regular, bold *font
+	pages         []*page
+	y             float64 // baseline of the next line, from the bottom edge
+}
+
+// cur returns the page being laid out.
+func (c *cursor) cur() *page {
+	return c.pages[len(c.pages)-1]
+}
+
+// newPage starts a page and moves to the top of its content area.
+func (c *cursor) newPage() {
+	c.pages = append(c.pages, &page{})
+	c.y = pageHeight - contentTop
+}
+
+// need starts a new page unless h points of content fit on the current one.
+func (c *cursor) need(h float64) {
+	if c.y-h < marginBottom {
+		c.newPage()
+	}
+}
+
+// space adds vertical space, ignored at the top of a page.
+func (c *cursor) space(h float64) {
+	if c.y < pageHeight-contentTop {
+		c.y -= h
+	}
+}
+
+// heading draws a bold heading. It keeps at least keep points of following
+// content on the same page.
+func (c *cursor) heading(s string, size, keep float64) {
+	lines := wrap(c.bold, size, plainText(s), contentWidth)
+	lead := size * lineSpacing
+	c.space(size * 0.6)
+	c.need(lead*float64(len(lines)) + keep)
+	for _, l := range lines {
+		c.y -= size
+		c.cur().text(c.bold, size, marginLeft, c.y, l)
+		c.y -= lead - size
+	}
+	c.y -= size * 0.3
+}
+
+// paragraph draws wrapped text across as many pages as it needs.
+func (c *cursor) paragraph(f *font, size float64, s string) {
+	c.paragraphAt(f, size, marginLeft, contentWidth, s)
+	c.y -= size * 0.6
+}
+
+// paragraphAt draws wrapped text in a column starting at x.
+func (c *cursor) paragraphAt(f *font, size, x, width float64, s string) {
+	lead := size * lineSpacing
+	for _, l := range wrap(f, size, s, width) {
+		c.need(lead)
+		c.y -= size
+		c.cur().text(f, size, x, c.y, l)
+		c.y -= lead - size
+	}
+}
+
+// html draws rich text stored as HTML (<p> paragraphs) in the model.
+func (c *cursor) html(s string) {
+	for _, p := range htmlParagraphs(s) {
+		c.paragraph(c.regular, sizeBody, p)
+	}
+}
+
+// row draws a table row. The first cell wraps within its column; the other
+// cells are single line. Empty cells are left blank.
+func (c *cursor) row(cols []column, cells []string, style rowStyle) {
+	f := c.regular
+	if style.bold || style.total {
+		f = c.bold
+	}
+	size := sizeBody
+	lead := size * lineSpacing
+	lines := wrap(f, size, plainText(cells[0]), cols[0].width-6)
+	h := lead*float64(len(lines)) + 2
+	c.need(h)
+
+	top := c.y
+	for i, l := range lines {
+		c.cur().text(f, size, marginLeft, top-size-float64(i)*lead, l)
+	}
+
+	// Amounts line up with the last line of a wrapped label.
+	base := top - size - float64(len(lines)-1)*lead
+	x := marginLeft + cols[0].width
+	for i := 1; i < len(cols) && i < len(cells); i++ {
+		col := cols[i]
+		if cell := plainText(cells[i]); cell != "" {
+			switch col.align {
+			case alignRight:
+				c.cur().text(f, size, x+col.width-f.textWidth(cell, size), base, cell)
+			default:
+				c.cur().text(f, size, x+4, base, cell)
+			}
+			if col.align == alignRight && (style.sum || style.total) {
+				c.cur().rule(x+8, x+col.width, base-3, 0.5)
+				if style.total {
+					c.cur().rule(x+8, x+col.width, base-5, 0.5)
+				}
+			}
+		}
+		x += col.width
+	}
+	c.y = top - h
+}
+
+// headerRow draws the column headings of a table in bold, allowing each
+// heading to wrap within its column.
+func (c *cursor) headerRow(cols []column, cells []string) {
+	size := sizeSmall
+	lead := size * lineSpacing
+	wrapped := make([][]string, len(cells))
+	n := 1
+	for i, cell := range cells {
+		var lines []string
+		for _, part := range strings.Split(plainText(cell), "\n") {
+			lines = append(lines, wrap(c.bold, size, part, cols[i].width-4)...)
+		}
+		wrapped[i] = lines
+		if len(lines) > n {
+			n = len(lines)
+		}
+	}
+	h := lead*float64(n) + 4
+	c.need(h + 3*sizeBody*lineSpacing)
+
+	top := c.y
+	x := marginLeft
+	for i, lines := range wrapped {
+		col := cols[i]
+		for j, l := range lines {
+			y := top - size - float64(j)*lead
+			if col.align == alignRight {
+				c.cur().text(c.bold, size, x+col.width-c.bold.textWidth(l, size), y, l)
+			} else {
+				c.cur().text(c.bold, size, x, y, l)
+			}
+		}
+		x += col.width
+	}
+	c.y = top - h
+	c.cur().rule(marginLeft, marginLeft+contentWidth, c.y+2, 0.5)
+	c.y -= 2
+}
+
+// groupRow draws a bold row heading that spans the table.
+func (c *cursor) groupRow(s string) {
+	size := sizeBody
+	c.space(3)
+	c.need(3 * size * lineSpacing)
+	c.y -= size
+	c.cur().text(c.bold, size, marginLeft, c.y, plainText(s))
+	c.y -= size*lineSpacing - size
+}
+
+// wrap breaks text into lines no wider than width. Words longer than a line
+// are broken between characters.
+func wrap(f *font, size float64, s string, width float64) []string {
+	words := strings.Fields(s)
+	if len(words) == 0 {
+		return []string{""}
+	}
+	var lines []string
+	line := ""
+	for _, w := range words {
+		candidate := w
+		if line != "" {
+			candidate = line + " " + w
+		}
+		if f.textWidth(candidate, size) <= width {
+			line = candidate
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+		for f.textWidth(w, size) > width {
+			n := len([]rune(w))
+			for n > 1 && f.textWidth(string([]rune(w)[:n]), size) > width {
+				n--
+			}
+			lines = append(lines, string([]rune(w)[:n]))
+			w = string([]rune(w)[n:])
+		}
+		line = w
+	}
+	return append(lines, line)
+}
+
+var (
+	tagRe       = regexp.MustCompile(`<[^>]*>`)
+	breakRe     = regexp.MustCompile(`(?i)<br\s*/?>`)
+	paragraphRe = regexp.MustCompile(`(?i)</p\s*>|<br\s*/?>`)
+)
+
+// plainText strips markup such as <abbr> from a display label. Line breaks
+// written as <br /> become newlines.
+func plainText(s string) string {
+	s = breakRe.ReplaceAllString(s, "\n")
+	return html.UnescapeString(tagRe.ReplaceAllString(s, ""))
+}
+
+// htmlParagraphs splits HTML text into plain paragraphs.
+func htmlParagraphs(s string) []string {
+	var out []string
+	for _, p := range paragraphRe.Split(s, -1) {
+		p = strings.Join(strings.Fields(html.UnescapeString(tagRe.ReplaceAllString(p, " "))), " ")
+		if p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
@@ -0,0 +1,140 @@
+package pdf
+
+import (
+	"github.com/redofri/redofri/pkg/model"
+)
+
+// writeManagementReport writes the förvaltningsberättelse.
+func (r *renderer) writeManagementReport(rep *model.AnnualReport) {
+	mr := &rep.ManagementReport
+
+	r.startSection("förvaltningsberättelse")
+	r.heading(r.t("Förvaltningsberättelse"), sizeH2, 0)
+	r.heading(r.t("Verksamheten"), sizeH3, 0)
+	r.heading(r.t("Allmänt om verksamheten"), sizeH4, 2*sizeBody)
+	r.html(mr.BusinessDescription)
+
+	r.heading(r.t("Väsentliga händelser under räkenskapsåret"), sizeH4, 2*sizeBody)
+	r.paragraph(r.regular, sizeBody, mr.SignificantEvents)
+
+	r.writeMultiYearOverview(rep)
+	r.writeEquityChanges(rep)
+	r.writeProfitDisposition(rep)
+
+	if mr.BoardDividendStatement != "" {
+		r.heading(r.t("Styrelsens yttrande över den föreslagna vinstutdelningen"), sizeH3, 2*sizeBody)
+		r.html(mr.BoardDividendStatement)
+	}
+}
+
+// writeMultiYearOverview writes the flerårsöversikt table.
+func (r *renderer) writeMultiYearOverview(rep *model.AnnualReport) {
+	myo := &rep.ManagementReport.MultiYearOverview
+	if len(myo.Years) == 0 {
+		return
+	}
+
+	yearWidth := 70.0
+	cols := []column{{width: contentWidth - yearWidth*float64(len(myo.Years))}}
+	header := []string{""}
+	for _, y := range myo.Years {
+		cols = append(cols, column{width: yearWidth, align: alignRight})
+		header = append(header, y.Year)
+	}
+
+	r.heading(r.t("Flerårsöversikt"), sizeH3, 4*sizeBody*lineSpacing)
+	r.headerRow(cols, header)
+
+	netSales := []string{r.t("Nettoomsättning, <abbr>tkr</abbr>")}
+	result := []string{r.t("Resultat efter finansiella poster, <abbr>tkr</abbr>")}
+	solidity := []string{r.t("Soliditet, %")}
+	for _, y := range myo.Years {
+		netSales = append(netSales, r.tkr(y.NetSales))
+		result = append(result, r.tkr(y.ResultAfterFinancialItems))
+		s := ""
+		if y.Solidity != nil {
+			s = r.lbl.Decimal(*y.Solidity)
+		}
+		solidity = append(solidity, s)
+	}
+	r.row(cols, netSales, rowStyle{})
+	r.row(cols, result, rowStyle{})
+	r.row(cols, solidity, rowStyle{})
+
+	if myo.Comment != "" {
+		r.y -= 4
+		r.paragraph(r.regular, sizeBody, myo.Comment)
+	}
+}
+
+// tkr formats an optional amount in thousands of kronor.
+func (r *renderer) tkr(v *int64) string {
+	if v == nil {
+		return ""
+	}
+	return r.lbl.Int(*v / 1000)
+}
+
+// writeEquityChanges writes the förändringar i eget kapital table.
+func (r *renderer) writeEquityChanges(rep *model.AnnualReport) {
+	ec := &rep.ManagementReport.EquityChanges
+
+	amountWidth := 68.0
+	cols := []column{{width: contentWidth - 5*amountWidth}}
+	header := []string{""}
+	for _, col := range []string{"Aktiekapital", "Reservfond", "Balanserat resultat", "Årets resultat", "Totalt"} {
+		cols = append(cols, column{width: amountWidth, align: alignRight})
+		header = append(header, r.t(col))
+	}
+
+	r.heading(r.t("Förändringar i eget kapital"), sizeH3, 4*sizeBody*lineSpacing)
+	r.headerRow(cols, header)
+
+	r.row(cols, []string{r.t("Belopp vid årets ingång"),
+		r.amount(ec.OpeningShareCapital, false),
+		r.amount(ec.OpeningReserveFund, false),
+		r.amount(ec.OpeningRetainedEarnings, false),
+		r.amount(ec.OpeningNetIncome, false),
+		r.amount(ec.OpeningTotal, false)}, rowStyle{})
+
+	r.row(cols, []string{r.t("Resultatdisposition enligt årsstämman")}, rowStyle{})
+
+	if ec.DividendNetIncome != nil || ec.DividendTotal != nil {
+		r.row(cols, []string{r.t("– Utdelning"), "–", "–", "–",
+			r.amount(ec.DividendNetIncome, true),
+			r.amount(ec.DividendTotal, true)}, rowStyle{})
+	}
+
+	r.row(cols, []string{r.t("Årets resultat"), "–", "–", "–",
+		r.amount(ec.YearResultNetIncome, false),
+		r.amount(ec.YearResultTotal, false)}, rowStyle{sum: true})
+
+	r.row(cols, []string{r.t("Belopp vid årets utgång"),
+		r.amount(ec.ClosingShareCapital, false),
+		r.amount(ec.ClosingReserveFund, false),
+		r.amount(ec.ClosingRetainedEarnings, false),
+		r.amount(ec.ClosingNetIncome, false),
+		r.amount(ec.ClosingTotal, false)}, rowStyle{total: true})
+}
+
+// writeProfitDisposition writes the resultatdisposition: the funds at the
+// disposal of the annual general meeting and the board's proposal.
+func (r *renderer) writeProfitDisposition(rep *model.AnnualReport) {
+	pd := &rep.ManagementReport.ProfitDisposition
+	cols := []column{{width: contentWidth - 110}, {width: 110, align: alignRight}}
+
+	r.heading(r.t("Resultatdisposition"), sizeH3, 5*sizeBody*lineSpacing)
+	r.paragraph(r.regular, sizeBody, r.t("Till årsstämmans förfogande står följande vinstmedel:"))
+	r.row(cols, []string{r.t("Balanserat resultat"), r.amount(pd.RetainedEarnings, false)}, rowStyle{})
+	r.row(cols, []string{r.t("Årets resultat"), r.amount(pd.NetIncome, false)}, rowStyle{sum: true})
+	r.row(cols, []string{r.t("Totalt"), r.amount(pd.TotalAvailable, false)}, rowStyle{total: true})
+	r.y -= 10
+
+	r.need(5 * sizeBody * lineSpacing)
+	r.paragraph(r.regular, sizeBody, r.t("Styrelsen och verkställande direktören föreslår att vinstmedlen disponeras enligt följande"))
+	if pd.Dividend != nil {
+		r.row(cols, []string{r.t("Utdelning till ägarna"), r.amount(pd.Dividend, false)}, rowStyle{})
+	}
+	r.row(cols, []string{r.t("Balanseras i ny räkning"), r.amount(pd.CarriedForward, false)}, rowStyle{sum: true})
+	r.row(cols, []string{r.t("Totalt"), r.amount(pd.TotalDisposition, false)}, rowStyle{total: true})
+}
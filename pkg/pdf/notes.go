@@ -0,0 +1,220 @@
+package pdf
+
+import (
+	"strconv"
+
+	"github.com/redofri/redofri/pkg/model"
+)
+
+// noteColumns returns the label and two amount columns used by the notes.
+func noteColumns() []column {
+	return []column{
+		{width: contentWidth - 2*88},
+		{width: 88, align: alignRight},
+		{width: 88, align: alignRight},
+	}
+}
+
+// writeNotes writes the noter followed by the underskrifter.
+func (r *renderer) writeNotes(rep *model.AnnualReport) {
+	notes := &rep.Notes
+
+	r.startSection("noter")
+	r.heading(r.t("Noter"), sizeH2, 0)
+	r.paragraph(r.regular, sizeSmall, r.t("Noterna förklarar de belopp och bedömningar som ligger bakom resultat- och balansräkningen."))
+
+	r.writeAccountingPoliciesNote(&notes.AccountingPolicies)
+	if notes.Employees != nil {
+		r.writeEmployeesNote(rep, notes.Employees)
+	}
+
+	for i := range notes.FixedAssetNotes {
+		if i == 0 {
+			r.heading(r.t("Upplysningar till balansräkningen"), sizeH3, 4*sizeBody*lineSpacing)
+		}
+		r.writeFixedAssetNote(rep, &notes.FixedAssetNotes[i])
+	}
+
+	if n := notes.LongTermLiabilitiesNote; n != nil {
+		r.noteHeading(n.NoteNumber, r.t("Långfristiga skulder"))
+		r.noteInstantHeader(rep)
+		r.row(noteColumns(), []string{r.t("Långfristiga skulder som förfaller till betalning senare än fem år efter balansdagen:")}, rowStyle{})
+		r.noteRow("Summa", n.DueAfterFiveYears, false, rowStyle{total: true})
+	}
+
+	if n := notes.Pledges; n != nil {
+		r.noteHeading(n.NoteNumber, r.t("Ställda säkerheter"))
+		r.noteInstantHeader(rep)
+		if n.CorporateMortgages.Current != nil || n.CorporateMortgages.Previous != nil {
+			r.noteRow("Företagsinteckning", n.CorporateMortgages, false, rowStyle{})
+		}
+		if n.RealEstateMortgages.Current != nil || n.RealEstateMortgages.Previous != nil {
+			r.noteRow("Fastighetsinteckning", n.RealEstateMortgages, false, rowStyle{})
+		}
+		r.noteRow("Summa ställda säkerheter", n.TotalPledges, false, rowStyle{total: true})
+	}
+
+	if n := notes.ContingentLiabilities; n != nil {
+		r.noteHeading(n.NoteNumber, r.t("Eventualförpliktelser"))
+		r.noteInstantHeader(rep)
+		r.noteRow("Summa", n.TotalContingent, false, rowStyle{total: true})
+	}
+
+	if n := notes.MultiPostNote; n != nil {
+		r.writeMultiPostNote(n)
+	}
+
+	r.writeSignatures(rep)
+}
+
+// noteHeading writes a "Not N Title" heading, kept with the start of the note.
+func (r *renderer) noteHeading(num int, title string) {
+	r.heading(r.lbl.Tf("Not %d", num)+"   "+title, sizeH3, 4*sizeBody*lineSpacing)
+}
+
+// noteInstantHeader writes the balance sheet date column headings.
+func (r *renderer) noteInstantHeader(rep *model.AnnualReport) {
+	_, prevEnd := prevYearDates(rep.FiscalYear.StartDate, rep.FiscalYear.EndDate)
+	r.headerRow(noteColumns(), []string{"", r.date(rep.FiscalYear.EndDate), r.date(prevEnd)})
+}
+
+// noteRow writes a note table row with the current and previous year.
+func (r *renderer) noteRow(label string, yc model.YearComparison, negative bool, style rowStyle) {
+	r.row(noteColumns(), []string{r.t(label), r.amount(yc.Current, negative), r.amount(yc.Previous, negative)}, style)
+}
+
+// writeAccountingPoliciesNote writes Note 1: Redovisnings- och värderingsprinciper.
+func (r *renderer) writeAccountingPoliciesNote(ap *model.AccountingPolicies) {
+	r.noteHeading(ap.NoteNumber, r.t("Redovisnings- och värderingsprinciper"))
+	r.paragraph(r.regular, sizeBody, ap.Description)
+
+	if len(ap.Depreciations) > 0 {
+		r.heading(r.t("Avskrivningar"), sizeH4, 3*sizeBody*lineSpacing)
+		r.paragraph(r.regular, sizeBody, r.t("Tillämpade avskrivningstider:"))
+		cols := []column{{width: contentWidth - 120}, {width: 120, align: alignRight}}
+		for _, dep := range ap.Depreciations {
+			r.row(cols, []string{dep.Category, strconv.Itoa(dep.Years) + " " + r.t("år")}, rowStyle{})
+		}
+		r.y -= 6
+	}
+
+	if ap.DepreciationComment != "" {
+		r.paragraph(r.regular, sizeBody, ap.DepreciationComment)
+	}
+
+	if ap.ManufacturedGoodsPolicy != "" {
+		r.heading(r.t("Anskaffningsvärde för egentillverkade varor"), sizeH4, 2*sizeBody*lineSpacing)
+		r.paragraph(r.regular, sizeBody, ap.ManufacturedGoodsPolicy)
+	}
+
+	if len(ap.Restatements) > 0 {
+		r.heading(r.t("Ändrade jämförelsetal"), sizeH4, 2*sizeBody*lineSpacing)
+		for _, rs := range ap.Restatements {
+			r.paragraph(r.regular, sizeBody, rs.Justification)
+		}
+	}
+
+	r.heading(r.t("Nyckeltalsdefinitioner"), sizeH4, 3*sizeBody*lineSpacing)
+	r.paragraphAt(r.bold, sizeBody, marginLeft, contentWidth, r.t("Soliditet"))
+	r.paragraph(r.regular, sizeBody, r.t("Eget kapital och obeskattade reserver (med avdrag för uppskjuten skatt) i förhållande till balansomslutningen."))
+}
+
+// writeEmployeesNote writes Note 2: Medelantalet anställda.
+func (r *renderer) writeEmployeesNote(rep *model.AnnualReport, emp *model.EmployeesNote) {
+	prevStart, prevEnd := prevYearDates(rep.FiscalYear.StartDate, rep.FiscalYear.EndDate)
+
+	r.heading(r.t("Upplysningar till resultaträkningen"), sizeH3, 5*sizeBody*lineSpacing)
+	r.noteHeading(emp.NoteNumber, r.t("Medelantalet anställda"))
+	r.headerRow(noteColumns(), []string{"",
+		r.date(rep.FiscalYear.StartDate) + "\n–" + r.date(rep.FiscalYear.EndDate),
+		r.date(prevStart) + "\n–" + r.date(prevEnd),
+	})
+	r.noteRow("Medelantalet anställda", emp.AverageEmployees, false, rowStyle{})
+}
+
+// writeFixedAssetNote writes a roll-forward note for a single asset category.
+func (r *renderer) writeFixedAssetNote(rep *model.AnnualReport, fan *model.FixedAssetNote) {
+	hasDepreciation := fan.OpeningDepreciation.Current != nil || fan.OpeningDepreciation.Previous != nil
+
+	r.noteHeading(fan.NoteNumber, fan.Title)
+	r.noteInstantHeader(rep)
+
+	r.noteRow("Ingående anskaffningsvärden", fan.OpeningAcquisitionValues, false, rowStyle{})
+	if fan.Purchases.Current != nil || fan.Purchases.Previous != nil {
+		r.noteChangeRow("- Inköp", fan.Purchases, false)
+	}
+	if fan.Sales.Current != nil || fan.Sales.Previous != nil {
+		r.noteChangeRow("- Försäljningar", fan.Sales, true)
+	}
+	r.noteRow("Utgående anskaffningsvärden", fan.ClosingAcquisitionValues, false, rowStyle{})
+
+	if hasDepreciation {
+		r.y -= 4
+		r.noteRow("Ingående avskrivningar", fan.OpeningDepreciation, true, rowStyle{})
+		r.noteRow("- Årets avskrivningar", fan.YearDepreciation, true, rowStyle{sum: true})
+		r.noteRow("Utgående avskrivningar", fan.ClosingDepreciation, true, rowStyle{sum: true})
+	}
+	r.y -= 4
+	r.noteRow("Redovisat värde", fan.CarryingValue, false, rowStyle{total: true})
+}
+
+// noteChangeRow writes a purchases or sales row. A missing previous year
+// value is shown as a dash, as in the iXBRL document.
+func (r *renderer) noteChangeRow(label string, yc model.YearComparison, negative bool) {
+	prev := r.amount(yc.Previous, negative)
+	if prev == "" {
+		prev = "–"
+	}
+	r.row(noteColumns(), []string{r.t(label), r.amount(yc.Current, negative), prev}, rowStyle{sum: true})
+}
+
+// writeMultiPostNote writes the note on assets, provisions and liabilities
+// that relate to several items.
+func (r *renderer) writeMultiPostNote(note *model.MultiPostNote) {
+	r.noteHeading(note.NoteNumber, r.t("Tillgångar, avsättningar och skulder som avser flera poster"))
+	r.paragraph(r.regular, sizeBody, note.Description)
+
+	cols := []column{{width: contentWidth - 110}, {width: 110, align: alignRight}}
+	for i, e := range note.Entries {
+		if i == 0 || note.Entries[i-1].Heading != e.Heading {
+			r.heading(e.Heading, sizeH4, 2*sizeBody*lineSpacing)
+		}
+		style := rowStyle{}
+		if i == len(note.Entries)-1 {
+			style.sum = true
+		}
+		r.row(cols, []string{e.PostName, r.amount(e.Amount, false)}, style)
+	}
+}
+
+// writeSignatures writes the underskrifter: place and date, followed by a
+// signature line with name and role for each signatory, two per row.
+func (r *renderer) writeSignatures(rep *model.AnnualReport) {
+	sigs := &rep.Signatures
+
+	r.y -= 20
+	r.need(3*sizeBody*lineSpacing + signatureHeight)
+	r.paragraph(r.regular, sizeBody, sigs.City+" "+sigs.Date)
+
+	colWidth := contentWidth / 2
+	for i, sig := range sigs.Signatories {
+		col := i % 2
+		if col == 0 {
+			r.need(signatureHeight)
+		}
+		x := marginLeft + float64(col)*colWidth
+		top := r.y
+		line := top - 36
+		r.cur().rule(x, x+colWidth-30, line, 0.5)
+		r.cur().text(r.regular, sizeBody, x, line-sizeBody-3, sig.FirstName+" "+sig.LastName)
+		if sig.Role != "" {
+			r.cur().text(r.regular, sizeSmall, x, line-sizeBody-3-sizeSmall*lineSpacing, sig.Role)
+		}
+		if col == 1 || i == len(sigs.Signatories)-1 {
+			r.y = top - signatureHeight
+		}
+	}
+}
+
+// signatureHeight is the vertical space of one row of signature blocks.
+const signatureHeight = 72.0
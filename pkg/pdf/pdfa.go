@@ -0,0 +1,164 @@
+package pdf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"html"
+	"math"
+	"time"
+)
+
+// metadata holds the document information shared by the Info dictionary
+// and, for PDF/A, the XMP packet. PDF/A requires both to agree.
+type metadata struct {
+	title    string
+	author   string
+	language string
+	created  time.Time
+}
+
+// producer is recorded as the Producer and CreatorTool of every document.
+const producer = "redofri"
+
+// writeInfo adds the document information dictionary.
+func (d *document) writeInfo(m metadata) int {
+	date := pdfDate(m.created)
+	return d.add(fmt.Sprintf("<</Title %s /Author %s /Creator (%s) /Producer (%s) /CreationDate (%s) /ModDate (%s)>>",
+		textString(m.title), textString(m.author), producer, producer, date, date))
+}
+
+// pdfDate formats a date as D:YYYYMMDDHHmmSS+HH'mm'.
+func pdfDate(t time.Time) string {
+	_, offset := t.Zone()
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	return fmt.Sprintf("D:%s%c%02d'%02d'", t.Format("20060102150405"), sign, offset/3600, offset%3600/60)
+}
+
+// writeXMP adds the uncompressed XMP metadata stream that identifies the
+// file as PDF/A-1b.
+func (d *document) writeXMP(m metadata) int {
+	date := m.created.Format("2006-01-02T15:04:05-07:00")
+	lang := m.language
+	if lang == "" {
+		lang = "x-default"
+	}
+	xmp := fmt.Sprintf(`<?xpacket begin="%s" id="W5M0MpCehiHzreSzNTczkc9d"?>
+<x:xmpmeta xmlns:x="adobe:ns:meta/">
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+<rdf:Description rdf:about="" xmlns:pdfaid="http://www.aiim.org/pdfa/ns/id/">
+<pdfaid:part>1</pdfaid:part>
+<pdfaid:conformance>B</pdfaid:conformance>
+</rdf:Description>
+<rdf:Description rdf:about="" xmlns:dc="http://purl.org/dc/elements/1.1/">
+<dc:format>application/pdf</dc:format>
+<dc:title><rdf:Alt><rdf:li xml:lang="x-default">%s</rdf:li></rdf:Alt></dc:title>
+<dc:creator><rdf:Seq><rdf:li>%s</rdf:li></rdf:Seq></dc:creator>
+<dc:language><rdf:Bag><rdf:li>%s</rdf:li></rdf:Bag></dc:language>
+</rdf:Description>
+<rdf:Description rdf:about="" xmlns:xmp="http://ns.adobe.com/xap/1.0/">
+<xmp:CreatorTool>%s</xmp:CreatorTool>
+<xmp:CreateDate>%s</xmp:CreateDate>
+<xmp:ModifyDate>%s</xmp:ModifyDate>
+</rdf:Description>
+<rdf:Description rdf:about="" xmlns:pdf="http://ns.adobe.com/pdf/1.3/">
+<pdf:Producer>%s</pdf:Producer>
+</rdf:Description>
+</rdf:RDF>
+</x:xmpmeta>
+<?xpacket end="w"?>`,
+		"\ufeff", html.EscapeString(m.title), html.EscapeString(m.author), html.EscapeString(lang),
+		producer, date, date, producer)
+	return d.addStream(" /Type /Metadata /Subtype /XML", []byte(xmp), false)
+}
+
+// writeOutputIntent adds the sRGB output intent required by PDF/A when
+// device colours are used.
+func (d *document) writeOutputIntent() int {
+	profile := d.addStream(" /N 3", srgbProfile(), true)
+	return d.add(fmt.Sprintf("<</Type /OutputIntent /S /GTS_PDFA1 /OutputConditionIdentifier (sRGB IEC61966-2.1) /Info (sRGB IEC61966-2.1) /RegistryName (http://www.color.org) /DestOutputProfile %s>>",
+		ref(profile)))
+}
+
+// srgbProfile builds a compact ICC version 2 display profile with the sRGB
+// primaries (adapted to D50) and a 2.2 gamma curve.
+func srgbProfile() []byte {
+	type tag struct {
+		sig  string
+		data []byte
+	}
+	xyz := func(x, y, z float64) []byte {
+		b := []byte("XYZ \x00\x00\x00\x00")
+		for _, v := range []float64{x, y, z} {
+			b = binary.BigEndian.AppendUint32(b, uint32(int32(math.Round(v*65536))))
+		}
+		return b
+	}
+	text := func(s string) []byte {
+		return append([]byte("text\x00\x00\x00\x00"+s), 0)
+	}
+	desc := func(s string) []byte {
+		b := []byte("desc\x00\x00\x00\x00")
+		b = binary.BigEndian.AppendUint32(b, uint32(len(s)+1))
+		b = append(b, s...)
+		b = append(b, 0)
+		// Empty Unicode and ScriptCode descriptions.
+		b = append(b, make([]byte, 4+4+2+1+67)...)
+		return b
+	}
+	// Gamma 2.2 as u8Fixed8Number.
+	curve := []byte("curv\x00\x00\x00\x00\x00\x00\x00\x01\x02\x33")
+
+	tags := []tag{
+		{"desc", desc("sRGB IEC61966-2.1")},
+		{"cprt", text("No copyright, use freely")},
+		{"wtpt", xyz(0.9642, 1.0, 0.8249)},
+		{"rXYZ", xyz(0.4361, 0.2225, 0.0139)},
+		{"gXYZ", xyz(0.3851, 0.7169, 0.0971)},
+		{"bXYZ", xyz(0.1431, 0.0606, 0.7141)},
+		{"rTRC", curve},
+		{"gTRC", curve},
+		{"bTRC", curve},
+	}
+
+	pad := func(b []byte) []byte {
+		for len(b)%4 != 0 {
+			b = append(b, 0)
+		}
+		return b
+	}
+
+	var body bytes.Buffer
+	var table bytes.Buffer
+	binary.Write(&table, binary.BigEndian, uint32(len(tags)))
+	offset := 128 + 4 + 12*len(tags)
+	for _, t := range tags {
+		table.WriteString(t.sig)
+		binary.Write(&table, binary.BigEndian, uint32(offset+body.Len()))
+		binary.Write(&table, binary.BigEndian, uint32(len(t.data)))
+		body.Write(pad(t.data))
+	}
+
+	size := 128 + table.Len() + body.Len()
+	header := make([]byte, 128)
+	binary.BigEndian.PutUint32(header[0:], uint32(size))
+	binary.BigEndian.PutUint32(header[8:], 0x02100000) // version 2.1
+	copy(header[12:], "mntr")
+	copy(header[16:], "RGB ")
+	copy(header[20:], "XYZ ")
+	for i, v := range []uint16{2024, 1, 1, 0, 0, 0} { // creation date
+		binary.BigEndian.PutUint16(header[24+2*i:], v)
+	}
+	copy(header[36:], "acsp")
+	// Rendering intent 0 (perceptual), PCS illuminant D50.
+	binary.BigEndian.PutUint32(header[68:], uint32(int32(math.Round(0.9642*65536))))
+	binary.BigEndian.PutUint32(header[72:], 65536)
+	binary.BigEndian.PutUint32(header[76:], uint32(int32(math.Round(0.8249*65536))))
+
+	out := append(header, table.Bytes()...)
+	return append(out, body.Bytes()...)
+}
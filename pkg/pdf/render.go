@@ -0,0 +1,237 @@
+// Package pdf renders a K2 annual report as an A4 PDF document.
+//
+// The PDF has the same sections as the iXBRL document — cover page with
+// fastställelseintyg, förvaltningsberättelse, resultaträkning,
+// balansräkning, noter and underskrifter — with page numbering in every
+// page header and signature lines for the signatories. It is written
+// directly by this package; no external services or browsers are involved.
+//
+// By default the document references the standard Helvetica fonts. For
+// archiving, Options.PDFA produces a PDF/A-1b file, which requires a
+// TrueType font to embed.
+package pdf
+
+import (
+	"bytes"
+	"crypto/md5"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/redofri/redofri/pkg/labels"
+	"github.com/redofri/redofri/pkg/model"
+)
+
+// Options controls optional aspects of PDF rendering.
+type Options struct {
+	// PDFA produces a PDF/A-1b document for long-term archiving. PDF/A
+	// requires embedded fonts, so Font must be set.
+	PDFA bool
+
+	// Font and BoldFont are TrueType font programs embedded in the
+	// document. Without Font the standard Helvetica fonts are used. Without
+	// BoldFont headings use Font.
+	Font     []byte
+	BoldFont []byte
+
+	// Created is recorded as the creation date. Zero means now.
+	Created time.Time
+}
+
+// ErrFontRequired is returned when a PDF/A document is requested without a
+// font to embed.
+var ErrFontRequired = errors.New("PDF/A requires an embedded TrueType font")
+
+// Render writes the annual report as a PDF document.
+func Render(w io.Writer, r *model.AnnualReport, opts Options) error {
+	rr, err := newRenderer(r, opts)
+	if err != nil {
+		return err
+	}
+	rr.render()
+	return rr.write(w)
+}
+
+// RenderBytes returns the annual report as a PDF document.
+func RenderBytes(r *model.AnnualReport, opts Options) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := Render(&buf, r, opts); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+type renderer struct {
+	cursor
+	report *model.AnnualReport
+	opts   Options
+	lbl    *labels.Catalogue
+	logo   *logoImage // nil without a raster logo
+
+	// sectionPages records the first page of each table of contents entry.
+	sectionPages map[string]int
+}
+
+func newRenderer(r *model.AnnualReport, opts Options) (*renderer, error) {
+	rr := &renderer{
+		report:       r,
+		opts:         opts,
+		lbl:          labels.For(r.Meta.Language),
+		sectionPages: map[string]int{},
+	}
+	if opts.PDFA && len(opts.Font) == 0 {
+		return nil, ErrFontRequired
+	}
+	if len(opts.Font) == 0 {
+		rr.regular = helvetica("F1", false)
+		rr.bold = helvetica("F2", true)
+	} else {
+		f, err := newTrueTypeFont("F1", opts.Font)
+		if err != nil {
+			return nil, err
+		}
+		rr.regular, rr.bold = f, f
+		if len(opts.BoldFont) > 0 {
+			b, err := newTrueTypeFont("F2", opts.BoldFont)
+			if err != nil {
+				return nil, fmt.Errorf("bold %w", err)
+			}
+			rr.bold = b
+		}
+	}
+	if r.Company.Logo != nil {
+		logo, err := loadLogo(r.Company.Logo)
+		if err != nil {
+			return nil, fmt.Errorf("company logo: %w", err)
+		}
+		rr.logo = logo
+	}
+	return rr, nil
+}
+
+// render lays out all sections in the same order as the iXBRL document.
+func (r *renderer) render() {
+	rep := r.report
+	r.writeCoverPage(rep)
+	r.writeManagementReport(rep)
+	r.writeIncomeStatement(rep)
+	r.writeBalanceSheetAssets(rep)
+	r.writeBalanceSheetEquityLiabilities(rep)
+	r.writeNotes(rep)
+}
+
+// startSection begins a section on a new page and records the page for the
+// table of contents entry toc, if any.
+func (r *renderer) startSection(toc string) {
+	r.newPage()
+	if toc != "" {
+		if _, ok := r.sectionPages[toc]; !ok {
+			r.sectionPages[toc] = len(r.pages)
+		}
+	}
+}
+
+// t translates a Swedish display text into the report language.
+func (r *renderer) t(sv string) string {
+	return r.lbl.T(sv)
+}
+
+// date formats an ISO date for display in the report language.
+func (r *renderer) date(iso string) string {
+	return r.lbl.Date(iso)
+}
+
+// amount formats an optional amount in whole kronor. Nil gives an empty
+// cell; negative adds the "-" that the iXBRL shows in front of expenses.
+func (r *renderer) amount(v *int64, negative bool) string {
+	if v == nil {
+		return ""
+	}
+	s := r.lbl.Int(*v)
+	if negative {
+		s = "-" + s
+	}
+	return s
+}
+
+// drawPageHeader draws the company name, org nr, logo and page number.
+func (r *renderer) drawPageHeader(p *page, num, total int) {
+	y := pageHeight - headerTop
+	x := marginLeft
+	if r.logo != nil {
+		h := 26.0
+		w := h * float64(r.logo.width) / float64(r.logo.height)
+		if w > 90 {
+			w = 90
+			h = w * float64(r.logo.height) / float64(r.logo.width)
+		}
+		p.image(x, y-h+sizeSmall+2, w, h)
+		x += w + 10
+	}
+	p.text(r.bold, sizeSmall+1, x, y, r.report.Company.Name)
+	p.text(r.regular, sizeSmall, x, y-11, r.report.Company.OrgNr)
+	pageText := r.lbl.Tf("Sida %d av %d", num, total)
+	p.text(r.regular, sizeSmall, pageWidth-marginRight-r.regular.textWidth(pageText, sizeSmall), y, pageText)
+	p.rule(marginLeft, pageWidth-marginRight, y-20, 0.5)
+}
+
+// write assembles the laid out pages into a PDF file.
+func (r *renderer) write(w io.Writer) error {
+	d := &document{}
+	catalog := d.alloc()
+	pagesID := d.alloc()
+
+	fonts := fmt.Sprintf("/%s %s", r.regular.resource, ref(d.writeFont(r.regular)))
+	if r.bold != r.regular {
+		fonts += fmt.Sprintf(" /%s %s", r.bold.resource, ref(d.writeFont(r.bold)))
+	}
+	var logoID int
+	if r.logo != nil {
+		logoID = d.writeImage(r.logo)
+	}
+
+	created := r.opts.Created
+	if created.IsZero() {
+		created = time.Now()
+	}
+	hash := md5.New()
+
+	var kids []string
+	total := len(r.pages)
+	for i, p := range r.pages {
+		r.drawPageHeader(p, i+1, total)
+		for _, fn := range p.deferred {
+			fn()
+		}
+		content := append([]byte("0 0 0 rg 0.25 0.25 0.25 RG\n"), p.content.Bytes()...)
+		hash.Write(content)
+
+		resources := "/Font <<" + fonts + ">>"
+		if p.logo {
+			resources += fmt.Sprintf(" /XObject <</Im1 %s>>", ref(logoID))
+		}
+		contents := d.addStream("", content, true)
+		kids = append(kids, ref(d.add(fmt.Sprintf("<</Type /Page /Parent %s /MediaBox [0 0 %s %s] /Resources <<%s>> /Contents %s>>",
+			ref(pagesID), number(pageWidth), number(pageHeight), resources, ref(contents)))))
+	}
+	d.set(pagesID, fmt.Sprintf("<</Type /Pages /Kids [%s] /Count %d>>", strings.Join(kids, " "), total))
+
+	meta := metadata{
+		title:    fmt.Sprintf("%s %s - %s", r.report.Company.OrgNr, r.report.Company.Name, r.t("Årsredovisning")),
+		author:   r.report.Company.Name,
+		language: r.lbl.Language,
+		created:  created,
+	}
+	info := d.writeInfo(meta)
+
+	cat := fmt.Sprintf("<</Type /Catalog /Pages %s /Lang %s", ref(pagesID), textString(r.lbl.Language))
+	if r.opts.PDFA {
+		cat += fmt.Sprintf(" /Metadata %s /OutputIntents [%s]", ref(d.writeXMP(meta)), ref(d.writeOutputIntent()))
+	}
+	d.set(catalog, cat+">>")
+
+	fmt.Fprint(hash, meta.title, created.Unix())
+	return d.writeTo(w, catalog, info, hash.Sum(nil))
+}
@@ -0,0 +1,247 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/text/encoding/charmap"
+
+	"github.com/redofri/redofri/pkg/model"
+)
+
+// loadTestReport loads the example test data from testdata/exempel1.json.
+func loadTestReport(t *testing.T) *model.AnnualReport {
+	t.Helper()
+	data, err := os.ReadFile("../../testdata/exempel1.json")
+	if err != nil {
+		t.Fatalf("reading test data: %v", err)
+	}
+	var r model.AnnualReport
+	if err := json.Unmarshal(data, &r); err != nil {
+		t.Fatalf("parsing test data: %v", err)
+	}
+	return &r
+}
+
+func renderOutput(t *testing.T, r *model.AnnualReport, opts Options) []byte {
+	t.Helper()
+	if opts.Created.IsZero() {
+		opts.Created = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	}
+	out, err := RenderBytes(r, opts)
+	if err != nil {
+		t.Fatalf("RenderBytes: %v", err)
+	}
+	return out
+}
+
+var (
+	streamRe = regexp.MustCompile(`(?s)/FlateDecode[^>]*>>\nstream\n(.*?)\nendstream`)
+	textRe   = regexp.MustCompile(`\(((?:\\.|[^\\)])*)\) Tj`)
+	escapeRe = regexp.MustCompile(`\\(.)`)
+)
+
+// pageText returns the text drawn on each page, one string per text
+// operator, decoded from WinAnsiEncoding.
+func pageText(t *testing.T, pdf []byte) []string {
+	t.Helper()
+	var out []string
+	for _, m := range streamRe.FindAllSubmatch(pdf, -1) {
+		zr, err := zlib.NewReader(bytes.NewReader(m[1]))
+		if err != nil {
+			t.Fatalf("inflating stream: %v", err)
+		}
+		content, err := io.ReadAll(zr)
+		if err != nil {
+			t.Fatalf("inflating stream: %v", err)
+		}
+		if !bytes.Contains(content, []byte(" Tj")) {
+			continue
+		}
+		var page []string
+		for _, tm := range textRe.FindAllSubmatch(content, -1) {
+			s := escapeRe.ReplaceAll(tm[1], []byte("$1"))
+			dec, _ := charmap.Windows1252.NewDecoder().Bytes(s)
+			page = append(page, string(dec))
+		}
+		out = append(out, strings.Join(page, "\n"))
+	}
+	return out
+}
+
+func TestRender_Structure(t *testing.T) {
+	out := renderOutput(t, loadTestReport(t), Options{})
+
+	if !bytes.HasPrefix(out, []byte("%PDF-1.4\n")) {
+		t.Error("missing PDF header")
+	}
+	if !bytes.HasSuffix(out, []byte("%%EOF\n")) {
+		t.Error("missing EOF marker")
+	}
+	for _, want := range []string{"/Type /Catalog", "/BaseFont /Helvetica", "/BaseFont /Helvetica-Bold", "/MediaBox [0 0 595.28 841.89]", "startxref"} {
+		if !bytes.Contains(out, []byte(want)) {
+			t.Errorf("missing %q", want)
+		}
+	}
+	if bytes.Contains(out, []byte("pdfaid")) {
+		t.Error("PDF/A metadata written without Options.PDFA")
+	}
+}
+
+func TestRender_PageNumbers(t *testing.T) {
+	r := loadTestReport(t)
+	pages := pageText(t, renderOutput(t, r, Options{}))
+	if len(pages) < 6 {
+		t.Fatalf("got %d pages, want at least 6", len(pages))
+	}
+	for i, p := range pages {
+		want := "Sida " + strconv.Itoa(i+1) + " av " + strconv.Itoa(len(pages))
+		if !strings.Contains(p, want) {
+			t.Errorf("page %d: missing %q", i+1, want)
+		}
+		if !strings.Contains(p, r.Company.Name) {
+			t.Errorf("page %d: missing company header", i+1)
+		}
+	}
+}
+
+func TestRender_Sections(t *testing.T) {
+	r := loadTestReport(t)
+	text := strings.Join(pageText(t, renderOutput(t, r, Options{})), "\n")
+
+	for _, want := range []string{
+		r.Company.Name,
+		"Fastställelseintyg",
+		"Förvaltningsberättelse",
+		"Flerårsöversikt",
+		"Resultatdisposition",
+		"Resultaträkning",
+		"Rörelseintäkter, lagerförändringar m.m.",
+		"Balansräkning",
+		"Summa eget kapital och skulder",
+		"Noter",
+		"Redovisnings- och värderingsprinciper",
+		"Redovisat värde",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("missing %q", want)
+		}
+	}
+	for _, s := range r.Signatures.Signatories {
+		if !strings.Contains(text, s.FirstName+" "+s.LastName) {
+			t.Errorf("missing signatory %s %s", s.FirstName, s.LastName)
+		}
+	}
+}
+
+func TestRender_TableOfContents(t *testing.T) {
+	pages := pageText(t, renderOutput(t, loadTestReport(t), Options{}))
+
+	// The TOC on the cover page lists the page on which each section starts.
+	first := map[string]int{}
+	for i, p := range pages {
+		for _, h := range []string{"Förvaltningsberättelse", "Resultaträkning", "Balansräkning", "Noter"} {
+			if _, ok := first[h]; !ok && strings.Contains("\n"+p, "\n"+h+"\n") {
+				first[h] = i + 1
+			}
+		}
+	}
+	// Page numbers are drawn after layout, so they are the last text on the
+	// cover page.
+	cover := strings.Split(pages[0], "\n")
+	got := cover[len(cover)-4:]
+	for i, h := range []string{"Förvaltningsberättelse", "Resultaträkning", "Balansräkning", "Noter"} {
+		if got[i] != strconv.Itoa(first[h]) {
+			t.Errorf("TOC %q: page %q, want %d", h, got[i], first[h])
+		}
+	}
+	if len(first) != 4 {
+		t.Errorf("found sections %v, want 4", first)
+	}
+}
+
+func TestRender_English(t *testing.T) {
+	r := loadTestReport(t)
+	r.Meta.Language = "en"
+	text := strings.Join(pageText(t, renderOutput(t, r, Options{})), "\n")
+
+	for _, want := range []string{"Page 1 of", "Income statement", "Balance sheet"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("missing %q", want)
+		}
+	}
+	if strings.Contains(text, "Resultaträkning") {
+		t.Error("Swedish heading in English rendering")
+	}
+}
+
+func TestRender_PDFARequiresFont(t *testing.T) {
+	_, err := RenderBytes(loadTestReport(t), Options{PDFA: true})
+	if !errors.Is(err, ErrFontRequired) {
+		t.Fatalf("got %v, want ErrFontRequired", err)
+	}
+}
+
+func TestRender_PDFA(t *testing.T) {
+	regular, err := os.ReadFile("/usr/share/fonts/truetype/dejavu/DejaVuSans.ttf")
+	if err != nil {
+		t.Skip("DejaVu Sans not installed")
+	}
+	bold, _ := os.ReadFile("/usr/share/fonts/truetype/dejavu/DejaVuSans-Bold.ttf")
+
+	out := renderOutput(t, loadTestReport(t), Options{PDFA: true, Font: regular, BoldFont: bold})
+	for _, want := range []string{
+		"<pdfaid:part>1</pdfaid:part>",
+		"<pdfaid:conformance>B</pdfaid:conformance>",
+		"/S /GTS_PDFA1",
+		"/DestOutputProfile",
+		"/FontFile2",
+		"/Subtype /TrueType",
+		"/Metadata",
+		"/ID [<",
+	} {
+		if !bytes.Contains(out, []byte(want)) {
+			t.Errorf("missing %q", want)
+		}
+	}
+	if bytes.Contains(out, []byte("/BaseFont /Helvetica")) {
+		t.Error("PDF/A document references a non-embedded font")
+	}
+	text := strings.Join(pageText(t, out), "\n")
+	if !strings.Contains(text, "Förvaltningsberättelse") {
+		t.Error("missing section text with embedded font")
+	}
+}
+
+func TestRender_RejectsInvalidFont(t *testing.T) {
+	if _, err := RenderBytes(loadTestReport(t), Options{Font: []byte("not a font")}); err == nil {
+		t.Fatal("expected error for invalid font")
+	}
+}
+
+func TestEncodeWinAnsi(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Årets resultat", "\xc5rets resultat"},
+		{"1\u00a0000", "1\xa0000"},
+		{"\u2212500", "-500"},
+		{"2024-01-01 – 2024-12-31", "2024-01-01 \x96 2024-12-31"},
+		{"Ő", "O"},
+		{"中", "?"},
+	}
+	for _, tt := range tests {
+		if got := string(encodeWinAnsi(tt.in)); got != tt.want {
+			t.Errorf("encodeWinAnsi(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
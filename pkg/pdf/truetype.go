@@ -0,0 +1,245 @@
+package pdf
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"unicode/utf16"
+)
+
+// trueType holds what the PDF writer needs from a TrueType font program:
+// metrics for the font descriptor, advance widths and the Unicode cmap.
+// The program itself is embedded unmodified.
+type trueType struct {
+	data []byte
+
+	postScriptName string
+	unitsPerEm     int
+	bbox           [4]int
+	ascent         int
+	descent        int
+	capHeight      int
+	italicAngle    float64
+	fixedPitch     bool
+
+	advances []uint16 // hmtx advance widths, indexed by glyph
+	cmap     []byte   // format 4 subtable for platform 3, encoding 1
+}
+
+// parseTrueType reads the tables of a TrueType font. OpenType fonts with
+// CFF outlines and font collections are not supported.
+func parseTrueType(data []byte) (*trueType, error) {
+	if len(data) < 12 {
+		return nil, errors.New("font: file too short")
+	}
+	switch string(data[:4]) {
+	case "\x00\x01\x00\x00", "true":
+	case "OTTO":
+		return nil, errors.New("font: OpenType fonts with CFF outlines are not supported, use a TrueType font")
+	case "ttcf":
+		return nil, errors.New("font: font collections are not supported, use a single TrueType font")
+	default:
+		return nil, errors.New("font: not a TrueType font")
+	}
+
+	tables := map[string][]byte{}
+	numTables := int(binary.BigEndian.Uint16(data[4:]))
+	for i := 0; i < numTables; i++ {
+		rec := 12 + 16*i
+		if rec+16 > len(data) {
+			return nil, errors.New("font: truncated table directory")
+		}
+		tag := string(data[rec : rec+4])
+		off := int(binary.BigEndian.Uint32(data[rec+8:]))
+		length := int(binary.BigEndian.Uint32(data[rec+12:]))
+		if off < 0 || length < 0 || off+length > len(data) {
+			return nil, fmt.Errorf("font: table %s out of range", tag)
+		}
+		tables[tag] = data[off : off+length]
+	}
+	for _, tag := range []string{"head", "hhea", "hmtx", "cmap", "name", "post"} {
+		if tables[tag] == nil {
+			return nil, fmt.Errorf("font: missing %s table", tag)
+		}
+	}
+
+	t := &trueType{data: data}
+
+	head := tables["head"]
+	if len(head) < 54 {
+		return nil, errors.New("font: short head table")
+	}
+	t.unitsPerEm = int(binary.BigEndian.Uint16(head[18:]))
+	if t.unitsPerEm == 0 {
+		return nil, errors.New("font: unitsPerEm is zero")
+	}
+	for i := range t.bbox {
+		t.bbox[i] = int(int16(binary.BigEndian.Uint16(head[36+2*i:])))
+	}
+
+	hhea := tables["hhea"]
+	if len(hhea) < 36 {
+		return nil, errors.New("font: short hhea table")
+	}
+	t.ascent = int(int16(binary.BigEndian.Uint16(hhea[4:])))
+	t.descent = int(int16(binary.BigEndian.Uint16(hhea[6:])))
+	numHMetrics := int(binary.BigEndian.Uint16(hhea[34:]))
+
+	hmtx := tables["hmtx"]
+	if numHMetrics == 0 || len(hmtx) < 4*numHMetrics {
+		return nil, errors.New("font: short hmtx table")
+	}
+	t.advances = make([]uint16, numHMetrics)
+	for i := range t.advances {
+		t.advances[i] = binary.BigEndian.Uint16(hmtx[4*i:])
+	}
+
+	post := tables["post"]
+	if len(post) < 16 {
+		return nil, errors.New("font: short post table")
+	}
+	t.italicAngle = float64(int32(binary.BigEndian.Uint32(post[4:]))) / 65536
+	t.fixedPitch = binary.BigEndian.Uint32(post[12:]) != 0
+
+	t.capHeight = t.ascent * 7 / 10
+	if os2 := tables["OS/2"]; len(os2) >= 10 {
+		// fsType bit 1: restricted license embedding.
+		if binary.BigEndian.Uint16(os2[8:])&0x000f == 0x0002 {
+			return nil, errors.New("font: the font license does not allow embedding")
+		}
+		if binary.BigEndian.Uint16(os2) >= 2 && len(os2) >= 90 {
+			t.capHeight = int(int16(binary.BigEndian.Uint16(os2[88:])))
+		}
+	}
+
+	t.postScriptName = name(postScriptName(tables["name"]))
+	if t.postScriptName == "" {
+		return nil, errors.New("font: no PostScript name in name table")
+	}
+
+	t.cmap = unicodeCmap(tables["cmap"])
+	if t.cmap == nil {
+		return nil, errors.New("font: no Windows Unicode (3,1) format 4 cmap")
+	}
+	return t, nil
+}
+
+// scale converts font units to thousandths of an em.
+func (t *trueType) scale(v int) int {
+	return int(math.Round(float64(v) * 1000 / float64(t.unitsPerEm)))
+}
+
+// advance returns the advance width of a glyph in font units.
+func (t *trueType) advance(glyph uint16) uint16 {
+	if int(glyph) < len(t.advances) {
+		return t.advances[glyph]
+	}
+	return t.advances[len(t.advances)-1]
+}
+
+// glyph maps a BMP character to a glyph index through the format 4 cmap.
+// Unmapped characters return glyph 0 (.notdef).
+func (t *trueType) glyph(r rune) uint16 {
+	c := t.cmap
+	if r < 0 || r > 0xffff || len(c) < 14 {
+		return 0
+	}
+	segCount := int(binary.BigEndian.Uint16(c[6:])) / 2
+	ends := 14
+	starts := ends + 2*segCount + 2
+	deltas := starts + 2*segCount
+	rangeOffsets := deltas + 2*segCount
+	if rangeOffsets+2*segCount > len(c) {
+		return 0
+	}
+	code := uint16(r)
+	for i := 0; i < segCount; i++ {
+		if binary.BigEndian.Uint16(c[ends+2*i:]) < code {
+			continue
+		}
+		start := binary.BigEndian.Uint16(c[starts+2*i:])
+		if start > code {
+			return 0
+		}
+		delta := binary.BigEndian.Uint16(c[deltas+2*i:])
+		ro := int(binary.BigEndian.Uint16(c[rangeOffsets+2*i:]))
+		if ro == 0 {
+			return code + delta
+		}
+		addr := rangeOffsets + 2*i + ro + 2*int(code-start)
+		if addr+2 > len(c) {
+			return 0
+		}
+		g := binary.BigEndian.Uint16(c[addr:])
+		if g == 0 {
+			return 0
+		}
+		return g + delta
+	}
+	return 0
+}
+
+// unicodeCmap returns the platform 3 encoding 1 (Windows Unicode BMP)
+// subtable if it uses format 4.
+func unicodeCmap(cmap []byte) []byte {
+	if len(cmap) < 4 {
+		return nil
+	}
+	n := int(binary.BigEndian.Uint16(cmap[2:]))
+	for i := 0; i < n; i++ {
+		rec := 4 + 8*i
+		if rec+8 > len(cmap) {
+			return nil
+		}
+		platform := binary.BigEndian.Uint16(cmap[rec:])
+		encoding := binary.BigEndian.Uint16(cmap[rec+2:])
+		off := int(binary.BigEndian.Uint32(cmap[rec+4:]))
+		if platform != 3 || encoding != 1 || off+4 > len(cmap) {
+			continue
+		}
+		if binary.BigEndian.Uint16(cmap[off:]) != 4 {
+			continue
+		}
+		length := int(binary.BigEndian.Uint16(cmap[off+2:]))
+		if off+length > len(cmap) {
+			length = len(cmap) - off
+		}
+		return cmap[off : off+length]
+	}
+	return nil
+}
+
+// postScriptName returns name ID 6 from the name table.
+func postScriptName(table []byte) string {
+	if len(table) < 6 {
+		return ""
+	}
+	count := int(binary.BigEndian.Uint16(table[2:]))
+	strings := int(binary.BigEndian.Uint16(table[4:]))
+	for i := 0; i < count; i++ {
+		rec := 6 + 12*i
+		if rec+12 > len(table) {
+			return ""
+		}
+		platform := binary.BigEndian.Uint16(table[rec:])
+		nameID := binary.BigEndian.Uint16(table[rec+6:])
+		length := int(binary.BigEndian.Uint16(table[rec+8:]))
+		off := strings + int(binary.BigEndian.Uint16(table[rec+10:]))
+		if nameID != 6 || off+length > len(table) {
+			continue
+		}
+		raw := table[off : off+length]
+		switch platform {
+		case 1:
+			return string(raw)
+		case 0, 3:
+			u := make([]uint16, len(raw)/2)
+			for j := range u {
+				u[j] = binary.BigEndian.Uint16(raw[2*j:])
+			}
+			return string(utf16.Decode(u))
+		}
+	}
+	return ""
+}
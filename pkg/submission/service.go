@@ -25,6 +25,10 @@ type SubmitOptions struct {
 	ReceiptEmailAddresses      []string
 	NotificationEmailAddresses []string
 	DocumentType               string
+	// Theme is passed to the iXBRL generator; nil uses the standard look.
+	Theme *ixbrl.Theme
+	// Validator runs the local validation; nil runs the default rules.
+	Validator *validate.Validator
 }
 
 // CheckResult contains the full result of a check flow.
@@ -34,6 +38,7 @@ type CheckResult struct {
 	AgreementText        string
 	AgreementVersionDate string
 	LocalFindings        []validate.Result
+	LocalSuppressed      []validate.Suppressed
 	RemoteFindings       []Finding
 	Checksum             string
 	ChecksumAlgorithm    string
@@ -50,6 +55,7 @@ type SubmitResult struct {
 	AgreementText        string
 	AgreementVersionDate string
 	LocalFindings        []validate.Result
+	LocalSuppressed      []validate.Suppressed
 	RemoteFindings       []Finding
 	Checksum             string
 	ChecksumAlgorithm    string
@@ -64,7 +70,8 @@ type SubmitResult struct {
 
 // ValidationError indicates that local validation failed before remote submission.
 type ValidationError struct {
-	Findings []validate.Result
+	Findings   []validate.Result
+	Suppressed []validate.Suppressed
 }
 
 func (e *ValidationError) Error() string {
@@ -131,6 +138,7 @@ func (s *Service) Check(ctx context.Context, report *model.AnnualReport, opts Su
 		AgreementText:        tokenResp.AgreementText,
 		AgreementVersionDate: tokenResp.AgreementVersionDate,
 		LocalFindings:        prepared.findings,
+		LocalSuppressed:      prepared.suppressed,
 		Checksum:             firstNonEmptyString(checksumResp.Checksum, prepared.checksum),
 		ChecksumAlgorithm:    checksumResp.Algorithm,
 		DocumentSize:         len(prepared.document),
@@ -190,6 +198,7 @@ func (s *Service) Submit(ctx context.Context, report *model.AnnualReport, opts S
 		AgreementText:        tokenResp.AgreementText,
 		AgreementVersionDate: tokenResp.AgreementVersionDate,
 		LocalFindings:        prepared.findings,
+		LocalSuppressed:      prepared.suppressed,
 		Checksum:             firstNonEmptyString(checksumResp.Checksum, prepared.checksum),
 		ChecksumAlgorithm:    checksumResp.Algorithm,
 		DocumentSize:         len(prepared.document),
@@ -257,6 +266,7 @@ func (s *Service) Submit(ctx context.Context, report *model.AnnualReport, opts S
 
 type preparedReport struct {
 	findings                   []validate.Result
+	suppressed                 []validate.Suppressed
 	document                   []byte
 	documentBase64             string
 	checksum                   string
@@ -282,25 +292,38 @@ func (p *preparedReport) applyChecksum(checksumValue, algorithm string) error {
 }
 
 func prepareReport(report *model.AnnualReport, opts SubmitOptions) (*preparedReport, error) {
-	findings := validate.Validate(report)
+	documentType := opts.DocumentType
+	if documentType == "" {
+		documentType = DefaultDocumentType
+	}
+	var (
+		findings   []validate.Result
+		suppressed []validate.Suppressed
+	)
+	if opts.Validator != nil {
+		findings, suppressed = opts.Validator.Validate(report)
+	} else {
+		findings = validate.Validate(report)
+	}
+	findings = append(findings, validate.CheckSubmission(report, documentType)...)
 	if validate.HasErrors(findings) {
-		return nil, &ValidationError{Findings: findings}
+		return nil, &ValidationError{Findings: findings, Suppressed: suppressed}
 	}
 
-	document, err := ixbrl.GenerateBytes(report)
+	document, err := ixbrl.GenerateBytesWithOptions(report, ixbrl.Options{Theme: opts.Theme})
 	if err != nil {
 		return nil, fmt.Errorf("generate iXBRL: %w", err)
 	}
+	if err := ixbrl.CheckDocument(document); err != nil {
+		return nil, fmt.Errorf("generated iXBRL: %w", err)
+	}
 
 	senderPersonalNumber := defaultPersonalNumber(opts.SenderPersonalNumber)
 	signerPersonalNumber := defaultPersonalNumber(opts.SignerPersonalNumber)
-	documentType := opts.DocumentType
-	if documentType == "" {
-		documentType = DefaultDocumentType
-	}
 
 	return &preparedReport{
 		findings:                   findings,
+		suppressed:                 suppressed,
 		document:                   document,
 		documentBase64:             base64.StdEncoding.EncodeToString(document),
 		checksum:                   checksum(document),
@@ -226,3 +226,82 @@ func TestServiceSubmitValidationError(t *testing.T) {
 		t.Fatal("expected validation findings to include errors")
 	}
 }
+
+func TestServiceSuppressedFindings(t *testing.T) {
+	report := loadTestReport(t)
+	report.Signatures.Date = "2017-04-01" // after the AGM: agm-after-signing
+	validator, err := validate.NewValidator(&validate.Config{Suppressions: []validate.Suppression{
+		{Rule: "agm-after-signing", Justification: "Extra meeting"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &fakeClient{
+		checksumTokenResp: &CreateTokenResponse{Token: "chk-123"},
+		checksumResp:      &CreateChecksumResponse{Checksum: "sum-123", Algorithm: "SHA-256"},
+		createResp:        &CreateTokenResponse{Token: "tok-123"},
+		checkResp:         &CheckResponse{OrgNumber: report.Company.OrgNr},
+	}
+	result, err := NewService(client).Check(context.Background(), report, SubmitOptions{Validator: validator})
+	if err != nil {
+		t.Fatalf("Check returned error: %v", err)
+	}
+	if len(result.LocalFindings) != 0 || len(result.LocalSuppressed) != 1 ||
+		result.LocalSuppressed[0].Rule != "agm-after-signing" || result.LocalSuppressed[0].Justification != "Extra meeting" {
+		t.Fatalf("findings = %v, suppressed = %v", result.LocalFindings, result.LocalSuppressed)
+	}
+
+	report.Company.Name = ""
+	_, err = NewService(&fakeClient{}).Submit(context.Background(), report, SubmitOptions{Validator: validator})
+	validationErr, ok := err.(*ValidationError)
+	if !ok || len(validationErr.Suppressed) != 1 {
+		t.Fatalf("error = %v", err)
+	}
+}
+
+func TestServiceRejectsInvalidCombination(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*model.AnnualReport, *SubmitOptions)
+		field  string
+	}{
+		{"unknown document type", func(_ *model.AnnualReport, o *SubmitOptions) {
+			o.DocumentType = "arsredovisning_okand"
+		}, "documentType"},
+		{"audit report not embedded", func(r *model.AnnualReport, _ *SubmitOptions) {
+			r.AuditReport = &model.AuditReport{}
+		}, "auditReport.embedded"},
+		{"audit report version", func(r *model.AnnualReport, _ *SubmitOptions) {
+			r.AuditReport = &model.AuditReport{Embedded: true, TaxonomyVersion: "2015-01-01"}
+		}, "auditReport.taxonomyVersion"},
+		{"taxonomy version not accepted", func(r *model.AnnualReport, _ *SubmitOptions) {
+			r.Meta.TaxonomyVersion = "2017-09-30"
+		}, "meta.taxonomyVersion"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := loadTestReport(t)
+			var opts SubmitOptions
+			tt.modify(report, &opts)
+			client := &fakeClient{}
+
+			_, err := NewService(client).Submit(context.Background(), report, opts)
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("error = %v, want *ValidationError", err)
+			}
+			var found bool
+			for _, f := range validationErr.Findings {
+				if f.Field == tt.field && f.Severity == validate.Error {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("no error for %s: %v", tt.field, validationErr.Findings)
+			}
+			if len(client.calls) != 0 {
+				t.Errorf("remote calls before rejecting: %v", client.calls)
+			}
+		})
+	}
+}
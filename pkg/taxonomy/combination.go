@@ -0,0 +1,119 @@
+package taxonomy
+
+import "fmt"
+
+// Combination is a row of Bolagsverket's "Kombinationer av taxonomirapporter"
+// (version 1.4, 2025-05-24): the taxonomy reports that together may make
+// up a document filed for registration.
+type Combination struct {
+	Document  string // e.g. "Endast årsredovisning enligt K2 2024-09-12"
+	Framework string // "K2" or "K3"
+	Version   string // version of the framework taxonomy
+
+	// Group is set for an års- och koncernredovisning, which adds the
+	// K3 koncern taxonomy of the same version, the fastställelseintyg for
+	// "Års- & koncernredovisning" and a revisionsberättelse with
+	// koncernuttalande.
+	Group bool
+
+	Certification string   // fastställelseintyg version
+	AuditReports  []string // revisionsberättelse versions
+}
+
+// AllowsAuditReport reports whether a revisionsberättelse of the given
+// version may be part of the document.
+func (c *Combination) AllowsAuditReport(version string) bool {
+	for _, v := range c.AuditReports {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// combinations is the table of "Kombinationer av taxonomirapporter" 1.4.
+var combinations = []Combination{
+	{
+		Document: "Endast årsredovisning enligt K2 2021-10-31", Framework: "K2", Version: "2021-10-31",
+		Certification: "2020-12-01", AuditReports: []string{"2017-09-30", "2020-12-01"},
+	},
+	{
+		Document: "Endast årsredovisning enligt K2 2024-09-12", Framework: "K2", Version: "2024-09-12",
+		Certification: "2020-12-01", AuditReports: []string{"2017-09-30", "2020-12-01"},
+	},
+	{
+		Document: "Endast årsredovisning enligt K3 2020-12-01", Framework: "K3", Version: "2020-12-01",
+		Certification: "2020-12-01", AuditReports: []string{"2017-09-30", "2020-12-01"},
+	},
+	{
+		Document: "Endast årsredovisning enligt K3 2021-10-31", Framework: "K3", Version: "2021-10-31",
+		Certification: "2020-12-01", AuditReports: []string{"2017-09-30", "2020-12-01"},
+	},
+	{
+		Document: "Års- och koncernredovisning enligt K3 2020-12-01", Framework: "K3", Version: "2020-12-01", Group: true,
+		Certification: "2020-12-01", AuditReports: []string{"2020-12-01"},
+	},
+	{
+		Document: "Års- och koncernredovisning enligt K3 2021-10-31", Framework: "K3", Version: "2021-10-31", Group: true,
+		Certification: "2020-12-01", AuditReports: []string{"2020-12-01"},
+	},
+}
+
+// Combinations returns the allowed combinations of taxonomy reports.
+func Combinations() []Combination {
+	return append([]Combination(nil), combinations...)
+}
+
+// K2Combination returns the combination for an annual report (without
+// group accounts) under the given K2 version, or nil if that version may
+// not be filed.
+func K2Combination(version string) *Combination {
+	for i := range combinations {
+		c := &combinations[i]
+		if c.Framework == "K2" && c.Version == version && !c.Group {
+			return c
+		}
+	}
+	return nil
+}
+
+// certificationSchema returns the schema URL of the fastställelseintyg
+// ("Endast årsredovisning") of the given version.
+func certificationSchema(version string) string {
+	return fmt.Sprintf("http://xbrl.taxonomier.se/se/fr/gaap/k2/rcoa/%[1]s/se-k2-rcoa-%[1]s.xsd", version)
+}
+
+// DocumentType is a handling.typ value of Bolagsverket's submission API.
+type DocumentType struct {
+	Name        string // e.g. "arsredovisning_komplett"
+	Description string
+
+	// EmbeddedAuditReport reports whether the document must contain the
+	// revisionsberättelse when the company is required to have one.
+	EmbeddedAuditReport bool
+}
+
+// documentTypes lists the handling.typ values redofri can file.
+var documentTypes = []DocumentType{
+	{
+		Name:                "arsredovisning_komplett",
+		Description:         "Årsredovisning med fastställelseintyg och, när den krävs, revisionsberättelse",
+		EmbeddedAuditReport: true,
+	},
+}
+
+// LookupDocumentType returns the document type with the given name, or
+// nil if redofri does not know it.
+func LookupDocumentType(name string) *DocumentType {
+	for i := range documentTypes {
+		if documentTypes[i].Name == name {
+			return &documentTypes[i]
+		}
+	}
+	return nil
+}
+
+// DocumentTypes returns the known handling.typ values.
+func DocumentTypes() []DocumentType {
+	return append([]DocumentType(nil), documentTypes...)
+}
@@ -0,0 +1,147 @@
+package taxonomy
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// Coverage lists which concepts of a taxonomy a set of supported concepts
+// covers, per presentation role. Abstract concepts, which are headings
+// only, are listed but not counted.
+type Coverage struct {
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+
+	Concepts  int `json:"concepts"`  // non-abstract concepts in the taxonomy
+	Supported int `json:"supported"` // of which supported
+
+	Roles []RoleCoverage `json:"roles"`
+
+	// Unknown lists supported concepts the taxonomy does not declare,
+	// e.g. after a concept was renamed in a new release.
+	Unknown []string `json:"unknown,omitempty"`
+}
+
+// RoleCoverage is the coverage of one presentation role. Concepts not
+// presented in any role are listed under the role "".
+type RoleCoverage struct {
+	Role       string            `json:"role"`
+	Definition string            `json:"definition,omitempty"`
+	Concepts   []ConceptCoverage `json:"concepts"`
+}
+
+// ConceptCoverage tells whether a concept is supported.
+type ConceptCoverage struct {
+	Name      string `json:"name"`
+	Label     string `json:"label,omitempty"`
+	Abstract  bool   `json:"abstract,omitempty"`
+	Supported bool   `json:"supported"`
+}
+
+// Coverage compares the taxonomy with the names of supported concepts.
+// Labels are given in language.
+func (t *Taxonomy) Coverage(supported []string, language string) *Coverage {
+	cov := &Coverage{Name: t.Name, Version: t.Version}
+	isSupported := map[string]bool{}
+	for _, name := range supported {
+		isSupported[name] = true
+		if t.Concept(name) == nil {
+			cov.Unknown = append(cov.Unknown, name)
+		}
+	}
+
+	entry := func(c *Concept) ConceptCoverage {
+		return ConceptCoverage{
+			Name:      c.Name,
+			Label:     c.Label(RoleLabel, language),
+			Abstract:  c.Abstract,
+			Supported: isSupported[c.Name],
+		}
+	}
+	presented := map[*Concept]bool{}
+	for _, role := range t.PresentationRoles() {
+		rc := RoleCoverage{Role: role, Definition: t.Roles[role]}
+		for _, c := range t.RoleConcepts(role) {
+			presented[c] = true
+			rc.Concepts = append(rc.Concepts, entry(c))
+		}
+		cov.Roles = append(cov.Roles, rc)
+	}
+	var rest RoleCoverage
+	for _, c := range t.Concepts {
+		if !c.Abstract {
+			cov.Concepts++
+			if isSupported[c.Name] {
+				cov.Supported++
+			}
+		}
+		if !presented[c] {
+			rest.Concepts = append(rest.Concepts, entry(c))
+		}
+	}
+	if len(rest.Concepts) > 0 {
+		cov.Roles = append(cov.Roles, rest)
+	}
+	return cov
+}
+
+// WriteText writes the coverage for reading: a summary, then each role
+// with its concepts marked "+" when supported and "-" when not.
+func (c *Coverage) WriteText(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	if c.Name != "" {
+		fmt.Fprintf(bw, "Taxonomy: %s %s\n", c.Name, c.Version)
+	}
+	fmt.Fprintf(bw, "Supported: %d of %d concepts (%s)\n", c.Supported, c.Concepts, percent(c.Supported, c.Concepts))
+
+	for _, rc := range c.Roles {
+		var n, supported int
+		for _, cc := range rc.Concepts {
+			if !cc.Abstract {
+				n++
+				if cc.Supported {
+					supported++
+				}
+			}
+		}
+		title := rc.Definition
+		switch {
+		case rc.Role == "":
+			title = "Not presented"
+		case title == "":
+			title = rc.Role
+		}
+		fmt.Fprintf(bw, "\n%s: %d of %d (%s)\n", title, supported, n, percent(supported, n))
+		for _, cc := range rc.Concepts {
+			marker := "-"
+			switch {
+			case cc.Abstract:
+				marker = " "
+			case cc.Supported:
+				marker = "+"
+			}
+			if cc.Label != "" {
+				fmt.Fprintf(bw, "  %s %s (%s)\n", marker, cc.Name, cc.Label)
+			} else {
+				fmt.Fprintf(bw, "  %s %s\n", marker, cc.Name)
+			}
+		}
+	}
+
+	if len(c.Unknown) > 0 {
+		fmt.Fprintf(bw, "\nNot in the taxonomy:\n")
+		for _, name := range c.Unknown {
+			fmt.Fprintf(bw, "  ! %s\n", name)
+		}
+	}
+	return bw.Flush()
+}
+
+// percent formats n of total as a percentage with one decimal.
+func percent(n, total int) string {
+	if total == 0 {
+		return "0.0%"
+	}
+	return fmt.Sprintf("%.1f%%", 100*float64(n)/float64(total))
+}
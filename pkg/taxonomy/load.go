@@ -0,0 +1,441 @@
+package taxonomy
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Namespaces read by the loader.
+const (
+	xsNS    = "http://www.w3.org/2001/XMLSchema"
+	xbrliNS = "http://www.xbrl.org/2003/instance"
+	linkNS  = "http://www.xbrl.org/2003/linkbase"
+	xlinkNS = "http://www.w3.org/1999/xlink"
+)
+
+// Arcroles of the relationships kept.
+const (
+	arcroleConceptLabel = "http://www.xbrl.org/2003/arcrole/concept-label"
+	arcroleParentChild  = "http://www.xbrl.org/2003/arcrole/parent-child"
+	arcroleSummation    = "http://www.xbrl.org/2003/arcrole/summation-item"
+)
+
+// Load reads a taxonomy package from a directory or a ZIP file.
+func Load(path string) (*Taxonomy, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading taxonomy package: %w", err)
+	}
+	if info.IsDir() {
+		return LoadFS(os.DirFS(path))
+	}
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading taxonomy package %s: %w", path, err)
+	}
+	defer zr.Close()
+	return LoadFS(zr)
+}
+
+// LoadFS reads a taxonomy package from a file system, e.g. an opened ZIP
+// archive. Schemas are read before linkbases, so that every locator can
+// be resolved regardless of the file order.
+func LoadFS(fsys fs.FS) (*Taxonomy, error) {
+	var schemas, others []string
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		switch strings.ToLower(path.Ext(p)) {
+		case ".xsd":
+			schemas = append(schemas, p)
+		case ".xml":
+			others = append(others, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading taxonomy package: %w", err)
+	}
+
+	l := &loader{
+		t: &Taxonomy{
+			Roles:  map[string]string{},
+			byName: map[string]*Concept{},
+			byID:   map[string]*Concept{},
+		},
+		seen: map[string]bool{},
+	}
+	for _, p := range schemas {
+		if err := l.readFile(fsys, p, l.readSchema); err != nil {
+			return nil, err
+		}
+	}
+	for _, p := range others {
+		if err := l.readFile(fsys, p, l.readXML); err != nil {
+			return nil, err
+		}
+	}
+	if len(l.t.Concepts) == 0 {
+		return nil, errors.New("reading taxonomy package: no concepts found")
+	}
+	l.finish()
+	return l.t, nil
+}
+
+// loader holds state while a package is read.
+type loader struct {
+	t          *Taxonomy
+	seen       map[string]bool // arc keys, to drop duplicates
+	prohibited []string        // keys of prohibited arcs
+}
+
+// readFile opens a file of the package and passes it to read.
+func (l *loader) readFile(fsys fs.FS, p string, read func([]byte) error) error {
+	data, err := fs.ReadFile(fsys, p)
+	if err != nil {
+		return fmt.Errorf("reading taxonomy package: %w", err)
+	}
+	if err := read(data); err != nil {
+		return fmt.Errorf("reading %s: %w", p, err)
+	}
+	return nil
+}
+
+// readSchema reads the element declarations and role types of a schema.
+func (l *loader) readSchema(data []byte) error {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	var (
+		targetNS string
+		prefixes = map[string]string{} // namespace → prefix
+		depth    int
+		role     string          // roleURI of the enclosing link:roleType
+		def      strings.Builder // its link:definition
+		inDef    bool
+	)
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			depth++
+			switch {
+			case depth == 1:
+				if tok.Name.Space != xsNS || tok.Name.Local != "schema" {
+					return nil // not a schema
+				}
+				for _, a := range tok.Attr {
+					switch {
+					case a.Name.Space == "" && a.Name.Local == "targetNamespace":
+						targetNS = a.Value
+					case a.Name.Space == "xmlns":
+						if _, ok := prefixes[a.Value]; !ok {
+							prefixes[a.Value] = a.Name.Local
+						}
+					}
+				}
+			case depth == 2 && tok.Name.Space == xsNS && tok.Name.Local == "element":
+				l.addConcept(tok, targetNS, prefixes[targetNS])
+			case tok.Name.Space == linkNS && tok.Name.Local == "roleType":
+				role = attr(tok, "", "roleURI")
+				def.Reset()
+			case tok.Name.Space == linkNS && tok.Name.Local == "definition":
+				inDef = true
+			}
+		case xml.CharData:
+			if inDef {
+				def.Write(tok)
+			}
+		case xml.EndElement:
+			depth--
+			switch {
+			case tok.Name.Space == linkNS && tok.Name.Local == "definition":
+				inDef = false
+			case tok.Name.Space == linkNS && tok.Name.Local == "roleType":
+				if role != "" {
+					l.t.Roles[role] = strings.Join(strings.Fields(def.String()), " ")
+				}
+				role = ""
+			}
+		}
+	}
+}
+
+// addConcept adds a global element declaration. Its QName uses the prefix
+// the schema declares for its target namespace, or else the prefix of an
+// id on the "prefix_Name" pattern.
+func (l *loader) addConcept(el xml.StartElement, namespace, prefix string) {
+	c := &Concept{
+		Namespace:         namespace,
+		ID:                attr(el, "", "id"),
+		Type:              attr(el, "", "type"),
+		SubstitutionGroup: attr(el, "", "substitutionGroup"),
+		PeriodType:        attr(el, xbrliNS, "periodType"),
+		Balance:           attr(el, xbrliNS, "balance"),
+		Abstract:          attr(el, "", "abstract") == "true",
+		Nillable:          attr(el, "", "nillable") == "true",
+	}
+	local := attr(el, "", "name")
+	if local == "" {
+		return
+	}
+	if p, ok := strings.CutSuffix(c.ID, "_"+local); prefix == "" && ok {
+		prefix = p
+	}
+	if prefix != "" {
+		c.Name = prefix + ":" + local
+	} else {
+		c.Name = "{" + namespace + "}" + local
+	}
+	if l.t.byName[c.Name] != nil {
+		return // the same schema in two places
+	}
+	l.t.Concepts = append(l.t.Concepts, c)
+	l.t.byName[c.Name] = c
+	l.t.byName["{"+namespace+"}"+local] = c
+	if c.ID != "" {
+		l.t.byID[c.ID] = c
+	}
+}
+
+// readXML reads a linkbase or the package description; other XML files,
+// such as catalog.xml, are skipped.
+func (l *loader) readXML(data []byte) error {
+	root, err := rootName(data)
+	if err != nil {
+		return err
+	}
+	switch {
+	case root.Space == linkNS && root.Local == "linkbase":
+		return l.readLinkbase(data)
+	case root.Local == "taxonomyPackage":
+		return l.readPackage(data)
+	}
+	return nil
+}
+
+// xmlPackage is META-INF/taxonomyPackage.xml. Elements are matched by
+// local name, as in the 2015 and 2016 versions of the specification.
+type xmlPackage struct {
+	Names       []string `xml:"name"`
+	Version     string   `xml:"version"`
+	EntryPoints []struct {
+		Names        []string `xml:"name"`
+		Descriptions []string `xml:"description"`
+		Documents    []struct {
+			Href string `xml:"href,attr"`
+		} `xml:"entryPointDocument"`
+	} `xml:"entryPoints>entryPoint"`
+}
+
+func (l *loader) readPackage(data []byte) error {
+	var pkg xmlPackage
+	if err := xml.Unmarshal(data, &pkg); err != nil {
+		return err
+	}
+	l.t.Name = first(pkg.Names)
+	l.t.Version = strings.TrimSpace(pkg.Version)
+	for _, e := range pkg.EntryPoints {
+		ep := EntryPoint{Name: first(e.Names), Description: first(e.Descriptions)}
+		for _, d := range e.Documents {
+			ep.Documents = append(ep.Documents, d.Href)
+		}
+		l.t.EntryPoints = append(l.t.EntryPoints, ep)
+	}
+	return nil
+}
+
+// xmlLink is an extended link: a labelLink, presentationLink,
+// calculationLink or any other kind, which is skipped.
+type xmlLink struct {
+	XMLName xml.Name
+	Role    string     `xml:"http://www.w3.org/1999/xlink role,attr"`
+	Locs    []xmlLoc   `xml:"http://www.xbrl.org/2003/linkbase loc"`
+	Labels  []xmlLabel `xml:"http://www.xbrl.org/2003/linkbase label"`
+	Arcs    []xmlArc   `xml:",any"`
+}
+
+type xmlLoc struct {
+	Label string `xml:"http://www.w3.org/1999/xlink label,attr"`
+	Href  string `xml:"http://www.w3.org/1999/xlink href,attr"`
+}
+
+type xmlLabel struct {
+	Label string `xml:"http://www.w3.org/1999/xlink label,attr"`
+	Role  string `xml:"http://www.w3.org/1999/xlink role,attr"`
+	Lang  string `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+	Text  string `xml:",chardata"`
+}
+
+type xmlArc struct {
+	XMLName        xml.Name
+	Arcrole        string `xml:"http://www.w3.org/1999/xlink arcrole,attr"`
+	From           string `xml:"http://www.w3.org/1999/xlink from,attr"`
+	To             string `xml:"http://www.w3.org/1999/xlink to,attr"`
+	Order          string `xml:"order,attr"`
+	Weight         string `xml:"weight,attr"`
+	PreferredLabel string `xml:"preferredLabel,attr"`
+	Use            string `xml:"use,attr"`
+}
+
+func (l *loader) readLinkbase(data []byte) error {
+	var lb struct {
+		Links []xmlLink `xml:",any"`
+	}
+	if err := xml.Unmarshal(data, &lb); err != nil {
+		return err
+	}
+	for _, link := range lb.Links {
+		if link.XMLName.Space != linkNS {
+			continue
+		}
+		switch link.XMLName.Local {
+		case "labelLink":
+			l.readLabelLink(link)
+		case "presentationLink":
+			l.readRelationships(link, "presentationArc", arcroleParentChild, &l.t.Presentation)
+		case "calculationLink":
+			l.readRelationships(link, "calculationArc", arcroleSummation, &l.t.Calculation)
+		}
+	}
+	return nil
+}
+
+// locators maps the xlink:labels of an extended link's locators to the
+// concepts they point to. Locators to elements outside the package are
+// left out.
+func (l *loader) locators(link xmlLink) map[string][]*Concept {
+	locs := map[string][]*Concept{}
+	for _, loc := range link.Locs {
+		_, id, _ := strings.Cut(loc.Href, "#")
+		if c := l.t.byID[id]; c != nil {
+			locs[loc.Label] = append(locs[loc.Label], c)
+		}
+	}
+	return locs
+}
+
+func (l *loader) readLabelLink(link xmlLink) {
+	locs := l.locators(link)
+	resources := map[string][]xmlLabel{}
+	for _, lbl := range link.Labels {
+		resources[lbl.Label] = append(resources[lbl.Label], lbl)
+	}
+	for _, arc := range link.Arcs {
+		if arc.XMLName.Local != "labelArc" || arc.Arcrole != arcroleConceptLabel || arc.Use == "prohibited" {
+			continue
+		}
+		for _, c := range locs[arc.From] {
+			for _, lbl := range resources[arc.To] {
+				role := lbl.Role
+				if role == "" {
+					role = RoleLabel
+				}
+				if c.labels == nil {
+					c.labels = map[labelKey]string{}
+				}
+				c.labels[labelKey{role, strings.ToLower(lbl.Lang)}] = strings.TrimSpace(lbl.Text)
+			}
+		}
+	}
+}
+
+// readRelationships adds the arcs of one extended link with the given
+// element name and arcrole to arcs. Prohibited arcs are remembered and
+// removed once the whole package is read.
+func (l *loader) readRelationships(link xmlLink, element, arcrole string, arcs *[]*Arc) {
+	locs := l.locators(link)
+	for _, x := range link.Arcs {
+		if x.XMLName.Local != element || x.Arcrole != arcrole {
+			continue
+		}
+		for _, from := range locs[x.From] {
+			for _, to := range locs[x.To] {
+				key := element + " " + link.Role + " " + from.Name + " " + to.Name
+				if x.Use == "prohibited" {
+					l.prohibited = append(l.prohibited, key)
+					continue
+				}
+				if l.seen[key] {
+					continue
+				}
+				l.seen[key] = true
+				a := &Arc{Role: link.Role, From: from, To: to, Order: 1, Weight: 1, PreferredLabel: x.PreferredLabel}
+				if x.Order != "" {
+					a.Order, _ = strconv.ParseFloat(x.Order, 64)
+				}
+				if x.Weight != "" {
+					a.Weight, _ = strconv.ParseFloat(x.Weight, 64)
+				}
+				*arcs = append(*arcs, a)
+			}
+		}
+	}
+}
+
+// finish removes prohibited arcs and sorts the concepts and arcs.
+func (l *loader) finish() {
+	for _, key := range l.prohibited {
+		l.seen[key] = false
+	}
+	keep := func(element string, arcs []*Arc) []*Arc {
+		out := arcs[:0]
+		for _, a := range arcs {
+			if l.seen[element+" "+a.Role+" "+a.From.Name+" "+a.To.Name] {
+				out = append(out, a)
+			}
+		}
+		sortArcs(out)
+		return out
+	}
+	l.t.Presentation = keep("presentationArc", l.t.Presentation)
+	l.t.Calculation = keep("calculationArc", l.t.Calculation)
+	sort.Slice(l.t.Concepts, func(i, j int) bool { return l.t.Concepts[i].Name < l.t.Concepts[j].Name })
+}
+
+// rootName returns the name of a document's root element.
+func rootName(data []byte) (xml.Name, error) {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return xml.Name{}, err
+		}
+		if el, ok := tok.(xml.StartElement); ok {
+			return el.Name, nil
+		}
+	}
+}
+
+// attr returns the value of an attribute, or "".
+func attr(el xml.StartElement, space, local string) string {
+	for _, a := range el.Attr {
+		if a.Name.Space == space && a.Name.Local == local {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// first returns the first of a list of texts, trimmed.
+func first(s []string) string {
+	if len(s) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(s[0])
+}
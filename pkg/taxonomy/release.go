@@ -0,0 +1,186 @@
+package taxonomy
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CurrentK2 is the K2 release used when a report does not name one.
+const CurrentK2 = "2024-09-12"
+
+// Release is a published release of the K2 taxonomy for aktiebolag: the
+// namespaces its concepts live in and the schemas a report refers to.
+type Release struct {
+	Version string // e.g. "2024-09-12"
+
+	// Namespaces maps the canonical prefixes se-gen-base, se-cd-base and
+	// se-bol-base to the namespaces of this release.
+	Namespaces map[string]string
+
+	// Certification is the fastställelseintyg version used with a release
+	// that is no longer in the table of combinations.
+	Certification string
+
+	// Missing lists concepts redofri supports that the release does not
+	// declare. The generator leaves them untagged. WithPackage replaces it
+	// with what the release's taxonomy package declares.
+	Missing []string
+
+	entryPoint string // URL with %[1]s for the variant and %[2]s for the version
+}
+
+// EntryPointURL returns the schema URL of an entry point variant, e.g.
+// "risbs".
+func (r *Release) EntryPointURL(variant string) string {
+	return fmt.Sprintf(r.entryPoint, variant, r.Version)
+}
+
+// Accepted reports whether Bolagsverket accepts the release for new
+// filings, i.e. whether it is in the table of combinations. Older releases
+// can still be parsed and regenerated, e.g. to correct a report filed with
+// them.
+func (r *Release) Accepted() bool {
+	return K2Combination(r.Version) != nil
+}
+
+// CertificationSchema returns the schema URL of the fastställelseintyg
+// used with the release.
+func (r *Release) CertificationSchema() string {
+	if c := K2Combination(r.Version); c != nil {
+		return certificationSchema(c.Certification)
+	}
+	return certificationSchema(r.Certification)
+}
+
+// Declares reports whether the release declares a supported concept.
+func (r *Release) Declares(concept string) bool {
+	for _, m := range r.Missing {
+		if m == concept {
+			return false
+		}
+	}
+	return true
+}
+
+// WithPackage returns a copy of the release whose Missing lists the
+// concepts, named with the canonical prefixes, that the taxonomy package
+// t does not declare. Only namespaces of the release that t declares
+// concepts in are judged, so a package of some of the release's schemas
+// can be used; concepts of the other namespaces keep the table's entries.
+// A package of none of them is an error.
+func (r *Release) WithPackage(t *Taxonomy, concepts []string) (*Release, error) {
+	covered := map[string]bool{}
+	for _, c := range t.Concepts {
+		covered[c.Namespace] = true
+	}
+	release := *r
+	release.Missing = nil
+	judged := false
+	for _, concept := range concepts {
+		prefix, local, _ := strings.Cut(concept, ":")
+		ns, ok := r.Namespaces[prefix]
+		if !ok || !covered[ns] {
+			if !r.Declares(concept) {
+				release.Missing = append(release.Missing, concept)
+			}
+			continue
+		}
+		judged = true
+		if t.Lookup(ns, local) == nil {
+			release.Missing = append(release.Missing, concept)
+		}
+	}
+	if !judged {
+		return nil, fmt.Errorf("taxonomy package declares no concepts in the namespaces of K2 release %s", r.Version)
+	}
+	return &release, nil
+}
+
+// k2Releases lists the K2 releases redofri can write, newest first.
+//
+// Missing is empty for every release. 2024-09-12 and 2021-10-31 share
+// gen-base and cd-base 2021-10-31, so they declare the same concepts, and
+// Bolagsverket's 2017-09-30 example filings tag every supported concept
+// but six fixed asset note concepts of families they do tag (see
+// TestConceptsFor_ReferenceExamples in pkg/ixbrl). A concept found to be
+// missing from a release is listed here; Release.WithPackage gives the
+// list for a taxonomy package at hand.
+var k2Releases = []*Release{
+	{
+		Version: "2024-09-12",
+		Namespaces: map[string]string{
+			"se-gen-base": "http://www.taxonomier.se/se/fr/gen-base/2021-10-31",
+			"se-cd-base":  "http://www.taxonomier.se/se/fr/cd-base/2021-10-31",
+			"se-bol-base": "http://www.bolagsverket.se/se/fr/comp-base/2017-09-30",
+		},
+		entryPoint: "http://xbrl.taxonomier.se/se/fr/gaap/k2-all/ab/%[1]s/%[2]s/se-k2-ab-%[1]s-%[2]s.xsd",
+	},
+	{
+		Version: "2021-10-31",
+		Namespaces: map[string]string{
+			"se-gen-base": "http://www.taxonomier.se/se/fr/gen-base/2021-10-31",
+			"se-cd-base":  "http://www.taxonomier.se/se/fr/cd-base/2021-10-31",
+			"se-bol-base": "http://www.bolagsverket.se/se/fr/comp-base/2017-09-30",
+		},
+		entryPoint: "http://xbrl.taxonomier.se/se/fr/gaap/k2-all/ab/%[1]s/%[2]s/se-k2-ab-%[1]s-%[2]s.xsd",
+	},
+	{
+		Version: "2017-09-30",
+		Namespaces: map[string]string{
+			"se-gen-base": "http://www.taxonomier.se/se/fr/gen-base/2017-09-30",
+			"se-cd-base":  "http://www.taxonomier.se/se/fr/cd-base/2017-09-30",
+			"se-bol-base": "http://www.bolagsverket.se/se/fr/comp-base/2017-09-30",
+		},
+		Certification: "2017-09-30",
+		entryPoint:    "http://xbrl.taxonomier.se/se/fr/gaap/k2/%[1]s/%[2]s/se-k2-%[1]s-%[2]s.xsd",
+	},
+}
+
+// K2Release returns the K2 release with the given version, or nil if
+// redofri does not know it. The empty version gives CurrentK2.
+func K2Release(version string) *Release {
+	if version == "" {
+		version = CurrentK2
+	}
+	for _, r := range k2Releases {
+		if r.Version == version {
+			return r
+		}
+	}
+	return nil
+}
+
+// K2Releases returns the known K2 releases, newest first.
+func K2Releases() []*Release {
+	return append([]*Release(nil), k2Releases...)
+}
+
+// K2Versions returns the versions of the known K2 releases, newest first.
+func K2Versions() []string {
+	var versions []string
+	for _, r := range k2Releases {
+		versions = append(versions, r.Version)
+	}
+	return versions
+}
+
+// DetectK2Release returns the K2 release and entry point variant a report
+// refers to, from its schemaRef hrefs. It returns nil if no href is a
+// known K2 entry point.
+func DetectK2Release(schemaRefs []string) (release *Release, variant string) {
+	for _, href := range schemaRefs {
+		file := href[strings.LastIndex(href, "/")+1:]
+		for _, r := range k2Releases {
+			rest, ok := strings.CutSuffix(file, "-"+r.Version+".xsd")
+			if !ok {
+				continue
+			}
+			rest, ok = strings.CutPrefix(rest, "se-k2-")
+			if !ok || strings.HasPrefix(rest, "rcoa") {
+				continue // not K2, or the fastställelseintyg
+			}
+			return r, strings.TrimPrefix(rest, "ab-")
+		}
+	}
+	return nil, ""
+}
@@ -0,0 +1,234 @@
+// Package taxonomy reads an XBRL taxonomy package from local files: the
+// schemas declaring the concepts, and the label, presentation and
+// calculation linkbases relating them.
+//
+// Load never goes to the network. It reads every schema and linkbase in
+// the package instead of following imports and linkbaseRefs, so a package
+// with several entry points (the K2 variants risbs, rbs, ...) gives the
+// union of their relationships. Linkbase locators are resolved by the id
+// of the element they point to, which is unique across the Swedish
+// taxonomies, so packages work whether their hrefs are relative or point
+// at the published http:// locations.
+package taxonomy
+
+import (
+	"sort"
+	"strings"
+)
+
+// Standard label roles.
+const (
+	RoleLabel         = "http://www.xbrl.org/2003/role/label"
+	RoleTerseLabel    = "http://www.xbrl.org/2003/role/terseLabel"
+	RoleVerboseLabel  = "http://www.xbrl.org/2003/role/verboseLabel"
+	RoleTotalLabel    = "http://www.xbrl.org/2003/role/totalLabel"
+	RoleDocumentation = "http://www.xbrl.org/2003/role/documentation"
+)
+
+// Taxonomy is a loaded taxonomy package.
+type Taxonomy struct {
+	// Name and Version are read from META-INF/taxonomyPackage.xml, if
+	// the package has one.
+	Name    string
+	Version string
+
+	// EntryPoints lists the entry points declared by the package.
+	EntryPoints []EntryPoint
+
+	// Concepts holds every element declared in the package's schemas,
+	// sorted by name.
+	Concepts []*Concept
+
+	// Roles maps extended link role URIs to their definitions, e.g.
+	// "Resultaträkning, kostnadsslagsindelad".
+	Roles map[string]string
+
+	// Presentation holds the parent-child arcs and Calculation the
+	// summation-item arcs, each sorted by role, parent and order.
+	Presentation []*Arc
+	Calculation  []*Arc
+
+	byName map[string]*Concept // "prefix:Local" and "{namespace}Local"
+	byID   map[string]*Concept
+}
+
+// EntryPoint is an entry point of a taxonomy package.
+type EntryPoint struct {
+	Name        string
+	Description string
+	Documents   []string // hrefs of the entry point schemas
+}
+
+// Concept is an element declared in a taxonomy schema.
+type Concept struct {
+	Name      string // QName, e.g. "se-gen-base:Nettoomsattning"
+	Namespace string
+	ID        string
+
+	Type              string // e.g. "xbrli:monetaryItemType"
+	SubstitutionGroup string // e.g. "xbrli:item", "xbrli:tuple"
+	PeriodType        string // "instant" or "duration"
+	Balance           string // "debit", "credit" or ""
+	Abstract          bool
+	Nillable          bool
+
+	labels map[labelKey]string
+}
+
+type labelKey struct {
+	role, lang string
+}
+
+// Arc is a presentation or calculation relationship between two concepts.
+type Arc struct {
+	Role     string // extended link role
+	From, To *Concept
+	Order    float64
+
+	Weight         float64 // calculation arcs
+	PreferredLabel string  // presentation arcs, if set
+}
+
+// LocalName returns the concept name without prefix.
+func (c *Concept) LocalName() string {
+	_, local, ok := strings.Cut(c.Name, ":")
+	if !ok {
+		return c.Name
+	}
+	return local
+}
+
+// IsTuple reports whether the concept is a tuple.
+func (c *Concept) IsTuple() bool {
+	return localPart(c.SubstitutionGroup) == "tuple"
+}
+
+// Label returns the concept's label with the given role in the given
+// language ("sv", "en", "en-GB"). Missing roles fall back to the standard
+// label; missing languages give "".
+func (c *Concept) Label(role, language string) string {
+	language = strings.ToLower(language)
+	base, _, _ := strings.Cut(language, "-")
+	for _, r := range []string{role, RoleLabel} {
+		for _, l := range []string{language, base} {
+			if s, ok := c.labels[labelKey{r, l}]; ok {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// Concept returns the concept with the given QName ("se-gen-base:Nettoomsattning")
+// or expanded name ("{http://...}Nettoomsattning"), or nil.
+func (t *Taxonomy) Concept(name string) *Concept {
+	return t.byName[name]
+}
+
+// Lookup returns the concept with the given namespace and local name, or nil.
+func (t *Taxonomy) Lookup(namespace, local string) *Concept {
+	return t.byName["{"+namespace+"}"+local]
+}
+
+// Label returns the standard label of the named concept in the given
+// language, or "" if the concept or the label is missing.
+func (t *Taxonomy) Label(name, language string) string {
+	c := t.Concept(name)
+	if c == nil {
+		return ""
+	}
+	return c.Label(RoleLabel, language)
+}
+
+// Summation is the calculation of one total in one extended link role.
+type Summation struct {
+	Role  string
+	Total *Concept
+	Items []*Arc // arcs from Total, in order
+}
+
+// Summations groups the calculation arcs by role and total, in the order
+// of Calculation.
+func (t *Taxonomy) Summations() []Summation {
+	var sums []Summation
+	for _, a := range t.Calculation {
+		if n := len(sums); n > 0 && sums[n-1].Role == a.Role && sums[n-1].Total == a.From {
+			sums[n-1].Items = append(sums[n-1].Items, a)
+			continue
+		}
+		sums = append(sums, Summation{Role: a.Role, Total: a.From, Items: []*Arc{a}})
+	}
+	return sums
+}
+
+// PresentationRoles returns the roles that have presentation arcs, sorted.
+func (t *Taxonomy) PresentationRoles() []string {
+	var roles []string
+	for _, a := range t.Presentation {
+		if len(roles) == 0 || roles[len(roles)-1] != a.Role {
+			roles = append(roles, a.Role)
+		}
+	}
+	return roles
+}
+
+// RoleConcepts returns the concepts of a presentation role in document
+// order: each root followed depth-first by its children.
+func (t *Taxonomy) RoleConcepts(role string) []*Concept {
+	children := map[*Concept][]*Concept{}
+	hasParent := map[*Concept]bool{}
+	var parents []*Concept
+	for _, a := range t.Presentation {
+		if a.Role != role {
+			continue
+		}
+		if _, ok := children[a.From]; !ok {
+			parents = append(parents, a.From)
+		}
+		children[a.From] = append(children[a.From], a.To)
+		hasParent[a.To] = true
+	}
+
+	var out []*Concept
+	seen := map[*Concept]bool{}
+	var walk func(c *Concept)
+	walk = func(c *Concept) {
+		if seen[c] {
+			return
+		}
+		seen[c] = true
+		out = append(out, c)
+		for _, child := range children[c] {
+			walk(child)
+		}
+	}
+	for _, p := range parents {
+		if !hasParent[p] {
+			walk(p)
+		}
+	}
+	return out
+}
+
+// sortArcs orders arcs by role, parent and order, keeping the load order
+// of equal arcs.
+func sortArcs(arcs []*Arc) {
+	sort.SliceStable(arcs, func(i, j int) bool {
+		a, b := arcs[i], arcs[j]
+		if a.Role != b.Role {
+			return a.Role < b.Role
+		}
+		if a.From != b.From {
+			return a.From.Name < b.From.Name
+		}
+		return a.Order < b.Order
+	})
+}
+
+// localPart strips the prefix from a QName.
+func localPart(qname string) string {
+	if i := strings.LastIndex(qname, ":"); i >= 0 {
+		return qname[i+1:]
+	}
+	return qname
+}
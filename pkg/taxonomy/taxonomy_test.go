@@ -0,0 +1,311 @@
+package taxonomy
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testPackage = "../../testdata/taxonomy/k2"
+
+const roleIS = "http://www.taxonomier.se/se/fr/k2/role/ab/risbs/resultatrakning-kostnadsslagsindelad"
+
+func loadTest(t *testing.T) *Taxonomy {
+	t.Helper()
+	tax, err := Load(testPackage)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	return tax
+}
+
+func TestLoad(t *testing.T) {
+	tax := loadTest(t)
+
+	if tax.Name != "K2 Aktiebolag (test)" || tax.Version != "2024-09-12" {
+		t.Errorf("package = %q %q", tax.Name, tax.Version)
+	}
+	if len(tax.EntryPoints) != 1 || !strings.HasSuffix(tax.EntryPoints[0].Documents[0], "se-k2-ab-risbs-2024-09-12.xsd") {
+		t.Errorf("entry points = %+v", tax.EntryPoints)
+	}
+	if len(tax.Concepts) != 21 {
+		t.Errorf("%d concepts, want 21", len(tax.Concepts))
+	}
+
+	c := tax.Concept("se-gen-base:Nettoomsattning")
+	if c == nil {
+		t.Fatal("Nettoomsattning not found")
+	}
+	if c != tax.Lookup("http://www.taxonomier.se/se/fr/gen-base/2021-10-31", "Nettoomsattning") {
+		t.Error("Lookup by namespace gives another concept")
+	}
+	if c.PeriodType != "duration" || c.Balance != "credit" || c.Type != "xbrli:monetaryItemType" || c.Abstract || c.IsTuple() {
+		t.Errorf("concept = %+v", c)
+	}
+	if !tax.Concept("se-gen-base:BalansrakningAbstract").Abstract {
+		t.Error("BalansrakningAbstract is not abstract")
+	}
+
+	labels := []struct{ role, lang, want string }{
+		{RoleLabel, "sv", "Nettoomsättning"},
+		{RoleLabel, "en", "Net sales"},
+		{RoleLabel, "en-GB", "Net sales"},
+		{RoleTotalLabel, "sv", "Nettoomsättning"},
+		{RoleLabel, "de", ""},
+	}
+	for _, l := range labels {
+		if got := c.Label(l.role, l.lang); got != l.want {
+			t.Errorf("Label(%s, %s) = %q, want %q", l.role, l.lang, got, l.want)
+		}
+	}
+	if got := tax.Concept("se-gen-base:Rorelseresultat").Label(RoleDocumentation, "sv"); got != "Resultat före finansiella poster." {
+		t.Errorf("documentation = %q", got)
+	}
+	if got := tax.Label("se-gen-base:Saknas", "sv"); got != "" {
+		t.Errorf("label of missing concept = %q", got)
+	}
+
+	if tax.Roles[roleIS] != "Resultaträkning, kostnadsslagsindelad" {
+		t.Errorf("roles = %v", tax.Roles)
+	}
+	if roles := tax.PresentationRoles(); len(roles) != 2 {
+		t.Errorf("presentation roles = %v", roles)
+	}
+	var names []string
+	for _, c := range tax.RoleConcepts(roleIS)[:4] {
+		names = append(names, c.LocalName())
+	}
+	if want := "ResultatrakningKostnadsslagsindeladAbstract RorelseintakterLagerforandringarMmAbstract Nettoomsattning ForandringLagerProdukterIArbeteFardigaVarorPagaendeArbetenAnnansRakning"; strings.Join(names, " ") != want {
+		t.Errorf("role concepts = %v", names)
+	}
+
+	sums := tax.Summations()
+	if len(sums) != 4 {
+		t.Fatalf("%d summations, want 4", len(sums))
+	}
+	for _, s := range sums {
+		if s.Total.LocalName() != "Rorelseresultat" {
+			continue
+		}
+		if len(s.Items) != 2 || s.Items[0].To.LocalName() != "RorelseintakterLagerforandringarMm" || s.Items[1].Weight != -1 {
+			t.Errorf("Rorelseresultat items = %+v %+v", s.Items[0], s.Items[1])
+		}
+	}
+}
+
+func TestLoadZip(t *testing.T) {
+	// Packages are published as a ZIP with a top-level directory.
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	err := filepath.WalkDir(testPackage, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, _ := filepath.Rel(testPackage, p)
+		w, err := zw.Create("k2/" + filepath.ToSlash(rel))
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "k2.zip")
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tax, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(tax.Concepts) != 21 || len(tax.Calculation) != 14 || tax.Label("se-gen-base:Tillgangar", "sv") != "Summa tillgångar" {
+		t.Errorf("zip package: %d concepts, %d calculation arcs", len(tax.Concepts), len(tax.Calculation))
+	}
+}
+
+func TestLoadProhibited(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.CopyFS(dir, os.DirFS(testPackage)); err != nil {
+		t.Fatal(err)
+	}
+	// An extension linkbase removing AktiveratArbeteEgenRakning from the sum.
+	ext := `<link:linkbase xmlns:link="http://www.xbrl.org/2003/linkbase" xmlns:xlink="http://www.w3.org/1999/xlink">
+  <link:calculationLink xlink:type="extended" xlink:role="` + roleIS + `">
+    <link:loc xlink:type="locator" xlink:href="se-gen-base-2021-10-31.xsd#se-gen-base_RorelseintakterLagerforandringarMm" xlink:label="total"/>
+    <link:loc xlink:type="locator" xlink:href="se-gen-base-2021-10-31.xsd#se-gen-base_AktiveratArbeteEgenRakning" xlink:label="item"/>
+    <link:calculationArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/summation-item" xlink:from="total" xlink:to="item" weight="1" use="prohibited" priority="1"/>
+  </link:calculationLink>
+</link:linkbase>`
+	if err := os.WriteFile(filepath.Join(dir, "a-ext-cal.xml"), []byte(ext), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tax, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	for _, a := range tax.Calculation {
+		if a.To.LocalName() == "AktiveratArbeteEgenRakning" {
+			t.Error("prohibited arc kept")
+		}
+	}
+	if len(tax.Calculation) != 13 {
+		t.Errorf("%d calculation arcs, want 13", len(tax.Calculation))
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	if _, err := Load("does-not-exist"); err == nil {
+		t.Error("missing package: no error")
+	}
+	if _, err := Load(t.TempDir()); err == nil || !strings.Contains(err.Error(), "no concepts") {
+		t.Errorf("empty package: %v", err)
+	}
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "broken.xsd"), []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema"><xs:element`), 0644)
+	if _, err := Load(dir); err == nil || !strings.Contains(err.Error(), "broken.xsd") {
+		t.Errorf("broken schema: %v", err)
+	}
+}
+
+func TestCoverage(t *testing.T) {
+	tax := loadTest(t)
+	cov := tax.Coverage([]string{
+		"se-gen-base:Nettoomsattning",
+		"se-gen-base:RorelseintakterLagerforandringarMm",
+		"se-gen-base:Tillgangar",
+		"se-gen-base:Borttagen",
+	}, "sv")
+
+	if cov.Concepts != 17 || cov.Supported != 3 {
+		t.Errorf("coverage = %d of %d", cov.Supported, cov.Concepts)
+	}
+	if len(cov.Unknown) != 1 || cov.Unknown[0] != "se-gen-base:Borttagen" {
+		t.Errorf("unknown = %v", cov.Unknown)
+	}
+	if len(cov.Roles) != 3 || cov.Roles[2].Role != "" || cov.Roles[2].Concepts[0].Name != "se-gen-base:ImmateriellaAnlaggningstillgangar" {
+		t.Errorf("roles = %+v", cov.Roles)
+	}
+
+	var buf bytes.Buffer
+	if err := cov.WriteText(&buf); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"Taxonomy: K2 Aktiebolag (test) 2024-09-12\nSupported: 3 of 17 concepts (17.6%)\n",
+		"\nBalansräkning: 1 of 3 (33.3%)\n    se-gen-base:BalansrakningAbstract (Balansräkning)\n  - se-gen-base:Anlaggningstillgangar (Summa anläggningstillgångar)\n",
+		"  + se-gen-base:Nettoomsattning (Nettoomsättning)\n",
+		"\nNot presented: 0 of 1 (0.0%)\n",
+		"\nNot in the taxonomy:\n  ! se-gen-base:Borttagen\n",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("text is missing %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestK2Release(t *testing.T) {
+	if r := K2Release(""); r == nil || r.Version != CurrentK2 || !r.Accepted() {
+		t.Errorf("default release = %+v", r)
+	}
+	if r := K2Release("2021-10-31"); r == nil || !r.Accepted() {
+		t.Errorf("previous release = %+v", r)
+	}
+	if r := K2Release("2017-09-30"); r == nil || r.Accepted() {
+		t.Errorf("2017 release = %+v", r)
+	}
+	if r := K2Release("2019-01-01"); r != nil {
+		t.Errorf("unknown release = %+v", r)
+	}
+	if got := K2Release("2021-10-31").EntryPointURL("risbs"); got != "http://xbrl.taxonomier.se/se/fr/gaap/k2-all/ab/risbs/2021-10-31/se-k2-ab-risbs-2021-10-31.xsd" {
+		t.Errorf("entry point = %s", got)
+	}
+}
+
+func TestReleaseWithPackage(t *testing.T) {
+	tax := loadTest(t)
+	concepts := []string{"se-gen-base:Nettoomsattning", "se-gen-base:Utdelning", "se-cd-base:SprakHandlingUpprattadList"}
+	release := K2Release("2024-09-12")
+	got, err := release.WithPackage(tax, concepts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// se-cd-base is not in the package, so its concepts are not judged.
+	if strings.Join(got.Missing, ",") != "se-gen-base:Utdelning" {
+		t.Errorf("Missing = %v", got.Missing)
+	}
+	if release.Missing != nil || got.Declares("se-gen-base:Utdelning") || !got.Declares("se-gen-base:Nettoomsattning") {
+		t.Errorf("release = %+v, with package = %+v", release, got)
+	}
+	if _, err := K2Release("2017-09-30").WithPackage(tax, concepts); err == nil {
+		t.Error("package of another release accepted")
+	}
+}
+
+func TestDetectK2Release(t *testing.T) {
+	tests := []struct {
+		refs    []string
+		version string
+		variant string
+	}{
+		{[]string{
+			"http://xbrl.taxonomier.se/se/fr/gaap/k2-all/ab/risab/2024-09-12/se-k2-ab-risab-2024-09-12.xsd",
+			"http://xbrl.taxonomier.se/se/fr/gaap/k2/rcoa/2020-12-01/se-k2-rcoa-2020-12-01.xsd",
+		}, "2024-09-12", "risab"},
+		{[]string{
+			"http://xbrl.taxonomier.se/se/fr/gaap/k2/rcoa/2017-09-30/se-k2-rcoa-2017-09-30.xsd",
+			"http://xbrl.taxonomier.se/se/fr/gaap/k2/risbs/2017-09-30/se-k2-risbs-2017-09-30.xsd",
+		}, "2017-09-30", "risbs"},
+		{[]string{"http://xbrl.taxonomier.se/se/fr/gaap/k3/2021-10-31/se-k3-ab-2021-10-31.xsd"}, "", ""},
+		{nil, "", ""},
+	}
+	for _, tt := range tests {
+		r, variant := DetectK2Release(tt.refs)
+		version := ""
+		if r != nil {
+			version = r.Version
+		}
+		if version != tt.version || variant != tt.variant {
+			t.Errorf("DetectK2Release(%v) = %q, %q, want %q, %q", tt.refs, version, variant, tt.version, tt.variant)
+		}
+	}
+}
+
+func TestCombinations(t *testing.T) {
+	for _, r := range K2Releases() {
+		c := K2Combination(r.Version)
+		if r.Accepted() != (c != nil) {
+			t.Errorf("K2 %s: accepted = %v, combination %v", r.Version, r.Accepted(), c)
+		}
+	}
+	if c := K2Combination("2024-09-12"); c == nil || !c.AllowsAuditReport("2017-09-30") || c.AllowsAuditReport("2021-10-31") {
+		t.Errorf("K2 2024-09-12 = %+v", c)
+	}
+	if got := K2Release("2024-09-12").CertificationSchema(); got != "http://xbrl.taxonomier.se/se/fr/gaap/k2/rcoa/2020-12-01/se-k2-rcoa-2020-12-01.xsd" {
+		t.Errorf("certification schema = %s", got)
+	}
+	if got := K2Release("2017-09-30").CertificationSchema(); got != "http://xbrl.taxonomier.se/se/fr/gaap/k2/rcoa/2017-09-30/se-k2-rcoa-2017-09-30.xsd" {
+		t.Errorf("2017 certification schema = %s", got)
+	}
+	for _, c := range Combinations() {
+		if c.Group && (c.Framework != "K3" || c.AllowsAuditReport("2017-09-30")) {
+			t.Errorf("group combination %q", c.Document)
+		}
+	}
+	if LookupDocumentType("arsredovisning_komplett") == nil || LookupDocumentType("arsredovisning") != nil {
+		t.Error("LookupDocumentType")
+	}
+}
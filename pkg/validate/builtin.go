@@ -0,0 +1,291 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/redofri/redofri/pkg/ixbrl"
+	"github.com/redofri/redofri/pkg/labels"
+	"github.com/redofri/redofri/pkg/model"
+	"github.com/redofri/redofri/pkg/taxonomy"
+)
+
+// findings collects the findings of a built-in rule.
+type findings []Finding
+
+func (f *findings) add(field, msg string) {
+	*f = append(*f, Finding{field, msg})
+}
+
+// rule returns a built-in rule whose check adds to a findings list.
+func rule(id string, code int, severity Severity, check func(r *model.AnnualReport, f *findings)) Rule {
+	return NewRule(id, code, severity, func(r *model.AnnualReport) []Finding {
+		var f findings
+		check(r, &f)
+		return f
+	})
+}
+
+// required returns a rule reporting an error on field when missing is true.
+func required(id string, code int, field, msg string, missing func(r *model.AnnualReport) bool) Rule {
+	return rule(id, code, Error, func(r *model.AnnualReport, f *findings) {
+		if missing(r) {
+			f.add(field, msg)
+		}
+	})
+}
+
+// builtinRules returns redofri's own rules, in the order they run:
+// required fields, calculations, business rules, value transfers,
+// capital, then continuity between years.
+func builtinRules() []Rule {
+	return []Rule{
+		// 1. Required fields
+
+		required("company-name", 1020, "company.name", "company name is missing",
+			func(r *model.AnnualReport) bool { return r.Company.Name == "" }),
+		required("org-nr", 0, "company.orgNr", "organisation number is missing",
+			func(r *model.AnnualReport) bool { return r.Company.OrgNr == "" }),
+		rule("fiscal-year-dates", 0, Error, func(r *model.AnnualReport, f *findings) {
+			if r.FiscalYear.StartDate == "" {
+				f.add("fiscalYear.startDate", "fiscal year start date is missing")
+			}
+			if r.FiscalYear.EndDate == "" {
+				f.add("fiscalYear.endDate", "fiscal year end date is missing")
+			}
+		}),
+		required("currency", 1037, "meta.currency", "currency is missing",
+			func(r *model.AnnualReport) bool { return r.Meta.Currency == "" }),
+		required("country", 1050, "meta.country", "country code is missing",
+			func(r *model.AnnualReport) bool { return r.Meta.Country == "" }),
+		required("language", 1173, "meta.language", "language indication is missing",
+			func(r *model.AnnualReport) bool { return r.Meta.Language == "" }),
+		required("amount-format", 1174, "meta.amountFormat", "amount format (unit of measurement) is missing",
+			func(r *model.AnnualReport) bool { return r.Meta.AmountFormat == "" }),
+		required("entry-point", 0, "meta.entryPoint", "entry point is missing",
+			func(r *model.AnnualReport) bool { return r.Meta.EntryPoint == "" }),
+		required("certification", 1019, "certification.confirmationText", "fastställelseintyg is missing",
+			func(r *model.AnnualReport) bool { return r.Certification.ConfirmationText == "" }),
+		required("agm-date", 1103, "certification.meetingDate", "AGM date is missing in adoption certificate",
+			func(r *model.AnnualReport) bool { return r.Certification.MeetingDate == "" }),
+		required("certification-signing-date", 1164, "certification.signingDate", "signing date is missing in adoption certificate",
+			func(r *model.AnnualReport) bool { return r.Certification.SigningDate == "" }),
+		required("certification-signatory", 1169, "certification.signatory", "name is missing in adoption certificate",
+			func(r *model.AnnualReport) bool {
+				return r.Certification.Signatory.FirstName == "" && r.Certification.Signatory.LastName == ""
+			}),
+		required("management-report", 1051, "managementReport", "directors' report (förvaltningsberättelse) is missing",
+			func(r *model.AnnualReport) bool {
+				return r.ManagementReport.BusinessDescription == "" && r.ManagementReport.IntroText == ""
+			}),
+		required("income-statement", 1060, "incomeStatement", "income statement is missing (no net result)",
+			func(r *model.AnnualReport) bool { return r.IncomeStatement.NetResult.Current == nil }),
+		required("total-assets", 3001, "balanceSheet.assets.totalAssets", "total assets is missing",
+			func(r *model.AnnualReport) bool { return r.BalanceSheet.Assets.TotalAssets.Current == nil }),
+		required("total-equity-and-liabilities", 3002, "balanceSheet.equityAndLiabilities.totalEquityAndLiabilities",
+			"total equity and liabilities is missing",
+			func(r *model.AnnualReport) bool {
+				return r.BalanceSheet.EquityAndLiabilities.TotalEquityAndLiabilities.Current == nil
+			}),
+		required("signing-date", 1107, "signatures.date", "signing date is missing in annual report",
+			func(r *model.AnnualReport) bool { return r.Signatures.Date == "" }),
+		rule("signatories", 1201, Error, func(r *model.AnnualReport, f *findings) {
+			if len(r.Signatures.Signatories) == 0 {
+				f.add("signatures.signatories", "no signatories in annual report")
+			}
+			for i, s := range r.Signatures.Signatories {
+				if s.FirstName == "" || s.LastName == "" {
+					f.add(fmt.Sprintf("signatures.signatories[%d]", i), "first or last name is missing for signatory")
+				}
+			}
+		}),
+		required("accounting-policies", 0, "notes.accountingPolicies.description", "accounting policies description is missing",
+			func(r *model.AnnualReport) bool { return r.Notes.AccountingPolicies.Description == "" }),
+
+		// 2. Calculations
+
+		rule("calculations", 0, Error, checkCalculations),
+		rule("balance", 3005, Error, checkBalance),
+		rule("shared-facts", 0, Error, checkSharedFacts),
+
+		// 3. Business rules
+
+		rule("currency-allowed", 1038, Error, func(r *model.AnnualReport, f *findings) {
+			if r.Meta.Currency != "" && r.Meta.Currency != "SEK" && r.Meta.Currency != "EUR" {
+				f.add("meta.currency", fmt.Sprintf("currency must be SEK or EUR, got %q", r.Meta.Currency))
+			}
+		}),
+		// Other languages are rendered from the label catalogue, or with
+		// Swedish labels if there is none.
+		rule("swedish-language", 1116, Warning, func(r *model.AnnualReport, f *findings) {
+			if r.Meta.Language == "" || r.Meta.Language == "sv" {
+				return
+			}
+			if labels.Supported(r.Meta.Language) {
+				f.add("meta.language",
+					"annual report does not appear to be prepared in Swedish; file it only where a translated version is accepted")
+			} else {
+				f.add("meta.language",
+					fmt.Sprintf("annual report does not appear to be prepared in Swedish, and there are no labels for %q (Swedish labels will be used)", r.Meta.Language))
+			}
+		}),
+		rule("entry-point-valid", 0, Error, func(r *model.AnnualReport, f *findings) {
+			valid := map[string]bool{"risbs": true, "risab": true, "raibs": true, "raiab": true}
+			if r.Meta.EntryPoint != "" && !valid[r.Meta.EntryPoint] {
+				f.add("meta.entryPoint",
+					fmt.Sprintf("invalid entry point %q, must be one of: risbs, risab, raibs, raiab", r.Meta.EntryPoint))
+			}
+		}),
+		rule("taxonomy-version", 0, Error, func(r *model.AnnualReport, f *findings) {
+			if taxonomy.K2Release(r.Meta.TaxonomyVersion) == nil {
+				f.add("meta.taxonomyVersion",
+					fmt.Sprintf("unknown taxonomy version %q, must be one of: %s", r.Meta.TaxonomyVersion, strings.Join(taxonomy.K2Versions(), ", ")))
+			}
+		}),
+		rule("taxonomy-version-accepted", 0, Warning, func(r *model.AnnualReport, f *findings) {
+			if release := taxonomy.K2Release(r.Meta.TaxonomyVersion); release != nil && !release.Accepted() {
+				f.add("meta.taxonomyVersion",
+					fmt.Sprintf("taxonomy version %s is no longer accepted for new filings; use %s", release.Version, taxonomy.CurrentK2))
+			}
+		}),
+		rule("org-nr-format", 0, Error, func(r *model.AnnualReport, f *findings) {
+			if r.Company.OrgNr != "" && !orgNrRe.MatchString(r.Company.OrgNr) {
+				f.add("company.orgNr",
+					fmt.Sprintf("organisation number %q does not match format NNNNNN-NNNN", r.Company.OrgNr))
+			}
+		}),
+		rule("date-format", 0, Error, func(r *model.AnnualReport, f *findings) {
+			for _, d := range []struct{ field, value string }{
+				{"fiscalYear.startDate", r.FiscalYear.StartDate},
+				{"fiscalYear.endDate", r.FiscalYear.EndDate},
+				{"certification.meetingDate", r.Certification.MeetingDate},
+				{"certification.signingDate", r.Certification.SigningDate},
+				{"signatures.date", r.Signatures.Date},
+			} {
+				if d.value != "" && !dateRe.MatchString(d.value) {
+					f.add(d.field, fmt.Sprintf("invalid date format %q, expected YYYY-MM-DD", d.value))
+				}
+			}
+		}),
+		rule("fiscal-year-length", 1046, Error, func(r *model.AnnualReport, f *findings) {
+			fyStart, ok1 := parseDate(r.FiscalYear.StartDate)
+			fyEnd, ok2 := parseDate(r.FiscalYear.EndDate)
+			if !ok1 || !ok2 {
+				return
+			}
+			months := (fyEnd.Year()-fyStart.Year())*12 + int(fyEnd.Month()-fyStart.Month())
+			if fyEnd.Day() > fyStart.Day() {
+				months++
+			}
+			if months > 18 {
+				f.add("fiscalYear", fmt.Sprintf("fiscal year exceeds 18 months (%d months)", months))
+			}
+		}),
+		// Date ordering rules only check dates that are both valid.
+		rule("signing-after-year-end", 1114, Error, func(r *model.AnnualReport, f *findings) {
+			fyEnd, ok1 := parseDate(r.FiscalYear.EndDate)
+			signDate, ok2 := parseDate(r.Signatures.Date)
+			if ok1 && ok2 && !signDate.After(fyEnd) {
+				f.add("signatures.date",
+					"signing date may not be earlier than or the same as the last day of the fiscal year")
+			}
+		}),
+		rule("agm-after-year-end", 1101, Error, func(r *model.AnnualReport, f *findings) {
+			fyEnd, ok1 := parseDate(r.FiscalYear.EndDate)
+			meetDate, ok2 := parseDate(r.Certification.MeetingDate)
+			if ok1 && ok2 && !meetDate.After(fyEnd) {
+				f.add("certification.meetingDate",
+					"AGM date may not be earlier than or the same as the last day of the fiscal year")
+			}
+		}),
+		rule("certification-after-agm", 1165, Error, func(r *model.AnnualReport, f *findings) {
+			meetDate, ok1 := parseDate(r.Certification.MeetingDate)
+			certSignDate, ok2 := parseDate(r.Certification.SigningDate)
+			if ok1 && ok2 && certSignDate.Before(meetDate) {
+				f.add("certification.signingDate",
+					"certification signing date may not be earlier than the AGM date")
+			}
+		}),
+		rule("agm-after-signing", 1183, Warning, func(r *model.AnnualReport, f *findings) {
+			signDate, ok1 := parseDate(r.Signatures.Date)
+			meetDate, ok2 := parseDate(r.Certification.MeetingDate)
+			if ok1 && ok2 && meetDate.Before(signDate) {
+				f.add("certification.meetingDate", "AGM date is earlier than the annual report signing date")
+			}
+		}),
+		// Comparative figures are required unless it is the first
+		// financial year, so their absence is only a warning.
+		rule("comparatives-income-statement", 3007, Warning, func(r *model.AnnualReport, f *findings) {
+			if r.IncomeStatement.NetResult.Current != nil && r.IncomeStatement.NetResult.Previous == nil {
+				f.add("incomeStatement", "comparative figures are missing in the income statement")
+			}
+		}),
+		rule("comparatives-balance-sheet", 3006, Warning, func(r *model.AnnualReport, f *findings) {
+			if r.BalanceSheet.Assets.TotalAssets.Current != nil && r.BalanceSheet.Assets.TotalAssets.Previous == nil {
+				f.add("balanceSheet", "comparative figures are missing in the balance sheet")
+			}
+		}),
+		rule("fixed-asset-carrying-value", 0, Error, func(r *model.AnnualReport, f *findings) {
+			for i, note := range r.Notes.FixedAssetNotes {
+				for _, label := range []string{"current", "previous"} {
+					pick := picker(label)
+					closingAcq := pick(note.ClosingAcquisitionValues)
+					closingDepr := pick(note.ClosingDepreciation)
+					carryVal := pick(note.CarryingValue)
+					if expected := closingAcq - closingDepr; carryVal != expected {
+						f.add(fmt.Sprintf("notes.fixedAssetNotes[%d].carryingValue.%s", i, label),
+							fmt.Sprintf("carrying value (%d) ≠ closing acquisition (%d) - closing depreciation (%d) = %d",
+								carryVal, closingAcq, closingDepr, expected))
+					}
+				}
+			}
+		}),
+		rule("accounting-policies-note-number", 0, Warning, func(r *model.AnnualReport, f *findings) {
+			if n := r.Notes.AccountingPolicies.NoteNumber; n != 0 && n != 1 {
+				f.add("notes.accountingPolicies.noteNumber",
+					fmt.Sprintf("accounting policies note number is %d, conventionally 1", n))
+			}
+		}),
+		rule("logo", 0, Error, func(r *model.AnnualReport, f *findings) {
+			if r.Company.Logo == nil {
+				return
+			}
+			if _, err := ixbrl.CheckImage(r.Company.Logo); err != nil {
+				f.add("company.logo", err.Error())
+			}
+		}),
+		rule("audit-report-combination", 0, Error, checkCombination),
+
+		// 4. Value transfers (ABL 17 kap.)
+
+		rule("dividend-free-equity", 0, Warning, checkDividendFreeEquity),
+		rule("dividend-restricted-equity", 0, Error, checkDividendRestrictedEquity),
+		rule("dividend-board-statement", 0, Warning, checkDividendStatement),
+		rule("dividend-paid", 0, Warning, checkDividendPaid),
+
+		// 5. Capital (ABL 25 kap.)
+
+		&controlBalanceRule{},
+
+		// 6. Continuity between years
+
+		rule("fixed-asset-opening", 0, Error, checkFixedAssetOpening),
+		rule("equity-changes-opening", 0, Error, checkEquityChangesOpening),
+		rule("depreciation-notes", 0, Warning, checkDepreciationNotes),
+		rule("multi-year-overview-income", 0, Error, checkOverviewIncome),
+		rule("solidity", 0, Warning, checkSolidity),
+	}
+}
+
+// orgNrRe matches an organisation number, NNNNNN-NNNN.
+var orgNrRe = regexp.MustCompile(`^\d{6}-\d{4}$`)
+
+// picker returns a function picking the "current" or "previous" value of
+// a YearComparison, nil as 0.
+func picker(label string) func(yc model.YearComparison) int64 {
+	if label == "current" {
+		return func(yc model.YearComparison) int64 { return i64(yc.Current) }
+	}
+	return func(yc model.YearComparison) int64 { return i64(yc.Previous) }
+}
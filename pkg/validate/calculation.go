@@ -0,0 +1,204 @@
+package validate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/redofri/redofri/pkg/ixbrl"
+	"github.com/redofri/redofri/pkg/model"
+	"github.com/redofri/redofri/pkg/taxonomy"
+)
+
+// ValidateWithTaxonomy runs Validate and then checks the facts of the
+// generated document against the taxonomy's calculation linkbase. It
+// fails if tax is nil.
+func ValidateWithTaxonomy(r *model.AnnualReport, tax *taxonomy.Taxonomy) ([]Result, error) {
+	rule, err := TaxonomyCalculationRule(tax)
+	if err != nil {
+		return nil, err
+	}
+	results, _ := defaultRegistry.defaultValidator(rule).Validate(r)
+	return results, nil
+}
+
+// TaxonomyCalculationRule returns the rule "taxonomy-calculations", which
+// generates the report and checks its facts with CheckCalculations. It is
+// not registered; pass it to NewValidator with a loaded taxonomy. It fails
+// if tax is nil.
+func TaxonomyCalculationRule(tax *taxonomy.Taxonomy) (Rule, error) {
+	if tax == nil {
+		return nil, errors.New("taxonomy-calculations: no taxonomy loaded")
+	}
+	return NewRule("taxonomy-calculations", 0, Error, func(r *model.AnnualReport) []Finding {
+		data, err := ixbrl.GenerateBytes(r)
+		if err != nil {
+			return []Finding{{"document", fmt.Sprintf("generating iXBRL: %v", err)}}
+		}
+		doc, err := ixbrl.ReadDocument(bytes.NewReader(data))
+		if err != nil {
+			return []Finding{{"document", fmt.Sprintf("reading generated iXBRL: %v", err)}}
+		}
+		var found []Finding
+		for _, res := range CheckCalculations(doc, tax) {
+			found = append(found, Finding{res.Field, res.Message})
+		}
+		return found
+	}), nil
+}
+
+// CheckCalculations checks the numeric facts of a document against the
+// summation-item relationships of the taxonomy, as in XBRL Calculations
+// 1.1: a total binds to the items reported in the same context, unit and
+// tuple, each value is rounded to the lowest decimals among them, and the
+// weighted sum must equal the total. Totals without any reported item,
+// and concepts with inconsistent duplicate facts, are not checked.
+//
+// Findings are errors with field "concept@context".
+func CheckCalculations(doc *ixbrl.Document, tax *taxonomy.Taxonomy) []Result {
+	facts := map[calcKey][]*ixbrl.Fact{}
+	for _, f := range doc.Facts {
+		if !f.Numeric || f.Nil || f.Context == nil || f.Unit == nil {
+			continue
+		}
+		c := tax.Lookup(f.Namespace, f.LocalName())
+		if c == nil {
+			continue
+		}
+		k := calcKey{c, f.Context.ID, f.Unit.ID, f.Tuple}
+		facts[k] = append(facts[k], f)
+	}
+
+	var results []Result
+	for _, sum := range tax.Summations() {
+		for _, f := range doc.Facts {
+			k, ok := bindingKey(f, sum.Total, facts)
+			if !ok {
+				continue
+			}
+			total, ok := calcValue(facts[k])
+			if !ok {
+				continue
+			}
+			decimals := total.decimals
+			var items []weighted
+			for _, arc := range sum.Items {
+				ik := k
+				ik.concept = arc.To
+				if len(facts[ik]) == 0 {
+					continue
+				}
+				item, ok := calcValue(facts[ik])
+				if !ok {
+					items = nil
+					break
+				}
+				items = append(items, weighted{item, arc.Weight})
+				decimals = min(decimals, item.decimals)
+			}
+			if len(items) == 0 {
+				continue
+			}
+
+			var computed float64
+			for _, it := range items {
+				computed += it.weight * round(it.value, decimals)
+			}
+			computed = round(computed, decimals)
+			reported := round(total.value, decimals)
+			if math.Abs(computed-reported) > 1e-9*math.Max(1, math.Abs(reported)) {
+				role := sum.Role
+				if def := tax.Roles[role]; def != "" {
+					role = def
+				}
+				results = append(results, Result{Severity: Error, Field: f.Concept + "@" + f.Context.ID,
+					Message: fmt.Sprintf("calculation inconsistency (%s): reported %s, items sum to %s (diff %s)",
+						role, formatCalc(reported), formatCalc(computed), formatCalc(reported-computed))})
+			}
+		}
+	}
+	return results
+}
+
+// calcKey groups the facts a calculation binds together.
+type calcKey struct {
+	concept *taxonomy.Concept
+	context string
+	unit    string
+	tuple   *ixbrl.Tuple
+}
+
+// bindingKey returns the key of f if it is the first reported fact of
+// total in its context, unit and tuple, so that each binding is checked
+// once, in document order.
+func bindingKey(f *ixbrl.Fact, total *taxonomy.Concept, facts map[calcKey][]*ixbrl.Fact) (calcKey, bool) {
+	if !f.Numeric || f.Nil || f.Context == nil || f.Unit == nil || f.LocalName() != total.LocalName() || f.Namespace != total.Namespace {
+		return calcKey{}, false
+	}
+	k := calcKey{total, f.Context.ID, f.Unit.ID, f.Tuple}
+	return k, facts[k][0] == f
+}
+
+// calcFact is the value of a set of duplicate facts.
+type calcFact struct {
+	value    float64
+	decimals int
+}
+
+type weighted struct {
+	calcFact
+	weight float64
+}
+
+// calcValue returns the value of duplicate facts: the most precise one,
+// provided they all agree when rounded to the least precise.
+func calcValue(facts []*ixbrl.Fact) (calcFact, bool) {
+	var best calcFact
+	lowest := math.MaxInt
+	var values []calcFact
+	for i, f := range facts {
+		v, err := strconv.ParseFloat(f.Value, 64)
+		if err != nil {
+			return calcFact{}, false
+		}
+		cf := calcFact{v, parseDecimals(f.Decimals)}
+		if i == 0 || cf.decimals > best.decimals {
+			best = cf
+		}
+		lowest = min(lowest, cf.decimals)
+		values = append(values, cf)
+	}
+	for _, cf := range values {
+		if round(cf.value, lowest) != round(best.value, lowest) {
+			return calcFact{}, false
+		}
+	}
+	return best, true
+}
+
+// parseDecimals parses a decimals attribute; INF, and a missing value,
+// give math.MaxInt.
+func parseDecimals(s string) int {
+	d, err := strconv.Atoi(s)
+	if err != nil {
+		return math.MaxInt
+	}
+	return d
+}
+
+// round rounds v to the given decimals, half to even. math.MaxInt leaves
+// v as it is.
+func round(v float64, decimals int) float64 {
+	if decimals == math.MaxInt {
+		return v
+	}
+	p := math.Pow(10, float64(decimals))
+	return math.RoundToEven(v*p) / p
+}
+
+// formatCalc formats a number without exponent or trailing zeros.
+func formatCalc(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,121 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/redofri/redofri/pkg/ixbrl"
+	"github.com/redofri/redofri/pkg/taxonomy"
+)
+
+func loadTestTaxonomy(t *testing.T) *taxonomy.Taxonomy {
+	t.Helper()
+	tax, err := taxonomy.Load("../../testdata/taxonomy/k2")
+	if err != nil {
+		t.Fatalf("loading taxonomy: %v", err)
+	}
+	return tax
+}
+
+func TestValidateWithTaxonomy(t *testing.T) {
+	tax := loadTestTaxonomy(t)
+	r := loadTestReport(t)
+	if results, err := ValidateWithTaxonomy(r, tax); err != nil || len(results) != 0 {
+		t.Fatalf("valid report: %v, %v", results, err)
+	}
+	if _, err := ValidateWithTaxonomy(r, nil); err == nil {
+		t.Error("nil taxonomy accepted")
+	}
+	if _, err := TaxonomyCalculationRule(nil); err == nil {
+		t.Error("TaxonomyCalculationRule: nil taxonomy accepted")
+	}
+
+	*r.IncomeStatement.OperatingResult.Current += 1000
+	results, err := ValidateWithTaxonomy(r, tax)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var found bool
+	for _, res := range results {
+		if res.Field == "se-gen-base:Rorelseresultat@period0" {
+			found = true
+			if res.Severity != Error || !strings.Contains(res.Message, "(Resultaträkning, kostnadsslagsindelad)") || !strings.HasSuffix(res.Message, "(diff 1000)") {
+				t.Errorf("result = %v", res)
+			}
+		}
+	}
+	if !found {
+		t.Error("no calculation error for Rorelseresultat")
+	}
+}
+
+func TestCheckCalculations(t *testing.T) {
+	tax := loadTestTaxonomy(t)
+	ns := "http://www.taxonomier.se/se/fr/gen-base/2021-10-31"
+	period := &ixbrl.Context{ID: "period0"}
+	balans := &ixbrl.Context{ID: "balans0"}
+	sek := &ixbrl.Unit{ID: "SEK"}
+	fact := func(concept string, ctx *ixbrl.Context, value, decimals string) *ixbrl.Fact {
+		return &ixbrl.Fact{Concept: "se-gen-base:" + concept, Namespace: ns, Numeric: true,
+			Context: ctx, Unit: sek, Value: value, Decimals: decimals}
+	}
+
+	tests := []struct {
+		name  string
+		facts []*ixbrl.Fact
+		want  []string // fields with findings
+	}{
+		{"consistent", []*ixbrl.Fact{
+			fact("Tillgangar", balans, "300", "INF"),
+			fact("Anlaggningstillgangar", balans, "100", "INF"),
+			fact("Omsattningstillgangar", balans, "200", "INF"),
+		}, nil},
+		{"inconsistent", []*ixbrl.Fact{
+			fact("Tillgangar", balans, "300", "INF"),
+			fact("Anlaggningstillgangar", balans, "100", "INF"),
+			fact("Omsattningstillgangar", balans, "150", "INF"),
+		}, []string{"se-gen-base:Tillgangar@balans0"}},
+		{"missing item counts as zero", []*ixbrl.Fact{
+			fact("Tillgangar", balans, "300", "INF"),
+			fact("Omsattningstillgangar", balans, "200", "INF"),
+		}, []string{"se-gen-base:Tillgangar@balans0"}},
+		{"no items", []*ixbrl.Fact{
+			fact("Tillgangar", balans, "300", "INF"),
+		}, nil},
+		{"other context", []*ixbrl.Fact{
+			fact("Tillgangar", balans, "300", "INF"),
+			fact("Anlaggningstillgangar", &ixbrl.Context{ID: "balans1"}, "300", "INF"),
+		}, nil},
+		{"rounded to tkr", []*ixbrl.Fact{
+			fact("Tillgangar", balans, "3000000", "-3"),
+			fact("Anlaggningstillgangar", balans, "1000400", "INF"),
+			fact("Omsattningstillgangar", balans, "1999700", "INF"),
+		}, nil},
+		{"consistent duplicates", []*ixbrl.Fact{
+			fact("Nettoomsattning", period, "2650000", "INF"),
+			fact("Nettoomsattning", period, "2650000", "-3"),
+			fact("RorelseintakterLagerforandringarMm", period, "2650000", "INF"),
+		}, nil},
+		{"inconsistent duplicates are skipped", []*ixbrl.Fact{
+			fact("Nettoomsattning", period, "2650000", "INF"),
+			fact("Nettoomsattning", period, "2700000", "INF"),
+			fact("RorelseintakterLagerforandringarMm", period, "1", "INF"),
+		}, nil},
+		{"weights", []*ixbrl.Fact{
+			fact("Rorelseresultat", period, "-50", "INF"),
+			fact("RorelseintakterLagerforandringarMm", period, "100", "INF"),
+			fact("Rorelsekostnader", period, "150", "INF"),
+		}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fields []string
+			for _, r := range CheckCalculations(&ixbrl.Document{Facts: tt.facts}, tax) {
+				fields = append(fields, r.Field)
+			}
+			if strings.Join(fields, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("findings %v, want %v", fields, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/redofri/redofri/pkg/model"
+)
+
+// controlBalanceRule warns when equity is below half of the registered
+// share capital, when the board must prepare a kontrollbalansräkning
+// (ABL 25 kap. 13 §), and checks that the management report mentions it.
+//
+// Options:
+//
+//	{"untaxedReserves": true, "taxRate": 20.6}
+//
+// untaxedReserves counts untaxed reserves as equity less deferred tax, as
+// a kontrollbalansräkning may; taxRate is the tax rate in percent, by
+// default the nominal rate of the fiscal year.
+type controlBalanceRule struct {
+	options controlBalanceOptions
+}
+
+type controlBalanceOptions struct {
+	UntaxedReserves bool     `json:"untaxedReserves,omitempty"`
+	TaxRate         *float64 `json:"taxRate,omitempty"`
+}
+
+func (*controlBalanceRule) ID() string         { return "control-balance-sheet" }
+func (*controlBalanceRule) Code() int          { return 0 }
+func (*controlBalanceRule) Severity() Severity { return Warning }
+
+func (rule *controlBalanceRule) Configure(options json.RawMessage) (Rule, error) {
+	dec := json.NewDecoder(bytes.NewReader(options))
+	dec.DisallowUnknownFields()
+	var opts controlBalanceOptions
+	if err := dec.Decode(&opts); err != nil {
+		return nil, fmt.Errorf("parsing options: %w", err)
+	}
+	if opts.TaxRate != nil && (*opts.TaxRate < 0 || *opts.TaxRate >= 100) {
+		return nil, fmt.Errorf("tax rate %g%% is out of range", *opts.TaxRate)
+	}
+	return &controlBalanceRule{opts}, nil
+}
+
+func (rule *controlBalanceRule) Check(r *model.AnnualReport) []Finding {
+	eq := r.BalanceSheet.EquityAndLiabilities.Equity
+	if eq.TotalEquity.Current == nil || i64(eq.ShareCapital.Current) <= 0 {
+		return nil
+	}
+	equity, shareCapital := *eq.TotalEquity.Current, *eq.ShareCapital.Current
+	what := "equity"
+	if reserves := i64(r.BalanceSheet.EquityAndLiabilities.UntaxedReserves.TotalUntaxedReserves.Current); rule.options.UntaxedReserves && reserves != 0 {
+		rate := nominalTaxRate(r.FiscalYear.StartDate)
+		if rule.options.TaxRate != nil {
+			rate = *rule.options.TaxRate
+		}
+		equity += int64(math.Round(float64(reserves) * (1 - rate/100)))
+		what = fmt.Sprintf("equity including untaxed reserves less %.1f%% tax", rate)
+	}
+	if 2*equity >= shareCapital {
+		return nil
+	}
+
+	var f findings
+	f.add("balanceSheet.equityAndLiabilities.equity.totalEquity.current",
+		fmt.Sprintf("KONTROLLBALANSRÄKNING: %s (%d) is %.1f%% of the registered share capital (%d), below half; the board must prepare a kontrollbalansräkning without delay (ABL 25 kap. 13 §)",
+			what, equity, 100*float64(equity)/float64(shareCapital), shareCapital))
+	if !mentionsControlBalance(r.ManagementReport) {
+		f.add("managementReport.significantEvents",
+			"the management report does not mention the kontrollbalansräkning or the capital shortfall")
+	}
+	return f
+}
+
+// nominalTaxRate returns the corporate income tax rate in percent for a
+// fiscal year starting on date (YYYY-MM-DD).
+func nominalTaxRate(start string) float64 {
+	switch {
+	case start >= "2021-01-01":
+		return 20.6
+	case start >= "2019-01-01":
+		return 21.4
+	default:
+		return 22
+	}
+}
+
+// mentionsControlBalance reports whether the management report's text
+// mentions a kontrollbalansräkning.
+func mentionsControlBalance(mr model.ManagementReport) bool {
+	for _, text := range []string{mr.IntroText, mr.BusinessDescription, mr.SignificantEvents, mr.MultiYearOverview.Comment} {
+		text = strings.ToLower(text)
+		if strings.Contains(text, "kontrollbalansräkning") || strings.Contains(text, "25 kap") {
+			return true
+		}
+	}
+	return false
+}
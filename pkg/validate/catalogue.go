@@ -0,0 +1,436 @@
+package validate
+
+import (
+	"strconv"
+)
+
+// CatalogueEntry describes a rule: what it checks, in Swedish and English,
+// the model fields its findings are reported on and how to fix them. A
+// path ending in "[]" stands for every element, e.g.
+// "notes.fixedAssetNotes[].carryingValue". The Swedish
+// descriptions of rules with a Bolagsverket code follow the wording of the
+// codes in the teknisk guide (appendix E).
+type CatalogueEntry struct {
+	ID          string   `json:"id"`
+	Code        int      `json:"code,omitempty"`
+	Severity    string   `json:"severity"` // "error" or "warning"
+	Swedish     string   `json:"sv"`
+	English     string   `json:"en"`
+	Fields      []string `json:"fields,omitempty"`
+	Remediation string   `json:"remediation,omitempty"`
+}
+
+// Describer is implemented by rules that carry their own catalogue entry.
+type Describer interface {
+	Describe() CatalogueEntry
+}
+
+// WithDescription returns rule with a catalogue entry. The entry's id,
+// code and severity are taken from the rule.
+func WithDescription(rule Rule, entry CatalogueEntry) Rule {
+	return &describedRule{rule, entry}
+}
+
+type describedRule struct {
+	Rule
+	entry CatalogueEntry
+}
+
+func (r *describedRule) Describe() CatalogueEntry { return r.entry }
+
+// Describe returns the catalogue entry of a rule. Rules without a
+// description get an entry with only their id, code and severity.
+func Describe(rule Rule) CatalogueEntry {
+	var e CatalogueEntry
+	if d, ok := rule.(Describer); ok {
+		e = d.Describe()
+	} else {
+		e = builtinCatalogue[rule.ID()]
+	}
+	e.ID, e.Code = rule.ID(), rule.Code()
+	e.Severity = severityName(rule.Severity())
+	return e
+}
+
+// Catalogue returns the entries of the rules Validate runs, in order.
+func Catalogue() []CatalogueEntry {
+	return defaultRegistry.Catalogue()
+}
+
+// Catalogue returns the entries of the registry's rules, in order.
+func (reg *Registry) Catalogue() []CatalogueEntry {
+	return catalogue(reg.rules)
+}
+
+// Catalogue returns the entries of the rules the validator runs, in
+// order, with their configured severities.
+func (v *Validator) Catalogue() []CatalogueEntry {
+	entries := catalogue(v.rules)
+	for i := range entries {
+		if sev, ok := v.severities[entries[i].ID]; ok {
+			entries[i].Severity = severityName(sev)
+		}
+	}
+	return entries
+}
+
+func catalogue(rules []Rule) []CatalogueEntry {
+	entries := make([]CatalogueEntry, 0, len(rules))
+	for _, rule := range rules {
+		entries = append(entries, Describe(rule))
+	}
+	return entries
+}
+
+// FindRules returns the catalogue entries of the rules with the given id
+// or Bolagsverket code, e.g. "signing-after-year-end" or "1114".
+func FindRules(key string) []CatalogueEntry {
+	return findRules(Catalogue(), key)
+}
+
+func findRules(entries []CatalogueEntry, key string) []CatalogueEntry {
+	code, err := strconv.Atoi(key)
+	var found []CatalogueEntry
+	for _, e := range entries {
+		if e.ID == key || (err == nil && code != 0 && e.Code == code) {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+func severityName(s Severity) string {
+	if s == Error {
+		return "error"
+	}
+	return "warning"
+}
+
+// builtinCatalogue describes the built-in rules and the taxonomy
+// calculation rule.
+var builtinCatalogue = map[string]CatalogueEntry{
+	"company-name": {
+		Swedish:     "Företagsnamnet saknas i årsredovisningen.",
+		English:     "The company name is missing from the annual report.",
+		Fields:      []string{"company.name"},
+		Remediation: "Enter the company name exactly as registered with Bolagsverket.",
+	},
+	"org-nr": {
+		Swedish:     "Organisationsnumret saknas i årsredovisningen.",
+		English:     "The organisation number is missing from the annual report.",
+		Fields:      []string{"company.orgNr"},
+		Remediation: "Enter the organisation number as NNNNNN-NNNN.",
+	},
+	"fiscal-year-dates": {
+		Swedish:     "Räkenskapsårets första eller sista dag saknas.",
+		English:     "The first or last day of the fiscal year is missing.",
+		Fields:      []string{"fiscalYear.startDate", "fiscalYear.endDate"},
+		Remediation: "Enter both dates of the fiscal year as YYYY-MM-DD.",
+	},
+	"currency": {
+		Swedish:     "Uppgift om valuta saknas i årsredovisningen.",
+		English:     "The reporting currency is missing from the annual report.",
+		Fields:      []string{"meta.currency"},
+		Remediation: `Set meta.currency to "SEK" or "EUR".`,
+	},
+	"country": {
+		Swedish:     "Landskoden saknas i årsredovisningen.",
+		English:     "The country code is missing from the annual report.",
+		Fields:      []string{"meta.country"},
+		Remediation: `Set meta.country, normally "SE".`,
+	},
+	"language": {
+		Swedish:     "Det saknas uppgift om vilket språk årsredovisningen är upprättad på.",
+		English:     "The language of the annual report is not stated.",
+		Fields:      []string{"meta.language"},
+		Remediation: `Set meta.language, normally "sv".`,
+	},
+	"amount-format": {
+		Swedish:     "Det saknas uppgift om vilken mätenhet (t.ex. kr, tkr) beloppen är angivna i.",
+		English:     "The unit the amounts are stated in (e.g. kr, tkr) is missing.",
+		Fields:      []string{"meta.amountFormat"},
+		Remediation: `Set meta.amountFormat, e.g. "NORMALFORM".`,
+	},
+	"entry-point": {
+		Swedish:     "Taxonomins ingångspunkt saknas.",
+		English:     "The taxonomy entry point is missing.",
+		Fields:      []string{"meta.entryPoint"},
+		Remediation: `Set meta.entryPoint to "risbs", "risab", "raibs" or "raiab".`,
+	},
+	"certification": {
+		Swedish:     "Fastställelseintyget saknas.",
+		English:     "The fastställelseintyg (certificate of adoption) is missing.",
+		Fields:      []string{"certification.confirmationText"},
+		Remediation: "Add the confirmation text that the income statement and balance sheet were adopted at the AGM.",
+	},
+	"agm-date": {
+		Swedish:     "Datum för årsstämman saknas i fastställelseintyget.",
+		English:     "The date of the AGM is missing from the fastställelseintyg.",
+		Fields:      []string{"certification.meetingDate"},
+		Remediation: "Enter the date of the annual general meeting.",
+	},
+	"certification-signing-date": {
+		Swedish:     "Datum för underskrift saknas i fastställelseintyget.",
+		English:     "The signing date is missing from the fastställelseintyg.",
+		Fields:      []string{"certification.signingDate"},
+		Remediation: "Enter the date the fastställelseintyg is signed, on or after the AGM.",
+	},
+	"certification-signatory": {
+		Swedish:     "Namnförtydligandet saknas i fastställelseintyget.",
+		English:     "The name of the person signing the fastställelseintyg is missing.",
+		Fields:      []string{"certification.signatory"},
+		Remediation: "Enter the first and last name of the board member or CEO who signs the certificate.",
+	},
+	"management-report": {
+		Swedish:     "Förvaltningsberättelsen saknas.",
+		English:     "The management report (förvaltningsberättelse) is missing.",
+		Fields:      []string{"managementReport"},
+		Remediation: "Describe the business in the management report.",
+	},
+	"income-statement": {
+		Swedish:     "Resultaträkningen saknas.",
+		English:     "The income statement is missing.",
+		Fields:      []string{"incomeStatement"},
+		Remediation: "Fill in the income statement, at least the net result for the year.",
+	},
+	"total-assets": {
+		Swedish:     "Balansräkningen saknar uppgift om ”Summa tillgångar”.",
+		English:     "The balance sheet has no total assets.",
+		Fields:      []string{"balanceSheet.assets.totalAssets"},
+		Remediation: "Fill in the assets side of the balance sheet, including the total.",
+	},
+	"total-equity-and-liabilities": {
+		Swedish:     "Balansräkningen saknar uppgift om ”Summa eget kapital och skulder”.",
+		English:     "The balance sheet has no total equity and liabilities.",
+		Fields:      []string{"balanceSheet.equityAndLiabilities.totalEquityAndLiabilities"},
+		Remediation: "Fill in the equity and liabilities side of the balance sheet, including the total.",
+	},
+	"signing-date": {
+		Swedish:     "Datum för underskrift saknas i årsredovisningen.",
+		English:     "The signing date is missing from the annual report.",
+		Fields:      []string{"signatures.date"},
+		Remediation: "Enter the date the board signs the annual report.",
+	},
+	"signatories": {
+		Swedish:     "Det saknas för- eller efternamn på den eller de som skrivit under årsredovisningen.",
+		English:     "A first or last name is missing for those who signed the annual report.",
+		Fields:      []string{"signatures.signatories"},
+		Remediation: "List every board member (and the CEO) who signs, with first and last name.",
+	},
+	"accounting-policies": {
+		Swedish:     "Redovisningsprinciper saknas i noterna.",
+		English:     "The accounting policies note is missing.",
+		Fields:      []string{"notes.accountingPolicies.description"},
+		Remediation: "Describe the accounting policies, e.g. that the report follows BFNAR 2016:10 (K2).",
+	},
+	"calculations": {
+		Swedish: "En summa stämmer inte med sina delposter.",
+		English: "A total does not equal the sum of its items.",
+		Fields: []string{"incomeStatement", "balanceSheet", "managementReport.equityChanges",
+			"managementReport.profitDisposition"},
+		Remediation: "Correct the total or the items so that they add up; the finding names the total and the difference.",
+	},
+	"balance": {
+		Swedish:     "”Summa tillgångar” och ”Summa eget kapital och skulder” stämmer inte överens.",
+		English:     "Total assets do not equal total equity and liabilities.",
+		Fields:      []string{"balanceSheet.current", "balanceSheet.previous"},
+		Remediation: "Find the difference between the two sides of the balance sheet, often a missing net result or rounding.",
+	},
+	"shared-facts": {
+		Swedish: "Samma begrepp och kontext har olika värden i olika delar av årsredovisningen.",
+		English: "The same concept and context has different values in different parts of the annual report.",
+		Fields: []string{"balanceSheet.equityAndLiabilities.equity", "balanceSheet.assets.fixedAssets",
+			"managementReport.equityChanges", "managementReport.profitDisposition",
+			"incomeStatement.netResult", "notes.fixedAssetNotes[].carryingValue"},
+		Remediation: "Make both fields named in the finding agree; the generated document would otherwise contain conflicting duplicate facts.",
+	},
+	"currency-allowed": {
+		Swedish:     "Valutan får endast vara SEK eller EUR.",
+		English:     "The currency must be SEK or EUR.",
+		Fields:      []string{"meta.currency"},
+		Remediation: `Set meta.currency to "SEK" or "EUR".`,
+	},
+	"swedish-language": {
+		Swedish:     "Årsredovisningen verkar inte vara upprättad på svenska.",
+		English:     "The annual report does not appear to be prepared in Swedish.",
+		Fields:      []string{"meta.language"},
+		Remediation: `File the Swedish version (meta.language "sv"); use other languages for convenience copies.`,
+	},
+	"entry-point-valid": {
+		Swedish:     "Ingångspunkten är inte en av K2-taxonomins ingångspunkter.",
+		English:     "The entry point is not one of the K2 taxonomy's entry points.",
+		Fields:      []string{"meta.entryPoint"},
+		Remediation: `Use "risbs", "risab", "raibs" or "raiab".`,
+	},
+	"taxonomy-version": {
+		Swedish:     "Taxonomiversionen är okänd.",
+		English:     "The taxonomy version is unknown.",
+		Fields:      []string{"meta.taxonomyVersion"},
+		Remediation: "Leave meta.taxonomyVersion empty for the current K2 release, or name a known release.",
+	},
+	"taxonomy-version-accepted": {
+		Swedish:     "Taxonomiversionen tas inte längre emot för nya årsredovisningar.",
+		English:     "The taxonomy version is no longer accepted for new filings.",
+		Fields:      []string{"meta.taxonomyVersion"},
+		Remediation: "Regenerate the report with the current K2 release before filing it.",
+	},
+	"org-nr-format": {
+		Swedish:     "Organisationsnumret har fel format.",
+		English:     "The organisation number has the wrong format.",
+		Fields:      []string{"company.orgNr"},
+		Remediation: "Write the organisation number as NNNNNN-NNNN, with the hyphen.",
+	},
+	"date-format": {
+		Swedish: "Ett datum har fel format.",
+		English: "A date has the wrong format.",
+		Fields: []string{"fiscalYear.startDate", "fiscalYear.endDate", "certification.meetingDate",
+			"certification.signingDate", "signatures.date"},
+		Remediation: "Write dates as YYYY-MM-DD.",
+	},
+	"fiscal-year-length": {
+		Swedish:     "Räkenskapsåret får inte vara längre än 18 månader.",
+		English:     "The fiscal year may not be longer than 18 months.",
+		Fields:      []string{"fiscalYear"},
+		Remediation: "Check the fiscal year dates; an extended first year may be at most 18 months.",
+	},
+	"signing-after-year-end": {
+		Swedish:     "Datum för underskrift av årsredovisningen får inte vara tidigare än eller samma som räkenskapsårets sista dag.",
+		English:     "The annual report may not be signed on or before the last day of the fiscal year.",
+		Fields:      []string{"signatures.date"},
+		Remediation: "Enter the actual signing date, after the fiscal year has ended.",
+	},
+	"agm-after-year-end": {
+		Swedish:     "Datum för årsstämman får inte vara tidigare än eller samma som räkenskapsårets sista dag.",
+		English:     "The AGM may not be held on or before the last day of the fiscal year.",
+		Fields:      []string{"certification.meetingDate"},
+		Remediation: "Enter the date of the AGM that adopted this annual report.",
+	},
+	"certification-after-agm": {
+		Swedish:     "Datum för underskrift av fastställelseintyget får inte vara tidigare än datum för årsstämman.",
+		English:     "The fastställelseintyg may not be signed before the AGM.",
+		Fields:      []string{"certification.signingDate"},
+		Remediation: "Sign the fastställelseintyg on or after the day of the AGM.",
+	},
+	"agm-after-signing": {
+		Swedish:     "Datum för årsstämman är tidigare än styrelsens underskrift.",
+		English:     "The AGM is dated before the board signed the annual report.",
+		Fields:      []string{"certification.meetingDate"},
+		Remediation: "Check both dates; the AGM adopts a report the board has already signed.",
+	},
+	"comparatives-income-statement": {
+		Swedish:     "Jämförelsesiffror saknas i resultaträkningen. De behövs om det inte är företagets första räkenskapsår.",
+		English:     "Comparative figures are missing from the income statement. They are required unless it is the company's first fiscal year.",
+		Fields:      []string{"incomeStatement"},
+		Remediation: "Fill in the previous year's figures, e.g. by parsing last year's filed report.",
+	},
+	"comparatives-balance-sheet": {
+		Swedish:     "Jämförelsesiffror saknas i balansräkningen. De behövs om det inte är företagets första räkenskapsår.",
+		English:     "Comparative figures are missing from the balance sheet. They are required unless it is the company's first fiscal year.",
+		Fields:      []string{"balanceSheet"},
+		Remediation: "Fill in the previous year's figures, e.g. by parsing last year's filed report.",
+	},
+	"fixed-asset-carrying-value": {
+		Swedish:     "Redovisat värde i en anläggningsnot stämmer inte med anskaffningsvärden minus avskrivningar.",
+		English:     "A fixed asset note's carrying value does not equal closing acquisition values less closing depreciation.",
+		Fields:      []string{"notes.fixedAssetNotes[].carryingValue"},
+		Remediation: "Correct the note so that the carrying value equals acquisition values less accumulated depreciation.",
+	},
+	"accounting-policies-note-number": {
+		Swedish:     "Noten om redovisningsprinciper har inte nummer 1.",
+		English:     "The accounting policies note is not note 1.",
+		Fields:      []string{"notes.accountingPolicies.noteNumber"},
+		Remediation: "Number the accounting policies note 1, as is customary.",
+	},
+	"logo": {
+		Swedish:     "Logotypen har ett format som inte tillåts eller är för stor.",
+		English:     "The logo has a format that is not allowed, or is too large.",
+		Fields:      []string{"company.logo"},
+		Remediation: "Use a JPEG, PNG, SVG or GIF image smaller than 1 MB.",
+	},
+	"audit-report-combination": {
+		Swedish:     "Revisionsberättelsens version får inte kombineras med årsredovisningens K2-version.",
+		English:     "The revisionsberättelse version may not be combined with the report's K2 version.",
+		Fields:      []string{"auditReport.taxonomyVersion"},
+		Remediation: "Use a revisionsberättelse version allowed by Bolagsverket's combinations of taxonomy reports.",
+	},
+	"dividend-free-equity": {
+		Swedish:     "Den föreslagna utdelningen överstiger det fria egna kapitalet.",
+		English:     "The proposed dividend exceeds fritt eget kapital.",
+		Fields:      []string{"managementReport.profitDisposition.dividend"},
+		Remediation: "Lower the dividend to at most the funds at the AGM's disposal (ABL 17 kap. 3 §).",
+	},
+	"dividend-restricted-equity": {
+		Swedish:     "Efter den föreslagna utdelningen finns inte full täckning för det bundna egna kapitalet.",
+		English:     "After the proposed dividend, restricted equity is no longer fully covered.",
+		Fields:      []string{"managementReport.profitDisposition.dividend"},
+		Remediation: "Lower the dividend so that equity after it at least equals restricted equity (ABL 17 kap. 3 §).",
+	},
+	"dividend-board-statement": {
+		Swedish:     "Styrelsens yttrande över den föreslagna vinstutdelningen saknas.",
+		English:     "The board's statement on the proposed dividend is missing.",
+		Fields:      []string{"managementReport.boardDividendStatement"},
+		Remediation: "Add the board's statement (styrelsens yttrande) on why the dividend is justifiable (ABL 18 kap. 4 §).",
+	},
+	"dividend-paid": {
+		Swedish:     "Förra årets utdelning stämmer inte med utdelningen i förändringar i eget kapital.",
+		English:     "Last year's dividend does not match the dividend in the changes in equity.",
+		Fields:      []string{"managementReport.equityChanges.dividendNetIncome"},
+		Remediation: "Enter the dividend paid during the year, i.e. the dividend the previous AGM resolved, in the changes in equity. The dividend is derived assuming that the result and the dividend are the only movements in equity; the rule is skipped when restricted equity changed, and may be suppressed with a justification for other movements, e.g. a shareholder contribution.",
+	},
+	"control-balance-sheet": {
+		Swedish:     "Det egna kapitalet understiger hälften av det registrerade aktiekapitalet; styrelsen ska upprätta en kontrollbalansräkning.",
+		English:     "Equity is below half of the registered share capital; the board must prepare a kontrollbalansräkning.",
+		Fields:      []string{"balanceSheet.equityAndLiabilities.equity.totalEquity.current", "managementReport.significantEvents"},
+		Remediation: "Prepare a kontrollbalansräkning and have it audited (ABL 25 kap. 13-14 §§), and describe it in the management report. Untaxed reserves may be counted with the rule option untaxedReserves.",
+	},
+	"fixed-asset-opening": {
+		Swedish:     "Ingående värden i en anläggningsnot stämmer inte med föregående års utgående värden.",
+		English:     "Opening values in a fixed asset note do not equal the previous year's closing values.",
+		Fields:      []string{"notes.fixedAssetNotes[].openingAcquisitionValues.current", "notes.fixedAssetNotes[].openingDepreciation.current"},
+		Remediation: "Open the year with the previous year's closing acquisition values and depreciation.",
+	},
+	"equity-changes-opening": {
+		Swedish: "Ingående belopp i förändringar i eget kapital stämmer inte med föregående års balansräkning.",
+		English: "Opening amounts in the changes in equity do not equal the previous year's balance sheet.",
+		Fields: []string{"managementReport.equityChanges.openingShareCapital", "managementReport.equityChanges.openingReserveFund",
+			"managementReport.equityChanges.openingRetainedEarnings", "managementReport.equityChanges.openingNetIncome",
+			"managementReport.equityChanges.openingTotal"},
+		Remediation: "Open the changes in equity with the previous year's equity in the balance sheet.",
+	},
+	"depreciation-notes": {
+		Swedish:     "Årets avskrivningar i noterna summerar inte till avskrivningarna i resultaträkningen.",
+		English:     "The year's depreciation in the notes does not add up to depreciation in the income statement.",
+		Fields:      []string{"incomeStatement.expenses.depreciationAmortization"},
+		Remediation: "Check the year's depreciation in each fixed asset note; the difference may also be write-downs or intangible assets without a note.",
+	},
+	"multi-year-overview-income": {
+		Swedish:     "Nettoomsättning eller resultat efter finansiella poster i flerårsöversikten stämmer inte med resultaträkningen.",
+		English:     "Net sales or result after financial items in the multi-year overview do not match the income statement.",
+		Fields:      []string{"managementReport.multiYearOverview.years[].netSales", "managementReport.multiYearOverview.years[].resultAfterFinancialItems"},
+		Remediation: "Enter the income statement's amounts for the two latest years; the overview shows them in tkr.",
+	},
+	"solidity": {
+		Swedish:     "Soliditeten i flerårsöversikten stämmer inte med justerat eget kapital i procent av balansomslutningen.",
+		English:     "Solidity in the multi-year overview does not equal adjusted equity as a percentage of total assets.",
+		Fields:      []string{"managementReport.multiYearOverview.years[].solidity"},
+		Remediation: "Compute solidity as (equity + untaxed reserves less deferred tax) / total assets.",
+	},
+	"previous-report": {
+		Swedish:     "Jämförelsetalen, ingående balanserna i noterna och flerårsöversikten stämmer inte med förra årets inlämnade årsredovisning.",
+		English:     "The comparatives, the notes' opening balances or the multi-year overview differ from last year's filed annual report.",
+		Fields:      []string{"incomeStatement", "balanceSheet", "notes", "managementReport.equityChanges", "managementReport.multiYearOverview.years[]"},
+		Remediation: "Use the figures of the filed report, or record why they were restated in notes.accountingPolicies.restatements.",
+	},
+	"previous-report-period": {
+		Swedish:     "Förra årets inlämnade årsredovisning avser inte räkenskapsåret före detta.",
+		English:     "Last year's filed annual report is not for the fiscal year before this one.",
+		Fields:      []string{"fiscalYear.startDate"},
+		Remediation: "Compare with the annual report filed for the previous fiscal year, or correct fiscalYear.",
+	},
+	"taxonomy-calculations": {
+		Swedish:     "En summa i det genererade dokumentet stämmer inte med taxonomins beräkningsregler.",
+		English:     "A total in the generated document does not agree with the taxonomy's calculation linkbase.",
+		Fields:      []string{"incomeStatement", "balanceSheet"},
+		Remediation: "Correct the total or its items; the finding names the concept, period and difference.",
+	},
+}
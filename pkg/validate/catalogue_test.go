@@ -0,0 +1,64 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/redofri/redofri/pkg/model"
+)
+
+func TestCatalogue(t *testing.T) {
+	entries := Catalogue()
+	if len(entries) != len(Rules()) {
+		t.Fatalf("%d entries for %d rules", len(entries), len(Rules()))
+	}
+	calculations, err := TaxonomyCalculationRule(loadTestTaxonomy(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules := append(Rules(), calculations)
+	for _, rule := range rules {
+		e := Describe(rule)
+		if e.ID != rule.ID() || e.Code != rule.Code() || e.Swedish == "" || e.English == "" ||
+			len(e.Fields) == 0 || e.Remediation == "" {
+			t.Errorf("incomplete entry for %s: %+v", rule.ID(), e)
+		}
+	}
+	for id := range builtinCatalogue {
+		if defaultRegistry.Rule(id) == nil && id != "taxonomy-calculations" && id != "previous-report" && id != "previous-report-period" {
+			t.Errorf("catalogue entry for unknown rule %q", id)
+		}
+	}
+}
+
+func TestFindRules(t *testing.T) {
+	if got := FindRules("1114"); len(got) != 1 || got[0].ID != "signing-after-year-end" || got[0].Severity != "error" {
+		t.Errorf("FindRules(1114) = %+v", got)
+	}
+	if got := FindRules("agm-after-signing"); len(got) != 1 || got[0].Code != 1183 || got[0].Severity != "warning" {
+		t.Errorf("FindRules(agm-after-signing) = %+v", got)
+	}
+	if got := FindRules("0"); len(got) != 0 {
+		t.Errorf("FindRules(0) = %d entries", len(got))
+	}
+
+	cfg, err := ParseConfig([]byte(`{"rules": {"agm-after-signing": {"severity": "error"}, "logo": {"enabled": false}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	custom := WithDescription(NewRule("own", 0, Warning, func(*model.AnnualReport) []Finding { return nil }),
+		CatalogueEntry{English: "Own check."})
+	v, err := NewValidator(cfg, custom)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := v.Catalogue()
+	if got := findRules(entries, "agm-after-signing"); len(got) != 1 || got[0].Severity != "error" {
+		t.Errorf("configured severity = %+v", got)
+	}
+	if got := findRules(entries, "logo"); len(got) != 0 {
+		t.Error("disabled rule in catalogue")
+	}
+	if got := findRules(entries, "own"); len(got) != 1 || got[0].English != "Own check." || got[0].Severity != "warning" {
+		t.Errorf("described rule = %+v", got)
+	}
+}
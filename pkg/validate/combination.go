@@ -0,0 +1,66 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/redofri/redofri/pkg/model"
+	"github.com/redofri/redofri/pkg/taxonomy"
+)
+
+// checkCombination checks that the revisionsberättelse embedded in the
+// report may be combined with its K2 version, following Bolagsverket's
+// "Kombinationer av taxonomirapporter".
+func checkCombination(r *model.AnnualReport, f *findings) {
+	ar := r.AuditReport
+	if ar == nil || !ar.Embedded {
+		return
+	}
+	if ar.TaxonomyVersion == "" {
+		f.add("auditReport.taxonomyVersion", "taxonomy version of the embedded revisionsberättelse is missing")
+		return
+	}
+	release := taxonomy.K2Release(r.Meta.TaxonomyVersion)
+	if release == nil {
+		return // reported as an unknown taxonomy version
+	}
+	if c := taxonomy.K2Combination(release.Version); c != nil && !c.AllowsAuditReport(ar.TaxonomyVersion) {
+		f.add("auditReport.taxonomyVersion",
+			fmt.Sprintf("revisionsberättelse %s cannot be combined with K2 %s (allowed: %s)",
+				ar.TaxonomyVersion, release.Version, strings.Join(c.AuditReports, ", ")))
+	}
+}
+
+// CheckSubmission checks that a report may be filed as the given document
+// type (handling.typ): the type must be known, the report's K2 version
+// must be accepted for new filings, and a revisionsberättelse the company
+// is required to have must be embedded when the type calls for it. It
+// does not repeat the checks of Validate.
+func CheckSubmission(r *model.AnnualReport, documentType string) []Result {
+	var f findings
+	dt := taxonomy.LookupDocumentType(documentType)
+	if dt == nil {
+		var names []string
+		for _, t := range taxonomy.DocumentTypes() {
+			names = append(names, t.Name)
+		}
+		f.add("documentType",
+			fmt.Sprintf("unknown document type %q, must be one of: %s", documentType, strings.Join(names, ", ")))
+	}
+
+	if release := taxonomy.K2Release(r.Meta.TaxonomyVersion); release != nil && !release.Accepted() {
+		f.add("meta.taxonomyVersion",
+			fmt.Sprintf("K2 %s is not in Bolagsverket's combinations of taxonomy reports and cannot be filed; regenerate the report with %s",
+				release.Version, taxonomy.CurrentK2))
+	}
+
+	if dt != nil && dt.EmbeddedAuditReport && r.AuditReport != nil && !r.AuditReport.Embedded {
+		f.add("auditReport.embedded",
+			fmt.Sprintf("%s must contain the revisionsberättelse the company is required to have", dt.Name))
+	}
+	var results []Result
+	for _, finding := range f {
+		results = append(results, Result{Severity: Error, Field: finding.Field, Message: finding.Message})
+	}
+	return results
+}
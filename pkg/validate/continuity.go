@@ -0,0 +1,192 @@
+package validate
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/redofri/redofri/pkg/model"
+)
+
+// The continuity rules check that the years of a report connect: what a
+// year opens with is what the previous year closed with, and the notes
+// and the multi-year overview agree with the statements.
+
+// checkFixedAssetOpening checks that each fixed asset note opens the year
+// with the previous year's closing values.
+func checkFixedAssetOpening(r *model.AnnualReport, f *findings) {
+	for i, note := range r.Notes.FixedAssetNotes {
+		field := fmt.Sprintf("notes.fixedAssetNotes[%d].", i)
+		for _, p := range []struct {
+			opening, closing string
+			values           [2]*int64
+		}{
+			{"openingAcquisitionValues", "closingAcquisitionValues",
+				[2]*int64{note.OpeningAcquisitionValues.Current, note.ClosingAcquisitionValues.Previous}},
+			{"openingDepreciation", "closingDepreciation",
+				[2]*int64{note.OpeningDepreciation.Current, note.ClosingDepreciation.Previous}},
+		} {
+			opening, closing := p.values[0], p.values[1]
+			if opening != nil && closing != nil && *opening != *closing {
+				f.add(field+p.opening+".current",
+					fmt.Sprintf("opening value (%d) ≠ previous year's closing value in %s%s.previous (%d)",
+						*opening, field, p.closing, *closing))
+			}
+		}
+	}
+}
+
+// checkEquityChangesOpening checks that the changes in equity open with
+// the previous year's equity in the balance sheet.
+func checkEquityChangesOpening(r *model.AnnualReport, f *findings) {
+	ec := r.ManagementReport.EquityChanges
+	eq := r.BalanceSheet.EquityAndLiabilities.Equity
+	const ecField, eqField = "managementReport.equityChanges.", "balanceSheet.equityAndLiabilities.equity."
+	for _, p := range []struct {
+		opening, balance string
+		values           [2]*int64
+	}{
+		{"openingShareCapital", "shareCapital", [2]*int64{ec.OpeningShareCapital, eq.ShareCapital.Previous}},
+		{"openingReserveFund", "reserveFund", [2]*int64{ec.OpeningReserveFund, eq.ReserveFund.Previous}},
+		{"openingRetainedEarnings", "retainedEarnings", [2]*int64{ec.OpeningRetainedEarnings, eq.RetainedEarnings.Previous}},
+		{"openingNetIncome", "netIncome", [2]*int64{ec.OpeningNetIncome, eq.NetIncome.Previous}},
+		{"openingTotal", "totalEquity", [2]*int64{ec.OpeningTotal, eq.TotalEquity.Previous}},
+	} {
+		opening, balance := p.values[0], p.values[1]
+		if opening != nil && balance != nil && *opening != *balance {
+			f.add(ecField+p.opening,
+				fmt.Sprintf("opening amount (%d) ≠ previous year's balance sheet in %s%s.previous (%d)",
+					*opening, eqField, p.balance, *balance))
+		}
+	}
+}
+
+// checkDepreciationNotes checks that the year's depreciation in the fixed
+// asset notes adds up to depreciation in the income statement.
+func checkDepreciationNotes(r *model.AnnualReport, f *findings) {
+	for _, label := range []string{"current", "previous"} {
+		reported := yearValue(r.IncomeStatement.Expenses.DepreciationAmortization, label)
+		var sum int64
+		var notes int
+		for _, note := range r.Notes.FixedAssetNotes {
+			if v := yearValue(note.YearDepreciation, label); v != nil {
+				sum += *v
+				notes++
+			}
+		}
+		if reported == nil || notes == 0 || *reported == sum {
+			continue
+		}
+		f.add("incomeStatement.expenses.depreciationAmortization."+label,
+			fmt.Sprintf("depreciation (%d) ≠ sum of notes.fixedAssetNotes[].yearDepreciation.%s (%d, %d notes), diff %d",
+				*reported, label, sum, notes, *reported-sum))
+	}
+}
+
+// checkOverviewIncome checks that the first two years of the multi-year
+// overview show the income statement's net sales and result after
+// financial items. The overview is written in tkr, so it may show the
+// statement's amount in whole tkr either truncated, as the generator
+// writes it, or rounded.
+func checkOverviewIncome(r *model.AnnualReport, f *findings) {
+	is := r.IncomeStatement
+	for i, y := range r.ManagementReport.MultiYearOverview.Years {
+		if i > 1 {
+			break
+		}
+		label := overviewLabel(i)
+		for _, p := range []struct {
+			name, overview, statement string
+			value                     *int64
+			yc                        model.YearComparison
+		}{
+			{"net sales", "netSales", "incomeStatement.revenue.netSales", y.NetSales, is.Revenue.NetSales},
+			{"result after financial items", "resultAfterFinancialItems", "incomeStatement.resultAfterFinancialItems",
+				y.ResultAfterFinancialItems, is.ResultAfterFinancialItems},
+		} {
+			amount := yearValue(p.yc, label)
+			if p.value == nil || amount == nil {
+				continue
+			}
+			if tkr := *p.value / 1000; tkr != *amount/1000 && tkr != roundTkr(*amount)/1000 {
+				f.add(fmt.Sprintf("managementReport.multiYearOverview.years[%d].%s", i, p.overview),
+					fmt.Sprintf("%s %d tkr ≠ %s.%s (%d, %d tkr)",
+						p.name, tkr, p.statement, label, *amount, roundTkr(*amount)/1000))
+			}
+		}
+	}
+}
+
+// checkSolidity checks the solidity of the first two years of the
+// multi-year overview: adjusted equity, i.e. equity plus untaxed reserves
+// less deferred tax at the nominal rate, as a percentage of total assets.
+// The stated figure may differ by rounding in its last decimal, and by
+// 0.01 percentage points for amounts rounded to tkr.
+func checkSolidity(r *model.AnnualReport, f *findings) {
+	bs := r.BalanceSheet
+	start, ok := parseDate(r.FiscalYear.StartDate)
+	if !ok {
+		return
+	}
+	for i, y := range r.ManagementReport.MultiYearOverview.Years {
+		if i > 1 {
+			break
+		}
+		if y.Solidity == nil {
+			continue
+		}
+		field := fmt.Sprintf("managementReport.multiYearOverview.years[%d].solidity", i)
+		stated, err := strconv.ParseFloat(strings.Replace(*y.Solidity, ",", ".", 1), 64)
+		if err != nil {
+			f.add(field, fmt.Sprintf("solidity %q is not a number", *y.Solidity))
+			continue
+		}
+		pick := picker(overviewLabel(i))
+		assets := pick(bs.Assets.TotalAssets)
+		if assets == 0 {
+			continue
+		}
+		rate := nominalTaxRate(start.AddDate(-i, 0, 0).Format("2006-01-02"))
+		reserves := pick(bs.EquityAndLiabilities.UntaxedReserves.TotalUntaxedReserves)
+		equity := pick(bs.EquityAndLiabilities.Equity.TotalEquity) + int64(math.Round(float64(reserves)*(1-rate/100)))
+		computed := 100 * float64(equity) / float64(assets)
+
+		tolerance := 0.05
+		if dot := strings.IndexAny(*y.Solidity, ".,"); dot < 0 {
+			tolerance = 0.5
+		} else if decimals := len(*y.Solidity) - dot - 1; decimals > 1 {
+			tolerance = 0.5 * math.Pow10(-decimals)
+		}
+		if math.Abs(stated-computed) > tolerance+0.01 {
+			f.add(field,
+				fmt.Sprintf("solidity %s%% ≠ adjusted equity (%d, untaxed reserves less %.1f%% tax) / total assets (%d) = %.1f%%",
+					*y.Solidity, equity, rate, assets, computed))
+		}
+	}
+}
+
+// overviewLabel returns the YearComparison value ("current" or
+// "previous") for the first two years of the multi-year overview.
+func overviewLabel(i int) string {
+	if i == 0 {
+		return "current"
+	}
+	return "previous"
+}
+
+// yearValue returns the "current" or "previous" value of a YearComparison.
+func yearValue(yc model.YearComparison, label string) *int64 {
+	if label == "current" {
+		return yc.Current
+	}
+	return yc.Previous
+}
+
+// roundTkr rounds an amount to whole thousands, halves away from zero.
+func roundTkr(v int64) int64 {
+	if v < 0 {
+		return -roundTkr(-v)
+	}
+	return (v + 500) / 1000 * 1000
+}
@@ -0,0 +1,91 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/redofri/redofri/pkg/model"
+)
+
+// Value transfers under ABL 17 kap.: a dividend may only be paid out of
+// fritt eget kapital, and must leave full coverage for bundet eget
+// kapital (17 kap. 3 §). The board's proposal must come with its
+// statement on the dividend (styrelsens yttrande, 18 kap. 4 §).
+
+// checkDividendFreeEquity warns when the proposed dividend exceeds the
+// fritt eget kapital at the AGM's disposal.
+func checkDividendFreeEquity(r *model.AnnualReport, f *findings) {
+	pd := r.ManagementReport.ProfitDisposition
+	dividend := i64(pd.Dividend)
+	if dividend <= 0 {
+		return
+	}
+	free, source := pd.TotalAvailable, "funds at the AGM's disposal"
+	if free == nil {
+		free, source = r.BalanceSheet.EquityAndLiabilities.Equity.TotalUnrestrictedEquity.Current, "fritt eget kapital"
+	}
+	if free != nil && dividend > *free {
+		f.add("managementReport.profitDisposition.dividend",
+			fmt.Sprintf("proposed dividend (%d) exceeds %s (%d)", dividend, source, *free))
+	}
+}
+
+// checkDividendRestrictedEquity reports a proposed dividend after which
+// the equity in the balance sheet no longer covers restricted equity.
+func checkDividendRestrictedEquity(r *model.AnnualReport, f *findings) {
+	dividend := i64(r.ManagementReport.ProfitDisposition.Dividend)
+	eq := r.BalanceSheet.EquityAndLiabilities.Equity
+	if dividend <= 0 || eq.TotalEquity.Current == nil || eq.TotalRestrictedEquity.Current == nil {
+		return
+	}
+	after, restricted := *eq.TotalEquity.Current-dividend, *eq.TotalRestrictedEquity.Current
+	if after < restricted {
+		f.add("managementReport.profitDisposition.dividend",
+			fmt.Sprintf("equity after the proposed dividend (%d - %d = %d) does not cover restricted equity (%d), short by %d (ABL 17 kap. 3 §)",
+				*eq.TotalEquity.Current, dividend, after, restricted, restricted-after))
+	}
+}
+
+// checkDividendStatement flags a proposed dividend without the board's
+// statement.
+func checkDividendStatement(r *model.AnnualReport, f *findings) {
+	mr := r.ManagementReport
+	if i64(mr.ProfitDisposition.Dividend) > 0 && strings.TrimSpace(mr.BoardDividendStatement) == "" {
+		f.add("managementReport.boardDividendStatement",
+			fmt.Sprintf("a dividend of %d is proposed without the board's statement (styrelsens yttrande, ABL 18 kap. 4 §)",
+				i64(mr.ProfitDisposition.Dividend)))
+	}
+}
+
+// checkDividendPaid checks that last year's dividend appears in the
+// changes in equity. The changes in equity show only the year's result and
+// the dividend, so the check assumes that they are the only movements:
+// last year's dividend is then the previous year's equity plus the year's
+// result less this year's equity. A change in restricted equity, e.g. a
+// share issue or a transfer to the reserve fund, is another movement, and
+// the check is skipped.
+func checkDividendPaid(r *model.AnnualReport, f *findings) {
+	equity := r.BalanceSheet.EquityAndLiabilities.Equity
+	eq := equity.TotalEquity
+	result := r.IncomeStatement.NetResult.Current
+	if eq.Current == nil || eq.Previous == nil || result == nil {
+		return
+	}
+	ec := r.ManagementReport.EquityChanges
+	if i64(equity.TotalRestrictedEquity.Current) != i64(equity.TotalRestrictedEquity.Previous) ||
+		(ec.OpeningShareCapital != nil && ec.ClosingShareCapital != nil && *ec.OpeningShareCapital != *ec.ClosingShareCapital) ||
+		(ec.OpeningReserveFund != nil && ec.ClosingReserveFund != nil && *ec.OpeningReserveFund != *ec.ClosingReserveFund) {
+		return
+	}
+	const assumption = "assuming the result and the dividend are the only movements in equity"
+	paid := *eq.Previous + *result - *eq.Current
+	switch {
+	case ec.DividendNetIncome == nil && paid > 0:
+		f.add("managementReport.equityChanges.dividendNetIncome",
+			fmt.Sprintf("equity decreased by %d beyond the year's result, but no dividend is shown in the changes in equity (%s)", paid, assumption))
+	case ec.DividendNetIncome != nil && *ec.DividendNetIncome != paid:
+		f.add("managementReport.equityChanges.dividendNetIncome",
+			fmt.Sprintf("dividend in the changes in equity (%d) ≠ previous equity (%d) + result (%d) - equity (%d) = %d (%s)",
+				*ec.DividendNetIncome, *eq.Previous, *result, *eq.Current, paid, assumption))
+	}
+}
@@ -0,0 +1,115 @@
+package validate
+
+import (
+	"fmt"
+
+	"github.com/redofri/redofri/pkg/model"
+)
+
+// factField is a model field the generator writes as a fact.
+type factField struct {
+	concept string // se-gen-base concept, e.g. "BalanseratResultat"
+	context string // e.g. "balans0"
+	field   string
+	value   *int64
+}
+
+// sameAmount maps facts of different concepts that must carry the same
+// amount to the fact they are compared with: the year's result in the
+// income statement is the year's result in equity.
+var sameAmount = map[string]string{
+	"AretsResultatEgetKapital@balans0":                        "AretsResultat@period0",
+	"ForandringEgetKapitalAretsResultatAretsResultat@period0": "AretsResultat@period0",
+}
+
+// balanceSheetAssetFields maps fixed asset note concept prefixes to the
+// balance sheet line with the same concept.
+var balanceSheetAssetFields = map[string]string{
+	"ByggnaderMark":                        "balanceSheet.assets.fixedAssets.tangible.buildingsAndLand",
+	"MaskinerAndraTekniskaAnlaggningar":    "balanceSheet.assets.fixedAssets.tangible.machineryAndEquipment",
+	"InventarierVerktygInstallationer":     "balanceSheet.assets.fixedAssets.tangible.fixturesAndFittings",
+	"AndraLangfristigaVardepappersinnehav": "balanceSheet.assets.fixedAssets.financial.otherLongTermSecurities",
+}
+
+// sharedFactFields returns the fields of the facts the generator writes
+// from more than one field, in the order of the document. Opening
+// balances and the multi-year overview, which repeat the previous year or
+// the income statement, are left to the continuity rules.
+func sharedFactFields(r *model.AnnualReport) []factField {
+	var fields []factField
+	yc := func(concept, field string, v model.YearComparison, current, previous string) {
+		fields = append(fields,
+			factField{concept: concept, context: current, field: field + ".current", value: v.Current},
+			factField{concept: concept, context: previous, field: field + ".previous", value: v.Previous})
+	}
+	add := func(concept, context, field string, v *int64) {
+		fields = append(fields, factField{concept: concept, context: context, field: field, value: v})
+	}
+
+	mr := &r.ManagementReport
+	ec := &mr.EquityChanges
+	const ecField = "managementReport.equityChanges."
+	add("ForandringEgetKapitalAretsResultatAretsResultat", "period0", ecField+"yearResultNetIncome", ec.YearResultNetIncome)
+	add("Aktiekapital", "balans0", ecField+"closingShareCapital", ec.ClosingShareCapital)
+	add("Reservfond", "balans0", ecField+"closingReserveFund", ec.ClosingReserveFund)
+	add("BalanseratResultat", "balans0", ecField+"closingRetainedEarnings", ec.ClosingRetainedEarnings)
+	add("AretsResultatEgetKapital", "balans0", ecField+"closingNetIncome", ec.ClosingNetIncome)
+
+	pd := &mr.ProfitDisposition
+	add("BalanseratResultat", "balans0", "managementReport.profitDisposition.retainedEarnings", pd.RetainedEarnings)
+	add("AretsResultatEgetKapital", "balans0", "managementReport.profitDisposition.netIncome", pd.NetIncome)
+
+	is := &r.IncomeStatement
+	yc("AretsResultat", "incomeStatement.netResult", is.NetResult, "period0", "period1")
+
+	bs := &r.BalanceSheet
+	tangible, financial := &bs.Assets.FixedAssets.Tangible, &bs.Assets.FixedAssets.Financial
+	yc("ByggnaderMark", balanceSheetAssetFields["ByggnaderMark"], tangible.BuildingsAndLand, "balans0", "balans1")
+	yc("MaskinerAndraTekniskaAnlaggningar", balanceSheetAssetFields["MaskinerAndraTekniskaAnlaggningar"],
+		tangible.MachineryAndEquipment, "balans0", "balans1")
+	yc("InventarierVerktygInstallationer", balanceSheetAssetFields["InventarierVerktygInstallationer"],
+		tangible.FixturesAndFittings, "balans0", "balans1")
+	yc("AndraLangfristigaVardepappersinnehav", balanceSheetAssetFields["AndraLangfristigaVardepappersinnehav"],
+		financial.OtherLongTermSecurities, "balans0", "balans1")
+	eq := &bs.EquityAndLiabilities.Equity
+	const eqField = "balanceSheet.equityAndLiabilities.equity."
+	yc("Aktiekapital", eqField+"shareCapital", eq.ShareCapital, "balans0", "balans1")
+	yc("Reservfond", eqField+"reserveFund", eq.ReserveFund, "balans0", "balans1")
+	yc("BalanseratResultat", eqField+"retainedEarnings", eq.RetainedEarnings, "balans0", "balans1")
+	yc("AretsResultatEgetKapital", eqField+"netIncome", eq.NetIncome, "balans0", "balans1")
+
+	for i, note := range r.Notes.FixedAssetNotes {
+		yc(note.ConceptPrefix, fmt.Sprintf("notes.fixedAssetNotes[%d].carryingValue", i), note.CarryingValue, "balans0", "balans1")
+	}
+	return fields
+}
+
+// checkSharedFacts reports fields that the generator writes as the same
+// fact, concept and context, with different values. Bolagsverket rejects
+// documents with such inconsistent duplicate facts.
+func checkSharedFacts(r *model.AnnualReport, f *findings) {
+	first := map[string]factField{}
+	for _, ff := range sharedFactFields(r) {
+		if ff.value == nil {
+			continue
+		}
+		key := ff.concept + "@" + ff.context
+		if k, ok := sameAmount[key]; ok {
+			key = k
+		}
+		ref, ok := first[key]
+		if !ok {
+			first[key] = ff
+			continue
+		}
+		if *ref.value == *ff.value {
+			continue
+		}
+		fact := "se-gen-base:" + ff.concept + "@" + ff.context
+		if ff.concept != ref.concept {
+			fact += " (the year's result, as se-gen-base:" + ref.concept + "@" + ref.context + ")"
+		}
+		f.add(ff.field, fmt.Sprintf("%s is %d in %s but %d in %s",
+			fact, *ref.value, ref.field, *ff.value, ff.field))
+	}
+}
@@ -0,0 +1,535 @@
+package validate
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/redofri/redofri/pkg/ixbrl"
+	"github.com/redofri/redofri/pkg/model"
+)
+
+// Namespaces checked by Lint.
+const (
+	xhtmlNS   = "http://www.w3.org/1999/xhtml"
+	svgNS     = "http://www.w3.org/2000/svg"
+	ixNS      = "http://www.xbrl.org/2013/inlineXBRL"
+	ix10NS    = "http://www.xbrl.org/2008/inlineXBRL"
+	xbrliNS   = "http://www.xbrl.org/2003/instance"
+	xlinkNS   = "http://www.w3.org/1999/xlink"
+	xsiNS     = "http://www.w3.org/2001/XMLSchema-instance"
+	xmlnsAttr = "xmlns"
+)
+
+// certificationDateID is the id Bolagsverket sets the fastställelseintyg
+// signing date by (tillämpningsanvisningar 4.4.2).
+const certificationDateID = "ID_DATUM_UNDERTECKNANDE_FASTSTALLELSEINTYG"
+
+// Bolagsverket's codes for documents rejected by the technical checks
+// (teknisk guide, appendix 6.2).
+const (
+	codeInvalidIXBRL      = 4001
+	codeTitle             = 5001
+	codeNotIXBRL          = 5002
+	codeExternalImage     = 5003
+	codeExternalCSS       = 5004
+	codeScript            = 5005
+	codeDocumentSize      = 5006
+	codeImageSize         = 5007
+	codeCharset           = 5008
+	codeSoftware          = 5009
+	codeExternalReference = 5010
+	codeCite              = 5011
+	codeIframe            = 5012
+	codeEmbed             = 5013
+	codeForm              = 5014
+	codeFormAction        = 5015
+)
+
+// xmlEncodingRe matches the encoding in the XML declaration.
+var xmlEncodingRe = regexp.MustCompile(`^\s*<\?xml[^>]*\bencoding\s*=\s*["']([^"']+)["']`)
+
+// cssURLRe matches url(...) and @import references in CSS; the first
+// group is set for imports.
+var cssURLRe = regexp.MustCompile(`(?i)(@import\s+)?(?:url\(\s*["']?([^"')]*)|["']([^"']*))`)
+
+// Lint checks an iXBRL document against the technical rules in
+// Bolagsverket's tillämpningsanvisningar for annual reports in iXBRL,
+// version 1.8: well-formed XHTML in UTF-8 without HTML entities, no
+// scripts, event handlers or executable elements, no external resources,
+// the size limits for the document and its images, decimals rather than
+// precision, the required title and meta tags, and the id of the
+// fastställelseintyg signing date. It also checks that every contextRef
+// and unitRef is defined, that every format is in the transformation
+// registry declared for its prefix, that ids are unique and that
+// continuation chains are complete.
+//
+// Results carry Bolagsverket's code where the rule has one. Field holds
+// the location, e.g. "line 12", or "document" for the file as a whole.
+func Lint(doc []byte) []Result {
+	l := &linter{
+		ids:           map[string]int{},
+		contexts:      map[string]bool{},
+		units:         map[string]bool{},
+		continuations: map[string]int{},
+		next:          map[string]string{},
+		continuedFrom: map[string]int{},
+		meta:          map[string]string{},
+	}
+	l.lint(doc)
+	return l.results
+}
+
+// reference is an attribute referring to another element.
+type reference struct {
+	attr, value string
+	line        int
+}
+
+// linter collects what Lint checks after reading the document.
+type linter struct {
+	results []Result
+	dec     *xml.Decoder
+	scopes  []map[string]string // namespace prefixes declared by each open element
+
+	ids           map[string]int // id → line of first use
+	contexts      map[string]bool
+	units         map[string]bool
+	refs          []reference       // contextRef and unitRef
+	continuations map[string]int    // ix:continuation id → line
+	next          map[string]string // continuation id → its continuedAt
+	continuedFrom map[string]int    // continuedAt value → number of elements using it
+	chainStarts   []reference       // continuedAt of facts and footnotes
+
+	inHead, inTitle, inStyle bool
+	title                    string
+	meta                     map[string]string
+	headerSeen               bool
+	certificationDate        bool // UnderskriftFastallelseintygDatum tagged
+	certification            bool // ArsstammaIntygande tagged
+}
+
+func (l *linter) report(sev Severity, code int, line int, format string, args ...any) {
+	field := "document"
+	if line > 0 {
+		field = fmt.Sprintf("line %d", line)
+	}
+	l.results = append(l.results, Result{Severity: sev, Code: code, Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (l *linter) lint(doc []byte) {
+	if len(doc) >= ixbrl.MaxDocumentSize {
+		l.report(Error, codeDocumentSize, 0, "document is %d bytes, must be smaller than %d bytes (4.2.1)", len(doc), ixbrl.MaxDocumentSize)
+	}
+	if m := xmlEncodingRe.FindSubmatch(doc); m != nil && !strings.EqualFold(string(m[1]), "utf-8") {
+		l.report(Error, codeCharset, 0, "encoding is declared as %s, must be UTF-8 (3.2.3)", m[1])
+	}
+	if !utf8.Valid(doc) {
+		l.report(Error, codeCharset, 0, "document is not valid UTF-8 (3.2.3)")
+		return
+	}
+
+	l.dec = xml.NewDecoder(bytes.NewReader(doc))
+	l.dec.CharsetReader = func(_ string, r io.Reader) (io.Reader, error) { return r, nil }
+	root := true
+	for {
+		tok, err := l.dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			line, _ := l.dec.InputPos()
+			msg := "document is not well-formed XHTML: %v (3.2.1)"
+			if strings.Contains(err.Error(), "entity") {
+				msg = "document is not well-formed XHTML: %v; only the XML entities and numeric references may be used (3.2.4)"
+			}
+			l.report(Error, codeInvalidIXBRL, line, msg, err)
+			return
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if root {
+				root = false
+				if t.Name.Space != xhtmlNS || t.Name.Local != "html" {
+					l.report(Error, codeInvalidIXBRL, l.line(), "root element must be html in the XHTML namespace (3.2.1)")
+				}
+			}
+			l.start(t)
+		case xml.EndElement:
+			l.end(t)
+		case xml.CharData:
+			if l.inTitle {
+				l.title += string(t)
+			}
+			if l.inStyle {
+				l.checkCSS(string(t), l.line())
+			}
+		}
+	}
+	l.finish()
+}
+
+// line returns the line of the token just read.
+func (l *linter) line() int {
+	line, _ := l.dec.InputPos()
+	return line
+}
+
+func (l *linter) start(e xml.StartElement) {
+	line := l.line()
+	var scope map[string]string
+	for _, a := range e.Attr {
+		if a.Name.Space == xmlnsAttr || (a.Name.Space == "" && a.Name.Local == xmlnsAttr) {
+			if a.Value == ix10NS {
+				l.report(Error, codeNotIXBRL, line, "inline XBRL 1.0 namespace %s; iXBRL 1.1 must be used (3.1)", a.Value)
+			}
+			if a.Name.Space == xmlnsAttr {
+				if scope == nil {
+					scope = map[string]string{}
+				}
+				scope[a.Name.Local] = a.Value
+			}
+			continue
+		}
+		if a.Name.Space == "" && a.Name.Local == "id" {
+			if first, ok := l.ids[a.Value]; ok {
+				l.report(Error, 0, line, "id %q is already used on line %d", a.Value, first)
+			} else {
+				l.ids[a.Value] = line
+			}
+		}
+	}
+
+	l.scopes = append(l.scopes, scope)
+
+	switch e.Name.Space {
+	case xhtmlNS, svgNS:
+		l.checkHTML(e, line)
+	case ixNS:
+		l.checkIX(e, line)
+	case xbrliNS:
+		switch e.Name.Local {
+		case "context":
+			l.contexts[attr(e, "", "id")] = true
+		case "unit":
+			l.units[attr(e, "", "id")] = true
+		}
+	}
+}
+
+func (l *linter) end(e xml.EndElement) {
+	l.scopes = l.scopes[:len(l.scopes)-1]
+	if e.Name.Space != xhtmlNS {
+		return
+	}
+	switch e.Name.Local {
+	case "head":
+		l.inHead = false
+	case "title":
+		l.inTitle = false
+	case "style":
+		l.inStyle = false
+	}
+}
+
+// checkHTML checks an XHTML or SVG element for scripts, executable
+// content and external references.
+func (l *linter) checkHTML(e xml.StartElement, line int) {
+	name := e.Name.Local
+	switch name {
+	case "head":
+		l.inHead = true
+	case "title":
+		l.inTitle = l.inHead
+	case "style":
+		l.inStyle = true
+	case "meta":
+		if n := attr(e, "", "name"); n != "" {
+			l.meta[n] = attr(e, "", "content")
+		}
+	case "script":
+		l.report(Error, codeScript, line, "script element is not allowed (3.4.1)")
+	case "applet", "object":
+		l.report(Error, codeScript, line, "%s element is not allowed (3.4.2)", name)
+	case "iframe":
+		l.report(Error, codeIframe, line, "iframe element is not allowed (3.6.1)")
+	case "embed":
+		l.report(Error, codeEmbed, line, "embed element is not allowed (3.4.2)")
+	case "form":
+		l.report(Error, codeForm, line, "form element is not allowed (3.4)")
+	case "link":
+		if strings.EqualFold(attr(e, "", "rel"), "stylesheet") {
+			l.report(Error, codeExternalCSS, line, "external stylesheet %q; styles must be declared in the document (3.7.3)", attr(e, "", "href"))
+			return
+		}
+	}
+
+	for _, a := range e.Attr {
+		local := strings.ToLower(a.Name.Local)
+		switch {
+		case a.Name.Space == "" && strings.HasPrefix(local, "on"):
+			l.report(Error, codeScript, line, "event handler attribute %s is not allowed (3.4.1)", a.Name.Local)
+		case a.Name.Space == "" && local == "cite":
+			l.report(Error, codeCite, line, "cite attribute is not allowed (3.6.1)")
+		case a.Name.Space == "" && local == "formaction":
+			l.report(Error, codeFormAction, line, "formaction attribute is not allowed (3.4.1)")
+		case a.Name.Space == "" && local == "style":
+			l.checkCSS(a.Value, line)
+		case local == "src" || local == "href" || local == "data" || local == "srcset" || local == "background" || local == "poster":
+			if a.Name.Space != "" && a.Name.Space != xlinkNS {
+				continue
+			}
+			l.checkReference(name, a.Name.Local, strings.TrimSpace(a.Value), line)
+		}
+	}
+}
+
+// checkReference checks a URL attribute: links must point within the
+// document and images must be embedded.
+func (l *linter) checkReference(element, attrName, value string, line int) {
+	lower := strings.ToLower(value)
+	switch {
+	case strings.HasPrefix(lower, "javascript:"):
+		l.report(Error, codeScript, line, "%s=%q runs a script (3.4.1)", attrName, value)
+	case strings.HasPrefix(lower, "data:"):
+		l.checkDataURI(value, line)
+	case element == "img" || element == "image":
+		l.report(Error, codeExternalImage, line, "image %q refers to an external resource; images must be embedded with base64 (3.5.4)", value)
+	case strings.HasPrefix(value, "#"):
+	default:
+		l.report(Error, codeExternalReference, line, "%s %s=%q refers to an external resource; links must use #-notation (3.6.1)", element, attrName, value)
+	}
+}
+
+// checkDataURI checks an embedded image against the allowed formats and
+// the size limit. Other embedded data, such as fonts, is allowed (3.11.2).
+func (l *linter) checkDataURI(uri string, line int) {
+	header, data, ok := strings.Cut(uri[len("data:"):], ",")
+	if !ok {
+		l.report(Error, codeExternalImage, line, "malformed data URI")
+		return
+	}
+	mediaType, _, _ := strings.Cut(header, ";")
+	if !strings.HasPrefix(strings.ToLower(mediaType), "image/") {
+		return
+	}
+	if !strings.HasSuffix(header, ";base64") {
+		l.report(Error, codeExternalImage, line, "image %s must be base64 encoded (3.5.4)", mediaType)
+		return
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(data), ""))
+	if err != nil {
+		l.report(Error, codeExternalImage, line, "image %s has invalid base64 data: %v", mediaType, err)
+		return
+	}
+	if len(raw) >= ixbrl.MaxImageSize {
+		l.report(Error, codeImageSize, line, "image is %d bytes, must be smaller than %d bytes (4.2.2)", len(raw), ixbrl.MaxImageSize)
+		return
+	}
+	if _, err := ixbrl.CheckImage(&model.Image{MediaType: mediaType, Data: raw}); err != nil {
+		l.report(Error, codeExternalImage, line, "image: %v (3.5.3)", err)
+	}
+}
+
+// checkCSS checks a style sheet or style attribute for external
+// references.
+func (l *linter) checkCSS(css string, line int) {
+	for _, m := range cssURLRe.FindAllStringSubmatch(css, -1) {
+		u := strings.TrimSpace(m[2] + m[3])
+		if m[1] != "" {
+			l.report(Error, codeExternalCSS, line, "@import %q; styles must be declared in the document (3.7.3)", u)
+			continue
+		}
+		if !strings.HasPrefix(strings.ToLower(m[0]), "url(") {
+			continue // a quoted string outside url()
+		}
+		switch {
+		case strings.HasPrefix(strings.ToLower(u), "data:"):
+			l.checkDataURI(u, line)
+		case strings.HasPrefix(u, "#"):
+		default:
+			l.report(Error, codeExternalReference, line, "style refers to external resource %q (3.6.1)", u)
+		}
+	}
+}
+
+// checkIX checks an inline XBRL element and records its references.
+func (l *linter) checkIX(e xml.StartElement, line int) {
+	name := e.Name.Local
+	switch name {
+	case "header":
+		l.headerSeen = true
+	case "nonFraction":
+		if hasAttr(e, "", "precision") {
+			l.report(Error, 0, line, "precision attribute on %s; decimals must be used (2.10.1)", attr(e, "", "name"))
+		} else if !hasAttr(e, "", "decimals") && attr(e, xsiNS, "nil") != "true" {
+			l.report(Error, 0, line, "%s has no decimals attribute (2.10.1)", attr(e, "", "name"))
+		}
+		if u := attr(e, "", "unitRef"); u != "" {
+			l.refs = append(l.refs, reference{"unitRef", u, line})
+		} else {
+			l.report(Error, 0, line, "%s has no unitRef", attr(e, "", "name"))
+		}
+	case "continuation":
+		id := attr(e, "", "id")
+		l.continuations[id] = line
+		if next := attr(e, "", "continuedAt"); next != "" {
+			l.next[id] = next
+			l.continuedFrom[next]++
+		}
+	}
+
+	if name == "nonFraction" || name == "nonNumeric" {
+		if c := attr(e, "", "contextRef"); c != "" {
+			l.refs = append(l.refs, reference{"contextRef", c, line})
+		} else {
+			l.report(Error, 0, line, "%s has no contextRef", attr(e, "", "name"))
+		}
+		if format := attr(e, "", "format"); format != "" {
+			l.checkFormat(format, line)
+		}
+		_, local, _ := strings.Cut(attr(e, "", "name"), ":")
+		switch local {
+		case "UnderskriftFastallelseintygDatum":
+			l.certificationDate = true
+			if id := attr(e, "", "id"); id != certificationDateID {
+				l.report(Error, 0, line, "UnderskriftFastallelseintygDatum must have id=%q (4.4.2)", certificationDateID)
+			}
+		case "ArsstammaIntygande":
+			l.certification = true
+		}
+	}
+	if name == "nonNumeric" || name == "footnote" {
+		if next := attr(e, "", "continuedAt"); next != "" {
+			l.continuedFrom[next]++
+			l.chainStarts = append(l.chainStarts, reference{"continuedAt", next, line})
+		}
+	}
+}
+
+// checkFormat checks that a fact's format is a rule of the transformation
+// registry its prefix is declared for.
+func (l *linter) checkFormat(format string, line int) {
+	prefix, name, ok := strings.Cut(format, ":")
+	if !ok {
+		l.report(Error, 0, line, "format %q has no namespace prefix", format)
+		return
+	}
+	ns := l.namespace(prefix)
+	switch {
+	case ns == "":
+		l.report(Error, 0, line, "format %q: prefix %q is not declared", format, prefix)
+	case !ixbrl.TransformDefined(ns, name):
+		l.report(Error, 0, line, "format %q is not in the transformation registry %s", format, ns)
+	}
+}
+
+// namespace returns the namespace URI declared for prefix where the
+// current element is, or "".
+func (l *linter) namespace(prefix string) string {
+	for i := len(l.scopes) - 1; i >= 0; i-- {
+		if ns, ok := l.scopes[i][prefix]; ok {
+			return ns
+		}
+	}
+	return ""
+}
+
+// finish runs the checks that need the whole document.
+func (l *linter) finish() {
+	if !l.headerSeen {
+		l.report(Error, codeNotIXBRL, 0, "document has no ix:header; it is not an inline XBRL 1.1 document (3.1)")
+	}
+	if strings.TrimSpace(l.title) == "" {
+		l.report(Error, codeTitle, 0, "document has no title or an empty title (3.8.1)")
+	}
+	for _, name := range []string{"programvara", "programversion"} {
+		if strings.TrimSpace(l.meta[name]) == "" {
+			l.report(Error, codeSoftware, 0, "meta element %q is missing or empty (4.3.1)", name)
+		}
+	}
+	if l.certification && !l.certificationDate {
+		l.report(Error, 0, 0, "fastställelseintyg has no UnderskriftFastallelseintygDatum with id=%q (4.4.2)", certificationDateID)
+	}
+	if line, ok := l.ids[certificationDateID]; ok && !l.certificationDate {
+		l.report(Error, 0, line, "id %q must be on UnderskriftFastallelseintygDatum (4.4.2)", certificationDateID)
+	}
+
+	for _, r := range l.refs {
+		defined := l.contexts
+		if r.attr == "unitRef" {
+			defined = l.units
+		}
+		if !defined[r.value] {
+			l.report(Error, 0, r.line, "%s %q is not defined", r.attr, r.value)
+		}
+	}
+
+	// Each continuation must be reached from exactly one fact or
+	// footnote, through a chain that ends.
+	reached := map[string]bool{}
+	for _, start := range l.chainStarts {
+		seen := map[string]bool{}
+		for id := start.value; id != ""; {
+			line, ok := l.continuations[id]
+			if !ok {
+				l.report(Error, 0, start.line, "continuedAt %q does not refer to an ix:continuation", id)
+				break
+			}
+			if seen[id] {
+				l.report(Error, 0, line, "continuation chain loops at %q", id)
+				break
+			}
+			seen[id] = true
+			reached[id] = true
+			id = l.next[id]
+		}
+	}
+	for _, id := range sortedByLine(l.continuations) {
+		line := l.continuations[id]
+		switch n := l.continuedFrom[id]; {
+		case n > 1:
+			l.report(Error, 0, line, "continuation %q is continued from %d elements, must be from one", id, n)
+		case !reached[id]:
+			l.report(Error, 0, line, "continuation %q is not part of a chain from a fact or footnote", id)
+		}
+	}
+}
+
+// sortedByLine returns the keys of m ordered by their line.
+func sortedByLine(m map[string]int) []string {
+	keys := slices.Collect(maps.Keys(m))
+	slices.SortFunc(keys, func(a, b string) int {
+		return cmp.Or(cmp.Compare(m[a], m[b]), strings.Compare(a, b))
+	})
+	return keys
+}
+
+// attr returns the value of an attribute, or "".
+func attr(e xml.StartElement, space, local string) string {
+	for _, a := range e.Attr {
+		if a.Name.Space == space && a.Name.Local == local {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// hasAttr reports whether e has the attribute.
+func hasAttr(e xml.StartElement, space, local string) bool {
+	for _, a := range e.Attr {
+		if a.Name.Space == space && a.Name.Local == local {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,152 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/redofri/redofri/pkg/ixbrl"
+)
+
+// lintDoc is a minimal document that passes Lint. The cases in TestLint
+// replace parts of it.
+const lintDoc = `<?xml version="1.0" encoding="UTF-8"?>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:ix="http://www.xbrl.org/2013/inlineXBRL"
+  xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:link="http://www.xbrl.org/2003/linkbase"
+  xmlns:xlink="http://www.w3.org/1999/xlink" xmlns:iso4217="http://www.xbrl.org/2003/iso4217" xmlns:ixt="http://www.xbrl.org/inlineXBRL/transformation/2010-04-20"
+  xmlns:se-gen-base="http://www.taxonomier.se/se/fr/gen-base/2021-10-31"
+  xmlns:se-bol-base="http://www.bolagsverket.se/se/fr/comp-base/2020-12-01">
+<head>
+<title>556999-9999 Exempel AB - Årsredovisning</title>
+<meta name="programvara" content="redofri"/>
+<meta name="programversion" content="1.0"/>
+<style type="text/css">body { font-family: "Arial"; }</style>
+</head>
+<body>
+<div style="display:none">
+<ix:header>
+<ix:references><link:schemaRef xlink:type="simple" xlink:href="http://www.taxonomier.se/se/fr/k2/2021-10-31/se-k2-ab-risbs-2021-10-31.xsd"/></ix:references>
+<ix:resources>
+<xbrli:context id="period0"><xbrli:entity><xbrli:identifier scheme="http://www.bolagsverket.se">5569999999</xbrli:identifier></xbrli:entity><xbrli:period><xbrli:startDate>2021-01-01</xbrli:startDate><xbrli:endDate>2021-12-31</xbrli:endDate></xbrli:period></xbrli:context>
+<xbrli:context id="balans0"><xbrli:entity><xbrli:identifier scheme="http://www.bolagsverket.se">5569999999</xbrli:identifier></xbrli:entity><xbrli:period><xbrli:instant>2021-12-31</xbrli:instant></xbrli:period></xbrli:context>
+<xbrli:unit id="SEK"><xbrli:measure>iso4217:SEK</xbrli:measure></xbrli:unit>
+</ix:resources>
+</ix:header>
+</div>
+<p><a href="#not1">Not 1</a></p>
+<p><ix:nonNumeric name="se-bol-base:ArsstammaIntygande" contextRef="balans0" continuedAt="intyg1">Jag intygar</ix:nonNumeric></p>
+<p><ix:continuation id="intyg1" continuedAt="intyg2">att resultaträkningen</ix:continuation></p>
+<p><ix:continuation id="intyg2">fastställts.</ix:continuation></p>
+<p><ix:nonNumeric name="se-bol-base:UnderskriftFastallelseintygDatum" contextRef="balans0" id="ID_DATUM_UNDERTECKNANDE_FASTSTALLELSEINTYG">2022-03-01</ix:nonNumeric></p>
+<p id="not1"><ix:nonFraction name="se-gen-base:Nettoomsattning" contextRef="period0" unitRef="SEK" decimals="INF" scale="0" format="ixt:numspacecomma">1 000</ix:nonFraction></p>
+<img src="IMAGE" alt="logo"/>
+</body>
+</html>
+`
+
+// pngData is the smallest valid PNG header, enough for type detection.
+var pngData = append([]byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"), make([]byte, 32)...)
+
+func validLintDoc() string {
+	return strings.Replace(lintDoc, "IMAGE", "data:image/png;base64,"+base64.StdEncoding.EncodeToString(pngData), 1)
+}
+
+func TestLint(t *testing.T) {
+	if results := Lint([]byte(validLintDoc())); len(results) != 0 {
+		t.Fatalf("valid document: %v", results)
+	}
+
+	bigImage := "data:image/png;base64," + base64.StdEncoding.EncodeToString(append(pngData, make([]byte, 1_000_000)...))
+	tests := []struct {
+		name     string
+		from, to string
+		code     int
+		message  string
+	}{
+		{"not well-formed", "</body>", "</div></body>", codeInvalidIXBRL, "not well-formed"},
+		{"HTML entity", "Not 1", "Not&nbsp;1", codeInvalidIXBRL, "(3.2.4)"},
+		{"encoding", `encoding="UTF-8"`, `encoding="ISO-8859-1"`, codeCharset, "must be UTF-8"},
+		{"invalid UTF-8", "Årsredovisning", "\xc5rsredovisning", codeCharset, "not valid UTF-8"},
+		{"script", "<body>", "<body><script>alert(1)</script>", codeScript, "script element"},
+		{"event handler", `<p id="not1">`, `<p id="not1" onclick="x()">`, codeScript, "onclick"},
+		{"javascript link", `href="#not1"`, `href="javascript:x()"`, codeScript, "runs a script"},
+		{"iframe", "<body>", `<body><iframe src="#x"></iframe>`, codeIframe, "iframe"},
+		{"embed", "<body>", "<body><embed/>", codeEmbed, "embed"},
+		{"form", "<body>", "<body><form></form>", codeForm, "form"},
+		{"cite", `<p id="not1">`, `<p id="not1"><q cite="#x">q</q>`, codeCite, "cite"},
+		{"external link", `href="#not1"`, `href="https://example.com/"`, codeExternalReference, "#-notation"},
+		{"external image", "IMAGE", "logo.png", codeExternalImage, "embedded with base64"},
+		{"image size", "IMAGE", bigImage, codeImageSize, "must be smaller than 1000000"},
+		{"image type", "IMAGE", "data:image/bmp;base64,Qk0=", codeExternalImage, "unsupported"},
+		{"external stylesheet", "</head>", `<link rel="stylesheet" href="style.css"/></head>`, codeExternalCSS, "style.css"},
+		{"css import", "body {", `@import url("x.css"); body {`, codeExternalCSS, "@import"},
+		{"css url", "body {", `body { background: url(https://example.com/bg.png); }`, codeExternalReference, "bg.png"},
+		{"title", "556999-9999 Exempel AB - Årsredovisning", "", codeTitle, "title"},
+		{"software", `<meta name="programversion" content="1.0"/>`, "", codeSoftware, "programversion"},
+		{"iXBRL 1.0", "http://www.xbrl.org/2013/inlineXBRL", "http://www.xbrl.org/2008/inlineXBRL", codeNotIXBRL, "iXBRL 1.1"},
+		{"precision", `decimals="INF"`, `precision="INF"`, 0, "decimals must be used"},
+		{"decimals", ` decimals="INF"`, "", 0, "no decimals"},
+		{"certification id", ` id="ID_DATUM_UNDERTECKNANDE_FASTSTALLELSEINTYG"`, "", 0, "must have id"},
+		{"duplicate id", `<p id="not1">`, `<p id="intyg2">`, 0, `id "intyg2" is already used`},
+		{"undefined context", `contextRef="period0"`, `contextRef="period9"`, 0, `contextRef "period9" is not defined`},
+		{"format from another registry", `format="ixt:numspacecomma"`, `format="ixt:numdotdecimal"`, 0, "not in the transformation registry"},
+		{"undeclared format prefix", `format="ixt:numspacecomma"`, `format="ixt4:num-dot-decimal"`, 0, `prefix "ixt4" is not declared`},
+		{"undefined unit", `unitRef="SEK"`, `unitRef="EUR"`, 0, `unitRef "EUR" is not defined`},
+		{"missing continuation", `continuedAt="intyg1"`, `continuedAt="intyg9"`, 0, `continuedAt "intyg9" does not refer`},
+		{"unreached continuation", ` continuedAt="intyg2"`, "", 0, `"intyg2" is not part of a chain`},
+		{"continuation continued twice", `<ix:continuation id="intyg2">`, `<ix:continuation id="intyg2" continuedAt="intyg1">`, 0, "continued from 2 elements"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := strings.Replace(validLintDoc(), tt.from, tt.to, 1)
+			if tt.from == "IMAGE" {
+				doc = strings.Replace(lintDoc, "IMAGE", tt.to, 1)
+			}
+			results := Lint([]byte(doc))
+			for _, r := range results {
+				if r.Severity == Error && r.Code == tt.code && strings.Contains(r.Message, tt.message) {
+					return
+				}
+			}
+			t.Errorf("no error with code %d and %q in %v", tt.code, tt.message, results)
+		})
+	}
+
+	t.Run("document size", func(t *testing.T) {
+		doc := strings.Replace(validLintDoc(), "</body>", "<p>"+strings.Repeat("x", 5_000_000)+"</p></body>", 1)
+		results := Lint([]byte(doc))
+		if len(results) != 1 || results[0].Code != codeDocumentSize || results[0].Field != "document" {
+			t.Errorf("results = %v", results)
+		}
+	})
+
+	t.Run("line numbers", func(t *testing.T) {
+		doc := strings.Replace(validLintDoc(), `contextRef="period0"`, `contextRef="period9"`, 1)
+		results := Lint([]byte(doc))
+		if len(results) != 1 || results[0].Field != "line 29" {
+			t.Errorf("results = %v", results)
+		}
+	})
+}
+
+// TestLintReferenceExample checks that Bolagsverket's example and the
+// generated example report pass.
+func TestLintReferenceExample(t *testing.T) {
+	doc, err := os.ReadFile("../../ref/exempel/faststalld-arsredovisning-exempel-1.xhtml")
+	if err != nil {
+		t.Skipf("reference example not available: %v", err)
+	}
+	if results := Lint(doc); len(results) != 0 {
+		t.Errorf("reference example: %v", results)
+	}
+
+	var buf bytes.Buffer
+	if err := ixbrl.Generate(&buf, loadTestReport(t)); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if results := Lint(buf.Bytes()); len(results) != 0 {
+		t.Errorf("generated report: %v", results)
+	}
+}
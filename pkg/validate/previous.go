@@ -0,0 +1,335 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/redofri/redofri/pkg/diff"
+	"github.com/redofri/redofri/pkg/model"
+)
+
+// PreviousReportRules returns the rules that check the report against
+// last year's filed report: "previous-report-period", which checks that
+// it is the report of the fiscal year before, and "previous-report",
+// which then compares the comparatives with ComparePrevious. They are not
+// registered; pass them to NewValidator with the filed report, e.g.
+// parsed from its iXBRL. It fails if previous is nil.
+func PreviousReportRules(previous *model.AnnualReport) ([]Rule, error) {
+	if previous == nil {
+		return nil, errors.New("previous-report: no filed report loaded")
+	}
+	return []Rule{
+		NewRule("previous-report-period", 0, Error, func(r *model.AnnualReport) []Finding {
+			return comparePeriod(r, previous)
+		}),
+		NewRule("previous-report", 0, Error, func(r *model.AnnualReport) []Finding {
+			if comparePeriod(r, previous) != nil {
+				return nil
+			}
+			return ComparePrevious(r, previous)
+		}),
+	}, nil
+}
+
+// ComparePrevious compares the comparatives of r with previous, last
+// year's filed report: the previous year's figures in the statements and
+// notes with the filed year's, the opening balances of the fixed asset
+// notes and the changes in equity with the filed closing balances, and
+// the multi-year overview with the filed overview, one year later. Absent
+// values count as zero. If previous does not end the day before r's fiscal
+// year starts, that is the only finding.
+//
+// Fields restated with a justification in
+// notes.accountingPolicies.restatements may differ from the filed report.
+func ComparePrevious(r, previous *model.AnnualReport) []Finding {
+	if f := comparePeriod(r, previous); f != nil {
+		return f
+	}
+
+	var f findings
+
+	var restatements []model.Restatement
+	for i, rs := range r.Notes.AccountingPolicies.Restatements {
+		field := fmt.Sprintf("notes.accountingPolicies.restatements[%d]", i)
+		switch {
+		case rs.Field == "":
+			f.add(field+".field", "restatement without a field")
+		case strings.TrimSpace(rs.Justification) == "":
+			f.add(field+".justification", fmt.Sprintf("restatement of %s has no justification", rs.Field))
+		default:
+			restatements = append(restatements, rs)
+		}
+	}
+	restated := func(field string) bool {
+		for _, rs := range restatements {
+			if withinField(field, rs.Field) {
+				return true
+			}
+		}
+		return false
+	}
+
+	cur, curPaths, err := diff.Fields(r)
+	if err != nil {
+		f.add("document", fmt.Sprintf("reading the report: %v", err))
+		return f
+	}
+	filed, filedPaths, err := diff.Fields(previous)
+	if err != nil {
+		f.add("document", fmt.Sprintf("reading last year's filed report: %v", err))
+		return f
+	}
+	c := matchComparatives(r, previous)
+	for _, note := range c.unmatched {
+		if !restated(note.Field) {
+			f.add(note.Field, note.Message)
+		}
+	}
+
+	seen := map[string]bool{}
+	compare := func(field, filedField string) {
+		if seen[field] {
+			return
+		}
+		seen[field] = true
+		v, ok := cur[field]
+		fv, fok := filed[filedField]
+		if orZero(v) == orZero(fv) || restated(field) {
+			return
+		}
+		switch {
+		case !fok:
+			f.add(field, fmt.Sprintf("%s, but last year's filed report has no %s", v, filedField))
+		case !ok:
+			f.add(field, fmt.Sprintf("missing; last year's filed report has %s = %s", filedField, fv))
+		default:
+			f.add(field, fmt.Sprintf("%s ≠ %s in last year's filed report (%s)", v, filedField, fv))
+		}
+	}
+	for _, p := range curPaths {
+		if fp := c.filedPath(p); fp != "" {
+			compare(p, fp)
+		}
+	}
+	for _, fp := range filedPaths {
+		for _, p := range c.comparativePaths(fp) {
+			compare(p, fp)
+		}
+	}
+
+	compareOverview(r, previous, restated, &f)
+	return f
+}
+
+// comparePeriod checks that previous ends the day before r's fiscal year
+// starts.
+func comparePeriod(r, previous *model.AnnualReport) []Finding {
+	start, ok := parseDate(r.FiscalYear.StartDate)
+	if !ok {
+		return nil
+	}
+	end, ok := parseDate(previous.FiscalYear.EndDate)
+	if !ok || end.AddDate(0, 0, 1).Equal(start) {
+		return nil
+	}
+	var f findings
+	f.add("fiscalYear.startDate",
+		fmt.Sprintf("last year's filed report ends %s, not the day before the fiscal year starts (%s)",
+			previous.FiscalYear.EndDate, r.FiscalYear.StartDate))
+	return f
+}
+
+// compareOverview compares the multi-year overview with the filed one.
+// The overview's years follow each other from the fiscal year, so each
+// year after the first is the filed overview's year before it. Amounts
+// are compared in tkr, as the generator writes them.
+func compareOverview(r, previous *model.AnnualReport, restated func(string) bool, f *findings) {
+	years, filedYears := r.ManagementReport.MultiYearOverview.Years, previous.ManagementReport.MultiYearOverview.Years
+	for i := 1; i < len(years) && i <= len(filedYears); i++ {
+		y, fy := years[i], filedYears[i-1]
+		field := fmt.Sprintf("managementReport.multiYearOverview.years[%d].", i)
+		filedField := fmt.Sprintf("managementReport.multiYearOverview.years[%d].", i-1)
+		for _, p := range []struct {
+			name         string
+			value, filed *int64
+		}{
+			{"netSales", y.NetSales, fy.NetSales},
+			{"resultAfterFinancialItems", y.ResultAfterFinancialItems, fy.ResultAfterFinancialItems},
+		} {
+			if p.value == nil || p.filed == nil || *p.value/1000 == *p.filed/1000 || restated(field+p.name) {
+				continue
+			}
+			f.add(field+p.name, fmt.Sprintf("%d tkr ≠ %s%s in last year's filed report (%d tkr)",
+				*p.value/1000, filedField, p.name, *p.filed/1000))
+		}
+		if y.Solidity == nil || fy.Solidity == nil || restated(field+"solidity") {
+			continue
+		}
+		s, err1 := strconv.ParseFloat(strings.Replace(*y.Solidity, ",", ".", 1), 64)
+		fs, err2 := strconv.ParseFloat(strings.Replace(*fy.Solidity, ",", ".", 1), 64)
+		if err1 == nil && err2 == nil && s != fs {
+			f.add(field+"solidity", fmt.Sprintf("solidity %s%% ≠ %ssolidity in last year's filed report (%s%%)",
+				*y.Solidity, filedField, *fy.Solidity))
+		}
+	}
+}
+
+// comparatives maps the fields of a report that repeat last year's filed
+// report to the filed fields. Fixed asset notes are matched by concept
+// prefix.
+type comparatives struct {
+	notes, filedNotes map[string]string // "notes.fixedAssetNotes[i]" paths, each way
+	unmatched         []Finding         // notes without a counterpart
+}
+
+// openingFields maps the opening balances of the fixed asset notes and
+// the changes in equity to the closing balances they repeat.
+var openingFields = map[string]string{
+	"openingAcquisitionValues": "closingAcquisitionValues",
+	"openingDepreciation":      "closingDepreciation",
+	"openingShareCapital":      "closingShareCapital",
+	"openingReserveFund":       "closingReserveFund",
+	"openingRetainedEarnings":  "closingRetainedEarnings",
+	"openingNetIncome":         "closingNetIncome",
+	"openingTotal":             "closingTotal",
+}
+
+const (
+	fixedAssetNotesField = "notes.fixedAssetNotes"
+	equityChangesField   = "managementReport.equityChanges."
+)
+
+func matchComparatives(r, previous *model.AnnualReport) *comparatives {
+	c := &comparatives{notes: map[string]string{}, filedNotes: map[string]string{}}
+	filed := map[string]int{}
+	for j, note := range previous.Notes.FixedAssetNotes {
+		filed[note.ConceptPrefix] = j
+	}
+	for i, note := range r.Notes.FixedAssetNotes {
+		field := fmt.Sprintf("%s[%d]", fixedAssetNotesField, i)
+		j, ok := filed[note.ConceptPrefix]
+		if !ok {
+			if !hasComparatives(note) {
+				continue // a new asset category
+			}
+			c.unmatched = append(c.unmatched, Finding{field,
+				fmt.Sprintf("last year's filed report has no %s note (%s)", note.Title, note.ConceptPrefix)})
+			continue
+		}
+		delete(filed, note.ConceptPrefix)
+		c.notes[field] = fmt.Sprintf("%s[%d]", fixedAssetNotesField, j)
+		c.filedNotes[c.notes[field]] = field
+	}
+	for _, note := range previous.Notes.FixedAssetNotes {
+		if _, ok := filed[note.ConceptPrefix]; ok {
+			c.unmatched = append(c.unmatched, Finding{fixedAssetNotesField,
+				fmt.Sprintf("last year's filed report has a %s note (%s), this report has none", note.Title, note.ConceptPrefix)})
+		}
+	}
+	return c
+}
+
+// hasComparatives reports whether a fixed asset note has values for the
+// previous year.
+func hasComparatives(note model.FixedAssetNote) bool {
+	for _, yc := range []model.YearComparison{
+		note.OpeningAcquisitionValues, note.Purchases, note.Sales, note.ClosingAcquisitionValues,
+		note.OpeningDepreciation, note.YearDepreciation, note.ClosingDepreciation, note.CarryingValue,
+	} {
+		if i64(yc.Previous) != 0 {
+			return true
+		}
+	}
+	return i64(note.OpeningAcquisitionValues.Current) != 0 || i64(note.OpeningDepreciation.Current) != 0
+}
+
+// filedPath returns the path in last year's filed report of the value
+// that the field at path repeats, or "" if it repeats none.
+func (c *comparatives) filedPath(path string) string {
+	if rest, ok := strings.CutPrefix(path, equityChangesField); ok {
+		if closing, ok := openingFields[rest]; ok {
+			return equityChangesField + closing
+		}
+		return ""
+	}
+	if !isComparativeSection(path) {
+		return ""
+	}
+	note, rest := splitNote(path)
+	if note != "" {
+		if note = c.notes[note]; note == "" {
+			return ""
+		}
+	}
+	if base, ok := strings.CutSuffix(rest, ".previous"); ok {
+		return note + base + ".current"
+	}
+	if base, ok := strings.CutSuffix(rest, ".current"); ok && note != "" {
+		if closing, ok := openingFields[strings.TrimPrefix(base, ".")]; ok {
+			return note + "." + closing + ".current"
+		}
+	}
+	return ""
+}
+
+// comparativePaths returns the paths of the fields that repeat the value
+// at filedPath in last year's filed report.
+func (c *comparatives) comparativePaths(filedPath string) []string {
+	if rest, ok := strings.CutPrefix(filedPath, equityChangesField); ok {
+		for opening, closing := range openingFields {
+			if rest == closing {
+				return []string{equityChangesField + opening}
+			}
+		}
+		return nil
+	}
+	if !isComparativeSection(filedPath) {
+		return nil
+	}
+	note, rest := splitNote(filedPath)
+	if note != "" {
+		if note = c.filedNotes[note]; note == "" {
+			return nil
+		}
+	}
+	base, ok := strings.CutSuffix(rest, ".current")
+	if !ok {
+		return nil
+	}
+	paths := []string{note + base + ".previous"}
+	if note != "" {
+		for opening, closing := range openingFields {
+			if strings.TrimPrefix(base, ".") == closing {
+				paths = append(paths, note+"."+opening+".current")
+			}
+		}
+	}
+	return paths
+}
+
+// isComparativeSection reports whether path is in a section of
+// YearComparison fields: the statements and the notes.
+func isComparativeSection(path string) bool {
+	return strings.HasPrefix(path, "incomeStatement.") || strings.HasPrefix(path, "balanceSheet.") ||
+		strings.HasPrefix(path, "notes.")
+}
+
+// splitNote splits a path below a fixed asset note into the note's path
+// and the rest; paths outside the notes have no note.
+func splitNote(path string) (note, rest string) {
+	if !strings.HasPrefix(path, fixedAssetNotesField+"[") {
+		return "", path
+	}
+	end := strings.IndexByte(path, ']')
+	return path[:end+1], path[end+1:]
+}
+
+// orZero returns v, or "0" for an absent value.
+func orZero(v string) string {
+	if v == "" {
+		return "0"
+	}
+	return v
+}
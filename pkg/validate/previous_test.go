@@ -0,0 +1,196 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/redofri/redofri/pkg/ixbrl"
+	"github.com/redofri/redofri/pkg/model"
+)
+
+// lastYearReport returns the report filed for the year before r, as r's
+// comparatives show it.
+func lastYearReport(t *testing.T, r *model.AnnualReport) *model.AnnualReport {
+	t.Helper()
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	var shift func(v any)
+	shift = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			_, current := v["current"]
+			_, previous := v["previous"]
+			if (current || previous) && len(v) <= 2 {
+				v["current"] = v["previous"]
+				delete(v, "previous")
+				return
+			}
+			for _, x := range v {
+				shift(x)
+			}
+		case []any:
+			for _, x := range v {
+				shift(x)
+			}
+		}
+	}
+	shift(doc["incomeStatement"])
+	shift(doc["balanceSheet"])
+	shift(doc["notes"])
+
+	mr := doc["managementReport"].(map[string]any)
+	ec := mr["equityChanges"].(map[string]any)
+	for opening, closing := range openingFields {
+		if v, ok := ec[opening]; ok {
+			ec[closing] = v
+			delete(ec, opening)
+		}
+	}
+	overview := mr["multiYearOverview"].(map[string]any)
+	overview["years"] = overview["years"].([]any)[1:]
+
+	data, err = json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var last model.AnnualReport
+	if err := json.Unmarshal(data, &last); err != nil {
+		t.Fatal(err)
+	}
+	last.FiscalYear = model.FiscalYear{StartDate: "2015-01-01", EndDate: "2015-12-31"}
+	return &last
+}
+
+func TestComparePrevious(t *testing.T) {
+	r := loadTestReport(t)
+	last := lastYearReport(t, r)
+	if found := ComparePrevious(r, last); len(found) != 0 {
+		t.Fatalf("comparatives as filed: %v", found)
+	}
+
+	// The filed report as parsed from its iXBRL.
+	data, err := ixbrl.GenerateBytes(last)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ixbrl.Parse(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found := ComparePrevious(r, parsed.Report); len(found) != 0 {
+		t.Fatalf("comparatives as parsed from the filed iXBRL: %v", found)
+	}
+
+	restate := func(field, justification string) func(r, last *model.AnnualReport) {
+		return func(r, last *model.AnnualReport) {
+			*r.IncomeStatement.Revenue.NetSales.Previous += 1000
+			r.Notes.AccountingPolicies.Restatements = append(r.Notes.AccountingPolicies.Restatements,
+				model.Restatement{Field: field, Justification: justification})
+		}
+	}
+	for _, tc := range []struct {
+		name    string
+		modify  func(r, last *model.AnnualReport)
+		want    []string // fields with findings
+		message string
+	}{
+		{"comparative", func(r, last *model.AnnualReport) {
+			*r.IncomeStatement.Revenue.NetSales.Previous += 1000
+		}, []string{"incomeStatement.revenue.netSales.previous"}, "≠ incomeStatement.revenue.netSales.current in last year's filed report"},
+		{"missing comparative", func(r, last *model.AnnualReport) {
+			r.BalanceSheet.Assets.TotalAssets.Previous = nil
+		}, []string{"balanceSheet.assets.totalAssets.previous"}, "missing; last year's filed report has balanceSheet.assets.totalAssets.current"},
+		{"note opening balance", func(r, last *model.AnnualReport) {
+			*last.Notes.FixedAssetNotes[0].ClosingAcquisitionValues.Current += 500
+		}, []string{"notes.fixedAssetNotes[0].openingAcquisitionValues.current", "notes.fixedAssetNotes[0].closingAcquisitionValues.previous"}, ""},
+		{"notes matched by concept", func(r, last *model.AnnualReport) {
+			notes := last.Notes.FixedAssetNotes
+			notes[0], notes[1] = notes[1], notes[0]
+		}, nil, ""},
+		{"note not filed", func(r, last *model.AnnualReport) {
+			last.Notes.FixedAssetNotes = last.Notes.FixedAssetNotes[1:]
+		}, []string{"notes.fixedAssetNotes[0]"}, "last year's filed report has no"},
+		{"equity changes opening", func(r, last *model.AnnualReport) {
+			*last.ManagementReport.EquityChanges.ClosingTotal += 1
+		}, []string{"managementReport.equityChanges.openingTotal"}, ""},
+		{"overview", func(r, last *model.AnnualReport) {
+			*last.ManagementReport.MultiYearOverview.Years[1].NetSales += 1000
+			*last.ManagementReport.MultiYearOverview.Years[0].ResultAfterFinancialItems += 999 // the same tkr
+			*last.ManagementReport.MultiYearOverview.Years[0].Solidity = "39.2"
+		}, []string{"managementReport.multiYearOverview.years[1].solidity", "managementReport.multiYearOverview.years[2].netSales"}, ""},
+		{"restated", restate("incomeStatement.revenue.netSales.previous", "Omklassificering av hyresintäkter"), nil, ""},
+		{"restated section", restate("incomeStatement", "Ändrad uppställningsform"), nil, ""},
+		{"restated without justification", restate("incomeStatement.revenue.netSales.previous", " "),
+			[]string{"notes.accountingPolicies.restatements[0].justification", "incomeStatement.revenue.netSales.previous"}, ""},
+		{"other fiscal year", func(r, last *model.AnnualReport) {
+			last.FiscalYear = model.FiscalYear{StartDate: "2014-01-01", EndDate: "2014-12-31"}
+		}, []string{"fiscalYear.startDate"}, "last year's filed report ends 2014-12-31"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := loadTestReport(t)
+			last := lastYearReport(t, r)
+			tc.modify(r, last)
+			found := ComparePrevious(r, last)
+			var fields []string
+			for _, f := range found {
+				fields = append(fields, f.Field)
+			}
+			if strings.Join(fields, ",") != strings.Join(tc.want, ",") {
+				t.Fatalf("findings = %v, want fields %v", found, tc.want)
+			}
+			if tc.message != "" && !strings.Contains(found[0].Message, tc.message) {
+				t.Errorf("message = %q, want it to contain %q", found[0].Message, tc.message)
+			}
+		})
+	}
+}
+
+func TestPreviousReportRules(t *testing.T) {
+	if _, err := PreviousReportRules(nil); err == nil {
+		t.Error("nil filed report accepted")
+	}
+	for _, tc := range []struct {
+		name   string
+		modify func(r, last *model.AnnualReport)
+		rule   string
+		field  string
+	}{
+		{"changed comparative", func(r, last *model.AnnualReport) { *r.IncomeStatement.Revenue.NetSales.Previous += 1000 },
+			"previous-report", "incomeStatement.revenue.netSales.previous"},
+		{"other fiscal year", func(r, last *model.AnnualReport) {
+			last.FiscalYear = model.FiscalYear{StartDate: "2014-01-01", EndDate: "2014-12-31"}
+		}, "previous-report-period", "fiscalYear.startDate"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := loadTestReport(t)
+			last := lastYearReport(t, r)
+			tc.modify(r, last)
+			rules, err := PreviousReportRules(last)
+			if err != nil {
+				t.Fatal(err)
+			}
+			v, err := NewValidator(nil, rules...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			results, _ := v.Validate(r)
+			var found []Result
+			for _, res := range results {
+				if strings.HasPrefix(res.Rule, "previous-report") {
+					found = append(found, res)
+				}
+			}
+			if len(found) != 1 || found[0].Rule != tc.rule || found[0].Severity != Error || found[0].Field != tc.field {
+				t.Errorf("results = %v", found)
+			}
+		})
+	}
+}
@@ -0,0 +1,299 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/redofri/redofri/pkg/model"
+)
+
+// Rule is a validation check with a stable id. Its findings get the rule's
+// Bolagsverket code and severity, unless a Config overrides the severity.
+type Rule interface {
+	// ID identifies the rule in configuration and results, e.g.
+	// "signing-after-year-end".
+	ID() string
+	// Code is the Bolagsverket code of the rule, 0 if it has none.
+	Code() int
+	// Severity is the default severity of the rule's findings.
+	Severity() Severity
+	// Check returns the rule's findings for a report.
+	Check(r *model.AnnualReport) []Finding
+}
+
+// Configurable is implemented by rules that take options in a Config.
+type Configurable interface {
+	// Configure returns the rule with the options applied. It fails on
+	// unknown or invalid options.
+	Configure(options json.RawMessage) (Rule, error)
+}
+
+// Finding is a problem found by a rule.
+type Finding struct {
+	Field   string // Dotted path to the field, e.g. "company.name".
+	Message string
+}
+
+// NewRule returns a Rule that calls check.
+func NewRule(id string, code int, severity Severity, check func(r *model.AnnualReport) []Finding) Rule {
+	return &funcRule{id, code, severity, check}
+}
+
+type funcRule struct {
+	id       string
+	code     int
+	severity Severity
+	check    func(r *model.AnnualReport) []Finding
+}
+
+func (r *funcRule) ID() string                                 { return r.id }
+func (r *funcRule) Code() int                                  { return r.code }
+func (r *funcRule) Severity() Severity                         { return r.severity }
+func (r *funcRule) Check(report *model.AnnualReport) []Finding { return r.check(report) }
+
+// Registry is an ordered set of rules with unique ids.
+type Registry struct {
+	rules []Rule
+	byID  map[string]Rule
+}
+
+// NewRegistry returns a registry with the built-in rules.
+func NewRegistry() *Registry {
+	reg := &Registry{byID: map[string]Rule{}}
+	for _, rule := range builtinRules() {
+		if err := reg.Register(rule); err != nil {
+			panic(err) // built-in ids are unique
+		}
+	}
+	return reg
+}
+
+// Register adds a rule after those already registered.
+func (reg *Registry) Register(rule Rule) error {
+	id := rule.ID()
+	if id == "" {
+		return fmt.Errorf("rule without id")
+	}
+	if reg.byID[id] != nil {
+		return fmt.Errorf("rule %q is already registered", id)
+	}
+	reg.rules = append(reg.rules, rule)
+	reg.byID[id] = rule
+	return nil
+}
+
+// Rules returns the registered rules in order.
+func (reg *Registry) Rules() []Rule {
+	return append([]Rule(nil), reg.rules...)
+}
+
+// Rule returns the rule with the given id, or nil.
+func (reg *Registry) Rule(id string) Rule {
+	return reg.byID[id]
+}
+
+// defaultRegistry holds the rules Validate runs.
+var defaultRegistry = NewRegistry()
+
+// Register adds a rule to the rules Validate and NewValidator run, e.g.
+// a firm's own check from an init function.
+func Register(rule Rule) error {
+	return defaultRegistry.Register(rule)
+}
+
+// Rules returns the rules Validate runs, in order.
+func Rules() []Rule {
+	return defaultRegistry.Rules()
+}
+
+// Config selects rules and severities, and suppresses findings. It is
+// read from JSON:
+//
+//	{
+//	  "rules": {
+//	    "agm-after-signing": {"enabled": false},
+//	    "comparatives-balance-sheet": {"severity": "error"},
+//	    "control-balance-sheet": {"options": {"untaxedReserves": true}}
+//	  },
+//	  "suppressions": [
+//	    {"rule": "accounting-policies-note-number", "justification": "Note 1 is the group note"}
+//	  ]
+//	}
+type Config struct {
+	Rules        map[string]RuleConfig `json:"rules,omitempty"`
+	Suppressions []Suppression         `json:"suppressions,omitempty"`
+}
+
+// RuleConfig configures a rule.
+type RuleConfig struct {
+	Enabled  *bool  `json:"enabled,omitempty"`  // nil keeps the rule enabled
+	Severity string `json:"severity,omitempty"` // "error" or "warning"; "" keeps the default
+	// Options are passed to a Configurable rule.
+	Options json.RawMessage `json:"options,omitempty"`
+}
+
+// Suppression hides findings of a rule, with the reason they are accepted.
+type Suppression struct {
+	Rule string `json:"rule"`
+	// Field limits the suppression to findings on this field or fields
+	// below it, e.g. "notes.fixedAssetNotes[0]"; empty matches all.
+	Field         string `json:"field,omitempty"`
+	Justification string `json:"justification"`
+}
+
+// Suppressed is a finding hidden by a suppression.
+type Suppressed struct {
+	Result
+	Justification string
+}
+
+// LoadConfig reads a Config from a JSON file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := ParseConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// ParseConfig parses a Config from JSON. Unknown keys are errors, so that
+// a misspelt setting is not silently ignored.
+func ParseConfig(data []byte) (*Config, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var cfg Config
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("parsing rule configuration: %w", err)
+	}
+	return &cfg, nil
+}
+
+// Validator runs a registry's rules with a configuration.
+type Validator struct {
+	rules      []Rule
+	severities map[string]Severity
+	config     *Config
+}
+
+// NewValidator returns a Validator for the rules Validate runs, plus any
+// extra rules, configured by cfg (nil for the defaults). It fails when the
+// configuration names an unknown rule or severity, gives options a rule
+// does not take, or a suppression has no justification.
+func NewValidator(cfg *Config, extra ...Rule) (*Validator, error) {
+	return defaultRegistry.Validator(cfg, extra...)
+}
+
+// Validator returns a Validator for the registry's rules, plus any extra
+// rules, configured by cfg (nil for the defaults).
+func (reg *Registry) Validator(cfg *Config, extra ...Rule) (*Validator, error) {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	known := map[string]Rule{}
+	for _, rule := range append(reg.Rules(), extra...) {
+		known[rule.ID()] = rule
+	}
+
+	v := &Validator{severities: map[string]Severity{}, config: cfg}
+	configured := map[string]Rule{}
+	for id, rc := range cfg.Rules {
+		rule := known[id]
+		if rule == nil {
+			return nil, fmt.Errorf("rule configuration: unknown rule %q", id)
+		}
+		switch strings.ToLower(rc.Severity) {
+		case "":
+		case "error":
+			v.severities[id] = Error
+		case "warning", "warn":
+			v.severities[id] = Warning
+		default:
+			return nil, fmt.Errorf("rule configuration: rule %q: unknown severity %q (available: error, warning)", id, rc.Severity)
+		}
+		if len(rc.Options) > 0 {
+			c, ok := rule.(Configurable)
+			if !ok {
+				return nil, fmt.Errorf("rule configuration: rule %q takes no options", id)
+			}
+			rule, err := c.Configure(rc.Options)
+			if err != nil {
+				return nil, fmt.Errorf("rule configuration: rule %q: %w", id, err)
+			}
+			configured[id] = rule
+		}
+	}
+	for i, s := range cfg.Suppressions {
+		if known[s.Rule] == nil {
+			return nil, fmt.Errorf("suppression %d: unknown rule %q", i+1, s.Rule)
+		}
+		if strings.TrimSpace(s.Justification) == "" {
+			return nil, fmt.Errorf("suppression %d (%s): justification is missing", i+1, s.Rule)
+		}
+	}
+
+	for _, rule := range append(reg.Rules(), extra...) {
+		if rc, ok := cfg.Rules[rule.ID()]; ok && rc.Enabled != nil && !*rc.Enabled {
+			continue
+		}
+		if c, ok := configured[rule.ID()]; ok {
+			rule = c
+		}
+		v.rules = append(v.rules, rule)
+	}
+	return v, nil
+}
+
+// defaultValidator returns a Validator for the registry's rules, plus any
+// extra rules, with the default configuration, which cannot be invalid.
+func (reg *Registry) defaultValidator(extra ...Rule) *Validator {
+	return &Validator{
+		rules:      append(reg.Rules(), extra...),
+		severities: map[string]Severity{},
+		config:     &Config{},
+	}
+}
+
+// Validate runs the enabled rules and returns their findings, apart from
+// suppressed ones, which are returned separately.
+func (v *Validator) Validate(r *model.AnnualReport) (results []Result, suppressed []Suppressed) {
+	for _, rule := range v.rules {
+		sev, ok := v.severities[rule.ID()]
+		if !ok {
+			sev = rule.Severity()
+		}
+		for _, f := range rule.Check(r) {
+			res := Result{Severity: sev, Code: rule.Code(), Field: f.Field, Message: f.Message, Rule: rule.ID()}
+			if s := v.suppression(res); s != nil {
+				suppressed = append(suppressed, Suppressed{res, s.Justification})
+				continue
+			}
+			results = append(results, res)
+		}
+	}
+	return results, suppressed
+}
+
+// suppression returns the suppression matching a result, or nil.
+func (v *Validator) suppression(res Result) *Suppression {
+	for i, s := range v.config.Suppressions {
+		if s.Rule != res.Rule {
+			continue
+		}
+		if s.Field == "" || withinField(res.Field, s.Field) {
+			return &v.config.Suppressions[i]
+		}
+	}
+	return nil
+}
+
+// withinField reports whether field is the field at path or below it.
+func withinField(field, path string) bool {
+	return field == path || strings.HasPrefix(field, path+".") || strings.HasPrefix(field, path+"[")
+}
@@ -0,0 +1,127 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/redofri/redofri/pkg/model"
+)
+
+func TestBuiltinRules(t *testing.T) {
+	seen := map[string]bool{}
+	for _, rule := range Rules() {
+		if rule.ID() == "" || seen[rule.ID()] {
+			t.Errorf("rule id %q is empty or duplicate", rule.ID())
+		}
+		seen[rule.ID()] = true
+	}
+
+	r := loadTestReport(t)
+	r.Company.Name = ""
+	for _, res := range Validate(r) {
+		if res.Field == "company.name" && (res.Rule != "company-name" || res.Code != 1020) {
+			t.Errorf("result = %+v", res)
+		}
+	}
+}
+
+func TestRegistryRegister(t *testing.T) {
+	reg := NewRegistry()
+	employees := NewRule("mentions-employees", 0, Warning, func(r *model.AnnualReport) []Finding {
+		if !strings.Contains(r.ManagementReport.BusinessDescription, "anställda") {
+			return []Finding{{"managementReport.businessDescription", "the number of employees is not mentioned"}}
+		}
+		return nil
+	})
+	if err := reg.Register(employees); err != nil {
+		t.Fatal(err)
+	}
+	if err := reg.Register(employees); err == nil {
+		t.Error("duplicate id: no error")
+	}
+	if err := reg.Register(NewRule("", 0, Error, nil)); err == nil {
+		t.Error("empty id: no error")
+	}
+	if reg.Rule("mentions-employees") != employees || len(reg.Rules()) != len(Rules())+1 {
+		t.Error("rule not registered")
+	}
+
+	v, err := reg.Validator(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, _ := v.Validate(loadTestReport(t))
+	if len(results) != 1 || results[0].Rule != "mentions-employees" || results[0].Severity != Warning {
+		t.Errorf("results = %v", results)
+	}
+	// The default registry is not affected.
+	if len(Validate(loadTestReport(t))) != 0 {
+		t.Error("custom rule ran in Validate")
+	}
+}
+
+func TestValidatorConfig(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`{
+		"rules": {
+			"company-name": {"enabled": false},
+			"agm-after-signing": {"severity": "error"}
+		},
+		"suppressions": [
+			{"rule": "fixed-asset-carrying-value", "field": "notes.fixedAssetNotes[0]", "justification": "Reclassified in the previous year"}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := NewValidator(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := loadTestReport(t)
+	r.Company.Name = ""
+	r.Certification.MeetingDate = "2017-02-01" // before the signing date
+	r.Certification.SigningDate = "2017-02-01"
+	*r.Notes.FixedAssetNotes[0].CarryingValue.Previous += 1
+	results, suppressed := v.Validate(r)
+
+	assertNoFieldError(t, results, "company.name")
+	assertHasFieldError(t, results, "certification.meetingDate")
+	for _, res := range results {
+		if res.Rule == "fixed-asset-carrying-value" {
+			t.Errorf("suppressed finding reported: %s", res)
+		}
+	}
+	if len(suppressed) != 1 || suppressed[0].Field != "notes.fixedAssetNotes[0].carryingValue.previous" ||
+		suppressed[0].Justification != "Reclassified in the previous year" {
+		t.Errorf("suppressed = %+v", suppressed)
+	}
+}
+
+func TestValidatorConfigErrors(t *testing.T) {
+	tests := []struct {
+		name, config, want string
+	}{
+		{"unknown rule", `{"rules": {"no-such-rule": {"enabled": false}}}`, `unknown rule "no-such-rule"`},
+		{"unknown severity", `{"rules": {"logo": {"severity": "fatal"}}}`, `unknown severity "fatal"`},
+		{"no justification", `{"suppressions": [{"rule": "logo"}]}`, "justification is missing"},
+		{"options of rule without options", `{"rules": {"logo": {"options": {"x": 1}}}}`, `rule "logo" takes no options`},
+		{"unknown option", `{"rules": {"control-balance-sheet": {"options": {"rate": 1}}}}`, `unknown field "rate"`},
+		{"suppressed unknown rule", `{"suppressions": [{"rule": "nope", "justification": "x"}]}`, `unknown rule "nope"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := ParseConfig([]byte(tt.config))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := NewValidator(cfg); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := ParseConfig([]byte(`{"rule": {}}`)); err == nil {
+		t.Error("misspelt key: no error")
+	}
+}
@@ -3,6 +3,7 @@ package validate
 import (
 	"encoding/json"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/redofri/redofri/pkg/model"
@@ -257,6 +258,68 @@ func TestValidEntryPoints(t *testing.T) {
 	}
 }
 
+// TestTaxonomyVersion checks that unknown versions are rejected and that
+// versions no longer accepted for new filings give a warning.
+func TestTaxonomyVersion(t *testing.T) {
+	r := loadTestReport(t)
+	r.Meta.TaxonomyVersion = "2019-01-01"
+	assertHasFieldError(t, Validate(r), "meta.taxonomyVersion")
+
+	r.Meta.TaxonomyVersion = "2021-10-31"
+	for _, res := range Validate(r) {
+		if res.Field == "meta.taxonomyVersion" {
+			t.Errorf("previous release: %s", res)
+		}
+	}
+
+	r.Meta.TaxonomyVersion = "2017-09-30"
+	results := Validate(r)
+	assertNoFieldError(t, results, "meta.taxonomyVersion")
+	var warned bool
+	for _, res := range results {
+		if res.Field == "meta.taxonomyVersion" && res.Severity == Warning && strings.Contains(res.Message, "no longer accepted") {
+			warned = true
+		}
+	}
+	if !warned {
+		t.Errorf("no warning for 2017-09-30: %v", results)
+	}
+}
+
+// TestAuditReportCombination checks the revisionsberättelse version
+// against the combinations of taxonomy reports.
+func TestAuditReportCombination(t *testing.T) {
+	r := loadTestReport(t)
+	r.AuditReport = &model.AuditReport{Embedded: true, TaxonomyVersion: "2017-09-30"}
+	assertNoFieldError(t, Validate(r), "auditReport.taxonomyVersion")
+
+	r.AuditReport.TaxonomyVersion = ""
+	assertHasFieldError(t, Validate(r), "auditReport.taxonomyVersion")
+
+	r.AuditReport.TaxonomyVersion = "2021-10-31"
+	assertHasFieldError(t, Validate(r), "auditReport.taxonomyVersion")
+
+	// A separately filed revisionsberättelse is not part of the document.
+	r.AuditReport.Embedded = false
+	assertNoFieldError(t, Validate(r), "auditReport.taxonomyVersion")
+}
+
+func TestCheckSubmission(t *testing.T) {
+	r := loadTestReport(t)
+	if results := CheckSubmission(r, "arsredovisning_komplett"); len(results) != 0 {
+		t.Errorf("valid submission: %v", results)
+	}
+	assertHasFieldError(t, CheckSubmission(r, "arsredovisning"), "documentType")
+
+	r.AuditReport = &model.AuditReport{}
+	assertHasFieldError(t, CheckSubmission(r, "arsredovisning_komplett"), "auditReport.embedded")
+	r.AuditReport = &model.AuditReport{Embedded: true, TaxonomyVersion: "2020-12-01"}
+	assertNoFieldError(t, CheckSubmission(r, "arsredovisning_komplett"), "auditReport.embedded")
+
+	r.Meta.TaxonomyVersion = "2017-09-30"
+	assertHasFieldError(t, CheckSubmission(r, "arsredovisning_komplett"), "meta.taxonomyVersion")
+}
+
 // --- Date ordering tests ---
 
 // TestFiscalYearExceeds18Months checks BV code 1046.
@@ -463,6 +526,236 @@ func TestFixedAssetNoteCarryingValueError(t *testing.T) {
 	assertHasFieldError(t, results, "notes.fixedAssetNotes[0].carryingValue.current")
 }
 
+// --- Shared fact tests ---
+
+// TestSharedFacts checks fields that are written as the same fact.
+func TestSharedFacts(t *testing.T) {
+	shared := func(r *model.AnnualReport) []Result {
+		var found []Result
+		for _, res := range Validate(r) {
+			if res.Rule == "shared-facts" {
+				found = append(found, res)
+			}
+		}
+		return found
+	}
+	if got := shared(loadTestReport(t)); len(got) != 0 {
+		t.Fatalf("valid report: %v", got)
+	}
+
+	r := loadTestReport(t)
+	wrong := int64(1000000)
+	r.ManagementReport.ProfitDisposition.RetainedEarnings = &wrong
+	got := shared(r)
+	if len(got) != 1 || got[0].Field != "managementReport.profitDisposition.retainedEarnings" ||
+		!strings.Contains(got[0].Message, "se-gen-base:BalanseratResultat@balans0 is 1011000 in managementReport.equityChanges.closingRetainedEarnings") {
+		t.Errorf("retained earnings: %v", got)
+	}
+
+	r = loadTestReport(t)
+	result := int64(1275000)
+	r.IncomeStatement.NetResult.Current = &result
+	if got := shared(r); len(got) != 1 || !strings.Contains(got[0].Message, "the year's result") {
+		t.Errorf("net result: %v", got)
+	}
+
+	r = loadTestReport(t)
+	carrying := int64(1600000)
+	r.Notes.FixedAssetNotes[0].CarryingValue.Current = &carrying
+	if got := shared(r); len(got) != 1 || got[0].Field != "notes.fixedAssetNotes[0].carryingValue.current" ||
+		!strings.Contains(got[0].Message, "balanceSheet.assets.fixedAssets.tangible.buildingsAndLand.current") {
+		t.Errorf("carrying value: %v", got)
+	}
+}
+
+// --- Continuity tests ---
+
+// TestContinuity checks the rules connecting the years of a report.
+func TestContinuity(t *testing.T) {
+	tests := []struct {
+		name, rule, field string
+		change            func(r *model.AnnualReport)
+	}{
+		{"fixed asset opening", "fixed-asset-opening", "notes.fixedAssetNotes[1].openingDepreciation.current",
+			func(r *model.AnnualReport) { *r.Notes.FixedAssetNotes[1].OpeningDepreciation.Current += 1000 }},
+		{"equity changes opening", "equity-changes-opening", "managementReport.equityChanges.openingRetainedEarnings",
+			func(r *model.AnnualReport) {
+				*r.BalanceSheet.EquityAndLiabilities.Equity.RetainedEarnings.Previous += 1000
+			}},
+		{"depreciation notes", "depreciation-notes", "incomeStatement.expenses.depreciationAmortization.previous",
+			func(r *model.AnnualReport) { *r.Notes.FixedAssetNotes[2].YearDepreciation.Previous += 1000 }},
+		{"overview net sales", "multi-year-overview-income", "managementReport.multiYearOverview.years[0].netSales",
+			func(r *model.AnnualReport) { *r.ManagementReport.MultiYearOverview.Years[0].NetSales = 2651000 }},
+		{"overview result", "multi-year-overview-income", "managementReport.multiYearOverview.years[1].resultAfterFinancialItems",
+			func(r *model.AnnualReport) {
+				*r.ManagementReport.MultiYearOverview.Years[1].ResultAfterFinancialItems = 1180000
+			}},
+		{"solidity", "solidity", "managementReport.multiYearOverview.years[0].solidity",
+			func(r *model.AnnualReport) { *r.ManagementReport.MultiYearOverview.Years[0].Solidity = "30.8" }},
+		// Within rounding.
+		{"overview net sales in tkr", "", "",
+			func(r *model.AnnualReport) { *r.ManagementReport.MultiYearOverview.Years[0].NetSales = 2650999 }},
+		{"income statement in tkr", "", "",
+			func(r *model.AnnualReport) { *r.IncomeStatement.Revenue.NetSales.Previous = 2250400 }},
+		{"equal amounts", "", "",
+			func(r *model.AnnualReport) {
+				*r.IncomeStatement.Revenue.NetSales.Current = 2650600
+				*r.ManagementReport.MultiYearOverview.Years[0].NetSales = 2650600
+			}},
+		{"rounded amounts", "", "",
+			func(r *model.AnnualReport) {
+				*r.IncomeStatement.Revenue.NetSales.Current = 2649600
+				*r.ManagementReport.MultiYearOverview.Years[0].NetSales = 2650000
+			}},
+		{"solidity with two decimals", "", "",
+			func(r *model.AnnualReport) { *r.ManagementReport.MultiYearOverview.Years[1].Solidity = "39,07" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := loadTestReport(t)
+			tt.change(r)
+			var found []Result
+			for _, res := range Validate(r) {
+				switch res.Rule {
+				case "fixed-asset-opening", "equity-changes-opening", "depreciation-notes", "multi-year-overview-income", "solidity":
+					found = append(found, res)
+				}
+			}
+			if tt.rule == "" {
+				if len(found) != 0 {
+					t.Errorf("results = %v", found)
+				}
+				return
+			}
+			if len(found) != 1 || found[0].Rule != tt.rule || found[0].Field != tt.field {
+				t.Errorf("results = %v, want %s on %s", found, tt.rule, tt.field)
+			}
+		})
+	}
+}
+
+// --- Dividend tests ---
+
+// TestDividendExceedsEquity checks a dividend exceeding fritt eget kapital
+// and leaving restricted equity uncovered.
+func TestDividendExceedsEquity(t *testing.T) {
+	r := loadTestReport(t)
+	div, cf := int64(2300000), int64(-15000)
+	r.ManagementReport.ProfitDisposition.Dividend = &div
+	r.ManagementReport.ProfitDisposition.CarriedForward = &cf
+	results := Validate(r)
+	assertHasFieldError(t, results, "managementReport.profitDisposition.dividend")
+	var warned bool
+	for _, res := range results {
+		if res.Rule == "dividend-restricted-equity" && !strings.Contains(res.Message, "short by 15000") {
+			t.Errorf("message = %q", res.Message)
+		}
+		warned = warned || (res.Rule == "dividend-free-equity" && res.Severity == Warning)
+	}
+	if !warned {
+		t.Errorf("no dividend-free-equity warning: %v", results)
+	}
+}
+
+func TestDividendWithoutBoardStatement(t *testing.T) {
+	r := loadTestReport(t)
+	r.ManagementReport.BoardDividendStatement = ""
+	results := Validate(r)
+	if len(results) != 1 || results[0].Rule != "dividend-board-statement" || results[0].Severity != Warning {
+		t.Errorf("results = %v", results)
+	}
+
+	r.ManagementReport.ProfitDisposition.Dividend = nil
+	for _, res := range Validate(r) {
+		if res.Rule == "dividend-board-statement" {
+			t.Errorf("no dividend: %s", res)
+		}
+	}
+}
+
+// TestDividendPaid checks last year's dividend against the change in equity.
+func TestDividendPaid(t *testing.T) {
+	r := loadTestReport(t)
+	wrong := int64(1000000)
+	r.ManagementReport.EquityChanges.DividendNetIncome = &wrong
+	results := Validate(r)
+	if len(results) != 1 || results[0].Rule != "dividend-paid" ||
+		!strings.Contains(results[0].Message, "= 1099000") {
+		t.Errorf("results = %v", results)
+	}
+
+	r.ManagementReport.EquityChanges.DividendNetIncome = nil
+	results = Validate(r)
+	if len(results) != 1 || results[0].Field != "managementReport.equityChanges.dividendNetIncome" ||
+		!strings.Contains(results[0].Message, "only movements in equity") {
+		t.Errorf("missing dividend: results = %v", results)
+	}
+
+	// A share issue is another movement in equity, not a dividend.
+	r = loadTestReport(t)
+	r.ManagementReport.EquityChanges.DividendNetIncome = &wrong
+	eq := &r.BalanceSheet.EquityAndLiabilities.Equity
+	*eq.TotalRestrictedEquity.Previous -= 50000
+	*eq.ShareCapital.Previous -= 50000
+	for _, res := range Validate(r) {
+		if res.Rule == "dividend-paid" {
+			t.Errorf("restricted equity changed: %s", res)
+		}
+	}
+}
+
+// --- Kontrollbalansräkning tests ---
+
+func TestControlBalanceSheet(t *testing.T) {
+	r := loadTestReport(t)
+	equity := int64(40000)
+	r.BalanceSheet.EquityAndLiabilities.Equity.TotalEquity.Current = &equity
+
+	var got []Result
+	for _, res := range Validate(r) {
+		if res.Rule == "control-balance-sheet" {
+			got = append(got, res)
+		}
+	}
+	if len(got) != 2 || got[0].Severity != Warning || !strings.Contains(got[0].Message, "is 40.0% of the registered share capital (100000)") ||
+		got[1].Field != "managementReport.significantEvents" {
+		t.Fatalf("results = %v", got)
+	}
+
+	r.ManagementReport.SignificantEvents = "Styrelsen har upprättat en kontrollbalansräkning."
+	if got := controlBalanceFindings(t, r, nil); len(got) != 1 {
+		t.Errorf("mentioned in management report: %v", got)
+	}
+
+	// 40000 + 290000 × (1 - 0.22) is above half the share capital.
+	if got := controlBalanceFindings(t, r, json.RawMessage(`{"untaxedReserves": true}`)); len(got) != 0 {
+		t.Errorf("with untaxed reserves: %v", got)
+	}
+	equity = -200000
+	got = controlBalanceFindings(t, r, json.RawMessage(`{"untaxedReserves": true, "taxRate": 20.6}`))
+	if len(got) != 1 || !strings.Contains(got[0].Message, "less 20.6% tax (30260)") {
+		t.Errorf("with tax rate: %v", got)
+	}
+}
+
+// controlBalanceFindings returns the findings of control-balance-sheet
+// configured with options.
+func controlBalanceFindings(t *testing.T, r *model.AnnualReport, options json.RawMessage) []Result {
+	t.Helper()
+	v, err := NewValidator(&Config{Rules: map[string]RuleConfig{"control-balance-sheet": {Options: options}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var found []Result
+	results, _ := v.Validate(r)
+	for _, res := range results {
+		if res.Rule == "control-balance-sheet" {
+			found = append(found, res)
+		}
+	}
+	return found
+}
+
 // --- Invalid date format tests ---
 
 // TestInvalidDateFormat checks that malformed dates are caught.
@@ -509,14 +802,14 @@ func TestSeverityString(t *testing.T) {
 // --- Result.String() test ---
 
 func TestResultString(t *testing.T) {
-	r := Result{Error, 1020, "company.name", "company name is missing"}
+	r := Result{Severity: Error, Code: 1020, Field: "company.name", Message: "company name is missing"}
 	got := r.String()
 	want := "ERROR [BV 1020]: company.name: company name is missing"
 	if got != want {
 		t.Errorf("Result.String() = %q, want %q", got, want)
 	}
 
-	r2 := Result{Warning, 0, "field", "msg"}
+	r2 := Result{Severity: Warning, Field: "field", Message: "msg"}
 	got2 := r2.String()
 	want2 := "WARN: field: msg"
 	if got2 != want2 {
@@ -581,3 +874,24 @@ func assertNoFieldError(t *testing.T, results []Result, field string) {
 		}
 	}
 }
+
+// TestInvalidLogo checks that an unsupported logo format is reported.
+func TestInvalidLogo(t *testing.T) {
+	r := loadTestReport(t)
+	r.Company.Logo = &model.Image{Data: []byte("not an image")}
+	results := Validate(r)
+	assertHasFieldError(t, results, "company.logo")
+}
+
+// TestUnsupportedLanguage checks that a language without labels mentions the fallback.
+func TestUnsupportedLanguage(t *testing.T) {
+	r := loadTestReport(t)
+	r.Meta.Language = "de"
+	results := Validate(r)
+	for _, res := range results {
+		if res.Code == 1116 && strings.Contains(res.Message, "Swedish labels will be used") {
+			return
+		}
+	}
+	t.Errorf("expected 1116 warning about missing labels, got %v", results)
+}